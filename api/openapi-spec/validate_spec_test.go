@@ -184,4 +184,30 @@ var _ = Describe("lxkns OpenAPI specification", func() {
 		Expect(json.Unmarshal(j, disco2)).To(Succeed())
 	})
 
+	It("validates a DiscoveryResult with namespace details", func() {
+		allns := discover.Namespaces(discover.WithStandardDiscovery(),
+			discover.WithIPCObjects(), discover.WithNetworkInterfaces(), discover.WithSockets())
+		disco := apitypes.NewDiscoveryResult(apitypes.WithResult(allns))
+		j, err := json.Marshal(disco)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(validate(lxknsapispec, "DiscoveryResult", j)).To(Succeed(), string(j))
+		Expect(validate(lxknsapispec, "NetworkTopology",
+			must(json.Marshal(apitypes.NetworkTopology(allns.NetworkTopology))))).To(Succeed())
+		Expect(validate(lxknsapispec, "UnixSocketGraph",
+			must(json.Marshal(apitypes.UnixSocketGraph(allns.UnixSocketGraph))))).To(Succeed())
+	})
+
+	It("validates Sockets", func() {
+		allns := discover.Namespaces(discover.WithStandardDiscovery(), discover.WithSockets())
+		j, err := json.Marshal(apitypes.NewSockets(allns))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validate(lxknsapispec, "Sockets", j)).To(Succeed(), string(j))
+	})
+
 })
+
+func must[T any](v T, err error) T {
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return v
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"github.com/thediveo/lxkns/audit"
+)
+
+// AuditFindings is a list of audit findings, with each finding referencing
+// the offending container by its identifier, name, and type.
+//
+// AuditFindings is only a marshalling and unmarshalling vehicle:
+// unmarshalling AuditFindings doesn't recreate the containers, but only their
+// identifiers.
+type AuditFindings []AuditFinding
+
+// AuditFinding is an [audit.Finding] with a reference to the offending
+// container instead of the container itself.
+type AuditFinding struct {
+	RuleID    string         `json:"rule-id"`   // stable identifier of the violated rule.
+	Severity  audit.Severity `json:"severity"`  // severity of the violation.
+	Message   string         `json:"message"`   // human-readable details.
+	Container AuditContainer `json:"container"` // offending container.
+}
+
+// AuditContainer identifies the offending container of an audit finding.
+type AuditContainer struct {
+	ID   string `json:"id"`   // container identifier.
+	Name string `json:"name"` // container name.
+	Type string `json:"type"` // container type, such as "docker.com".
+}
+
+// NewAuditFindings returns the specified audit findings in their order,
+// ready for marshalling.
+func NewAuditFindings(findings audit.Findings) AuditFindings {
+	auditfindings := AuditFindings{}
+	for _, finding := range findings {
+		auditfinding := AuditFinding{
+			RuleID:   finding.RuleID,
+			Severity: finding.Severity,
+			Message:  finding.Message,
+		}
+		if cntr := finding.Container; cntr != nil {
+			auditfinding.Container = AuditContainer{
+				ID:   cntr.ID,
+				Name: cntr.Name,
+				Type: cntr.Type,
+			}
+		}
+		auditfindings = append(auditfindings, auditfinding)
+	}
+	return auditfindings
+}
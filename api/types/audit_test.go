@@ -0,0 +1,61 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/thediveo/lxkns/audit"
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("audit findings", func() {
+
+	It("marshals and unmarshals findings with their containers", func() {
+		findings := NewAuditFindings(audit.Findings{
+			{
+				RuleID:    audit.RuleHostNetNamespace,
+				Severity:  audit.SeverityHigh,
+				Message:   "container shares the initial network namespace",
+				Container: &model.Container{ID: "deadbeef", Name: "fooserver", Type: "docker.com"},
+			},
+		})
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Container).To(Equal(AuditContainer{
+			ID: "deadbeef", Name: "fooserver", Type: "docker.com"}))
+
+		j, err := json.Marshal(findings)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[
+			{"rule-id":"host-net-namespace","severity":"high",
+			 "message":"container shares the initial network namespace",
+			 "container":{"id":"deadbeef","name":"fooserver","type":"docker.com"}}
+		]`))
+
+		var f AuditFindings
+		Expect(json.Unmarshal(j, &f)).To(Succeed())
+		Expect(f).To(Equal(findings))
+	})
+
+	It("marshals no findings as empty list", func() {
+		j, err := json.Marshal(NewAuditFindings(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[]`))
+	})
+
+})
@@ -0,0 +1,160 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+)
+
+// ResultDiff is the JSON representation of a [discover.ResultDiff], telling
+// what has changed between two discovery results. As a discovery diff refers
+// to objects from two different discovery results, ResultDiff represents
+// namespaces, processes, and containers in a self-contained form, where
+// references to other objects are replaced by their identifiers. In
+// consequence, ResultDiff can be marshalled as well as unmarshalled, but it
+// cannot be converted back into a [discover.ResultDiff].
+type ResultDiff struct {
+	Namespaces       Changes[NamespaceUnMarshal]            `json:"namespaces"`
+	Processes        Changes[DiffProcess]                   `json:"processes"`
+	Containers       Changes[DiffContainer]                 `json:"containers"`
+	ContainerEngines Changes[*model.ContainerEngine]        `json:"container-engines"`
+	Mounts           map[uint64]Changes[*mounts.MountPoint] `json:"mounts"` // per mount namespace ID (inode number only).
+}
+
+// Changes is the JSON representation of [discover.Changes].
+type Changes[T any] struct {
+	Added   []T         `json:"added"`
+	Removed []T         `json:"removed"`
+	Changed []Change[T] `json:"changed"`
+}
+
+// Change is the JSON representation of [discover.Change].
+type Change[T any] struct {
+	Old T `json:"old"`
+	New T `json:"new"`
+}
+
+// DiffProcess represents a process in a discovery diff, referencing the
+// namespaces it is joined to by their types and IDs (inode numbers only).
+type DiffProcess struct {
+	*model.Process
+	Namespaces map[string]uint64 `json:"namespaces"`
+	Cmdline    []string          `json:"cmdline"` // ensure to never marshal nil=null.
+}
+
+// DiffContainer represents a container in a discovery diff, referencing its
+// managing container engine by the engine's ID.
+type DiffContainer struct {
+	*model.Container
+	EngineID string       `json:"engine-id"`
+	Labels   model.Labels `json:"labels"` // ensure to never marshal nil=null.
+}
+
+// NewResultDiff returns the JSON representation of the specified discovery
+// diff.
+func NewResultDiff(d *discover.ResultDiff) *ResultDiff {
+	rd := &ResultDiff{
+		Namespaces:       convertChanges(d.Namespaces, newDiffNamespace),
+		Processes:        convertChanges(d.Processes, newDiffProcess),
+		Containers:       convertChanges(d.Containers, newDiffContainer),
+		ContainerEngines: convertChanges(d.ContainerEngines, func(e *model.ContainerEngine) *model.ContainerEngine { return e }),
+		Mounts:           map[uint64]Changes[*mounts.MountPoint]{},
+	}
+	for mntnsid, changes := range d.Mounts {
+		rd.Mounts[mntnsid.Ino] = convertChanges(changes, func(m *mounts.MountPoint) *mounts.MountPoint { return m })
+	}
+	return rd
+}
+
+// convertChanges converts the objects in the specified changes into their
+// JSON representations, ensuring to never marshal nil=null lists.
+func convertChanges[T, J any](c discover.Changes[T], convert func(T) J) Changes[J] {
+	jc := Changes[J]{
+		Added:   make([]J, 0, len(c.Added)),
+		Removed: make([]J, 0, len(c.Removed)),
+		Changed: make([]Change[J], 0, len(c.Changed)),
+	}
+	for _, obj := range c.Added {
+		jc.Added = append(jc.Added, convert(obj))
+	}
+	for _, obj := range c.Removed {
+		jc.Removed = append(jc.Removed, convert(obj))
+	}
+	for _, change := range c.Changed {
+		jc.Changed = append(jc.Changed, Change[J]{
+			Old: convert(change.Old),
+			New: convert(change.New),
+		})
+	}
+	return jc
+}
+
+// newDiffNamespace returns the self-contained representation of a namespace,
+// similar to the namespaces in a marshalled discovery result.
+func newDiffNamespace(ns model.Namespace) NamespaceUnMarshal {
+	aux := NamespaceUnMarshal{
+		ID:           ns.ID().Ino,
+		Type:         ns.Type().Name(),
+		Ref:          ns.Ref(),
+		Leaders:      ns.LeaderPIDs(),
+		LooseThreads: ns.LooseThreadIDs(),
+	}
+	if owner := ns.Owner(); owner != nil {
+		aux.Owner = owner.(model.Namespace).ID().Ino
+	}
+	if hns, ok := ns.(model.Hierarchy); ok {
+		if parent := hns.Parent(); parent != nil {
+			aux.Parent = parent.(model.Namespace).ID().Ino
+		}
+	}
+	if uns, ok := ns.(model.Ownership); ok {
+		aux.UserUID = uns.UID()
+	}
+	return aux
+}
+
+func newDiffProcess(proc *model.Process) DiffProcess {
+	dp := DiffProcess{
+		Process:    proc,
+		Namespaces: map[string]uint64{},
+		Cmdline:    proc.Cmdline,
+	}
+	if dp.Cmdline == nil {
+		dp.Cmdline = []string{}
+	}
+	for nsidx, ns := range proc.Namespaces {
+		if ns == nil {
+			continue
+		}
+		dp.Namespaces[model.TypesByIndex[nsidx].Name()] = ns.ID().Ino
+	}
+	return dp
+}
+
+func newDiffContainer(c *model.Container) DiffContainer {
+	dc := DiffContainer{
+		Container: c,
+		Labels:    c.Labels,
+	}
+	if dc.Labels == nil {
+		dc.Labels = model.Labels{}
+	}
+	if c.Engine != nil {
+		dc.EngineID = c.Engine.ID
+	}
+	return dc
+}
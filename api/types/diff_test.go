@@ -0,0 +1,92 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("discovery diff JSON", func() {
+
+	It("marshals an empty diff without nulls", func() {
+		j, err := json.Marshal(NewResultDiff(discover.Diff(nil, nil)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`{
+			"namespaces": {"added": [], "removed": [], "changed": []},
+			"processes": {"added": [], "removed": [], "changed": []},
+			"containers": {"added": [], "removed": [], "changed": []},
+			"container-engines": {"added": [], "removed": [], "changed": []},
+			"mounts": {}
+		}`))
+	})
+
+	It("marshals and unmarshals a diff", func() {
+		netnsid := species.NamespaceID{Dev: 1, Ino: 4026531992}
+		netns := namespaces.NewWithSimpleRef(species.CLONE_NEWNET, netnsid, "/proc/42/ns/net")
+		proc := &model.Process{
+			PID:  42,
+			PPID: 1,
+			ProTaskCommon: model.ProTaskCommon{
+				Name:      "foo",
+				Starttime: 666,
+			},
+		}
+		proc.Namespaces[model.NetNS] = netns
+		engine := &model.ContainerEngine{ID: "engine-1", Type: "docker.com"}
+		container := &model.Container{ID: "cntr-1", Name: "sleepy", PID: 42}
+		engine.AddContainer(container)
+
+		after := &discover.Result{
+			Namespaces:       *model.NewAllNamespaces(),
+			Processes:        model.ProcessTable{42: proc},
+			Containers:       model.Containers{container},
+			ContainerEngines: []*model.ContainerEngine{engine},
+		}
+		after.Namespaces[model.NetNS][netnsid] = netns
+
+		j, err := json.Marshal(NewResultDiff(discover.Diff(nil, after)))
+		Expect(err).NotTo(HaveOccurred())
+
+		var rd ResultDiff
+		Expect(json.Unmarshal(j, &rd)).To(Succeed())
+		Expect(rd.Namespaces.Added).To(HaveExactElements(And(
+			HaveField("ID", netnsid.Ino),
+			HaveField("Type", "net"),
+			HaveField("Ref", model.NamespaceRef{"/proc/42/ns/net"}),
+		)))
+		Expect(rd.Processes.Added).To(HaveExactElements(And(
+			HaveField("PID", model.PIDType(42)),
+			HaveField("Starttime", uint64(666)),
+			HaveField("Namespaces", HaveKeyWithValue("net", netnsid.Ino)),
+			HaveField("Cmdline", BeEmpty()),
+		)))
+		Expect(rd.Containers.Added).To(HaveExactElements(And(
+			HaveField("Name", "sleepy"),
+			HaveField("EngineID", "engine-1"),
+		)))
+		Expect(rd.ContainerEngines.Added).To(HaveExactElements(
+			HaveField("Type", "docker.com")))
+		Expect(rd.Processes.Removed).To(BeEmpty())
+	})
+
+})
@@ -97,6 +97,10 @@ const (
 	FieldContainerEngines = "container-engines"
 	FieldContainerGroups  = "container-groups"
 	FieldOnlineCPUs       = "cpus-online"
+	FieldNetworkTopology  = "network-topology"
+	FieldUnixSocketGraph  = "unix-socket-graph"
+	FieldErrors           = "errors"
+	FieldWarnings         = "warnings"
 )
 
 // NewDiscoveryResult returns a discovery result object ready for unmarshalling
@@ -150,6 +154,13 @@ func NewDiscoveryResult(opts ...NewDiscoveryResultOption) *DiscoveryResult {
 	} else {
 		dr.Fields[FieldOnlineCPUs] = &cpus.List{}
 	}
+	// ...and the network topology across network namespaces, if any.
+	dr.Fields[FieldNetworkTopology] = (*NetworkTopology)(&dr.DiscoveryResult.NetworkTopology)
+	// ...as well as the graph of connected UNIX domain sockets.
+	dr.Fields[FieldUnixSocketGraph] = (*UnixSocketGraph)(&dr.DiscoveryResult.UnixSocketGraph)
+	// ...and finally the problems encountered during discovery.
+	dr.Fields[FieldErrors] = (*Issues)(&dr.DiscoveryResult.Errors)
+	dr.Fields[FieldWarnings] = (*Issues)(&dr.DiscoveryResult.Warnings)
 	// Done. Phew.
 	return dr
 }
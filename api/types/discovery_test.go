@@ -17,8 +17,10 @@ package types
 import (
 	"encoding/json"
 
+	"github.com/thediveo/lxkns/discover"
 	"github.com/thediveo/lxkns/model"
 	. "github.com/thediveo/lxkns/nstest/gmodel"
+	"github.com/thediveo/lxkns/species"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -33,16 +35,30 @@ var _ = Describe("discovery result JSON", func() {
 		Expect(j).To(MatchJSON(`{
 			"from-procs": true,
 			"from-tasks": false,
+			"via-pidfds": false,
 			"from-fds": false,
 			"from-bindmounts": false,
+			"from-nsfs": false,
 			"with-hierarchy": true,
 			"with-ownership": true,
 			"with-freezer": true,
 			"with-mounts": true,
 			"with-socket-processes": false,
+			"with-uts-names": false,
+			"with-cgroup-roots": false,
+			"with-ipc-objects": false,
+			"with-network-interfaces": false,
+			"with-sockets": false,
 			"with-affinity-scheduling": false,
 			"with-task-affinity-scheduling": false,
+			"with-capabilities": false,
+			"with-task-capabilities": false,
+			"with-credentials": false,
+			"with-task-credentials": false,
+			"with-lsm-contexts": false,
 			"labels": {},
+			"concurrency": 0,
+			"procfs-root": "",
 			"scanned-namespace-types": [
 			  "time",
 			  "mnt",
@@ -156,4 +172,57 @@ var _ = Describe("discovery result JSON", func() {
 		Expect(drcm.Groups.groupRefIDs).To(HaveLen(len(allnscm.Groups.groupRefIDs)))
 	})
 
+	It("marshals and unmarshals the network topology", func() {
+		netnsid := species.NamespaceIDfromInode(123)
+		peernetnsid := species.NamespaceIDfromInode(456)
+		topo := model.NetworkTopology{
+			Nodes: []model.NetworkTopologyNode{{
+				NetworkInterfaceRef: model.NetworkInterfaceRef{NetNS: netnsid, Index: 2},
+				Name:                "eth0",
+				Kind:                "veth",
+			}},
+			Edges: []model.NetworkTopologyEdge{{
+				From:     model.NetworkInterfaceRef{NetNS: netnsid, Index: 2},
+				To:       model.NetworkInterfaceRef{NetNS: peernetnsid, Index: 5},
+				Relation: model.NetworkRelationPeer,
+			}},
+		}
+		j, err := json.Marshal(NewDiscoveryResult(WithResult(&discover.Result{
+			NetworkTopology: topo,
+		})))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(
+			`"network-topology":{"nodes":[{"index":2,"name":"eth0","kind":"veth","netns":123}],` +
+				`"edges":[{"from":{"netns":123,"index":2},"to":{"netns":456,"index":5},"relation":"peer"}]}`))
+
+		dr := NewDiscoveryResult()
+		Expect(json.Unmarshal(j, dr)).To(Succeed())
+		Expect(dr.Result().NetworkTopology).To(Equal(topo))
+	})
+
+	It("marshals and unmarshals the UNIX domain socket graph", func() {
+		graph := model.UnixSocketGraph{
+			Nodes: []model.UnixSocketNode{{
+				NetNS: species.NamespaceIDfromInode(123),
+				Socket: model.Socket{
+					Protocol: model.SocketProtocolUNIX,
+					Inode:    42,
+					Peer:     666,
+					PIDs:     []model.PIDType{1},
+				},
+			}},
+			Edges: []model.UnixSocketEdge{{From: 42, To: 666}},
+		}
+		j, err := json.Marshal(NewDiscoveryResult(WithResult(&discover.Result{
+			UnixSocketGraph: graph,
+		})))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"unix-socket-graph":{"nodes":[{"protocol":"unix",`))
+		Expect(string(j)).To(ContainSubstring(`"netns":123}]`))
+
+		dr := NewDiscoveryResult()
+		Expect(json.Unmarshal(j, dr)).To(Succeed())
+		Expect(dr.Result().UnixSocketGraph).To(Equal(graph))
+	})
+
 })
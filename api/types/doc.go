@@ -23,6 +23,14 @@ discovery service:
 	err := json.Unmarshal(jsondata, disco)
 	allns := disco.Result()
 
+# Discovery Diffs
+
+To tell API users what has changed between two discovery results, marshal the
+[discover.ResultDiff] returned by [discover.Diff] using [NewResultDiff]:
+
+	diff := discover.Diff(previous, allns)
+	err := json.Marshal(NewResultDiff(diff))
+
 # Process Table
 
 Process Tables of type [model.ProcessTable] are un/marshalled from/to JSON with
@@ -0,0 +1,88 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"syscall"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+)
+
+// Issues is the (digital) twin of the errors or warnings of a
+// [discover.Result], adding marshalling and unmarshalling to and from JSON.
+// Unmarshalling Issues doesn't recreate the original underlying errors, but
+// only their textual descriptions.
+type Issues []discover.Issue
+
+// issue is the JSON representation of a [discover.Issue].
+type issue struct {
+	Source string        `json:"source"`          // discovery step.
+	PID    model.PIDType `json:"pid,omitempty"`   // PID of the process involved, if any.
+	NSID   uint64        `json:"nsid,omitempty"`  // namespace involved, if any.
+	Path   string        `json:"path,omitempty"`  // path involved, if any.
+	Errno  uint          `json:"errno,omitempty"` // OS-level error number, if any.
+	Error  string        `json:"error,omitempty"` // description of the underlying error.
+}
+
+// MarshalJSON emits discovery issues as a JSON array of issue objects, with
+// the underlying errors reduced to their textual descriptions.
+func (i Issues) MarshalJSON() ([]byte, error) {
+	issues := make([]issue, 0, len(i))
+	for _, iss := range i {
+		aux := issue{
+			Source: string(iss.Source),
+			PID:    iss.PID,
+			NSID:   iss.Namespace.Ino,
+			Path:   iss.Path,
+			Errno:  uint(iss.Errno),
+		}
+		if iss.Err != nil {
+			aux.Error = iss.Err.Error()
+		}
+		issues = append(issues, aux)
+	}
+	return json.Marshal(issues)
+}
+
+// UnmarshalJSON unmarshals discovery issues from a JSON array of issue
+// objects.
+func (i *Issues) UnmarshalJSON(data []byte) error {
+	var issues []issue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return err
+	}
+	*i = nil
+	for _, aux := range issues {
+		iss := discover.Issue{
+			Source:    discover.IssueSource(aux.Source),
+			PID:       aux.PID,
+			Namespace: species.NoneID,
+			Path:      aux.Path,
+			Errno:     syscall.Errno(aux.Errno),
+		}
+		if aux.NSID != 0 {
+			iss.Namespace = species.NamespaceIDfromInode(aux.NSID)
+		}
+		if aux.Error != "" {
+			iss.Err = errors.New(aux.Error)
+		}
+		*i = append(*i, iss)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"io/fs"
+	"syscall"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("discovery issues", func() {
+
+	It("marshals and unmarshals issues", func() {
+		issues := Issues{
+			{
+				Source:    discover.SourceBindmounts,
+				PID:       42,
+				Namespace: species.NamespaceIDfromInode(666),
+				Path:      "/proc/42/ns/mnt",
+				Errno:     syscall.EPERM,
+				Err:       syscall.EPERM,
+			},
+			{
+				Source:    discover.SourceProc,
+				Namespace: species.NoneID,
+				Err:       fs.ErrNotExist,
+			},
+		}
+		j, err := json.Marshal(issues)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[
+			{"source":"bind-mounts","pid":42,"nsid":666,"path":"/proc/42/ns/mnt",
+			 "errno":1,"error":"operation not permitted"},
+			{"source":"proc","error":"file does not exist"}
+		]`))
+
+		var i Issues
+		Expect(json.Unmarshal(j, &i)).To(Succeed())
+		Expect(i).To(HaveLen(2))
+		Expect(i[0]).To(And(
+			HaveField("Source", discover.SourceBindmounts),
+			HaveField("PID", BeEquivalentTo(42)),
+			HaveField("Namespace", species.NamespaceIDfromInode(666)),
+			HaveField("Path", "/proc/42/ns/mnt"),
+			HaveField("Errno", syscall.EPERM),
+			HaveField("Err", MatchError("operation not permitted"))))
+		Expect(i[1].Namespace).To(Equal(species.NoneID))
+		Expect(i[1].Err).To(MatchError("file does not exist"))
+	})
+
+	It("marshals no issues as empty list", func() {
+		j, err := json.Marshal(Issues(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[]`))
+	})
+
+	It("carries issues in discovery results", func() {
+		result := &discover.Result{
+			Errors:   []discover.Issue{{Source: discover.SourceNsfs, Err: syscall.EPERM}},
+			Warnings: []discover.Issue{{Source: discover.SourceProc, PID: 42}},
+		}
+		j, err := json.Marshal(NewDiscoveryResult(WithResult(result)))
+		Expect(err).NotTo(HaveOccurred())
+
+		dr := NewDiscoveryResult()
+		Expect(json.Unmarshal(j, dr)).To(Succeed())
+		Expect(dr.Result().Errors).To(ConsistOf(And(
+			HaveField("Source", discover.SourceNsfs),
+			HaveField("Err", MatchError("operation not permitted")))))
+		Expect(dr.Result().Warnings).To(ConsistOf(
+			HaveField("PID", BeEquivalentTo(42))))
+	})
+
+})
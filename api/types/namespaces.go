@@ -122,15 +122,26 @@ func (d *NamespacesDict) UnmarshalJSON(data []byte) error {
 // after unmarshalling (such as the list of children and the owned
 // namespaces).
 type NamespaceUnMarshal struct {
-	ID           uint64             `json:"nsid"`                    // namespace ID.
-	Type         string             `json:"type"`                    // "net", "user", et cetera...
-	Owner        uint64             `json:"owner,omitempty"`         // namespace ID of owning user namespace.
-	Ref          model.NamespaceRef `json:"reference,omitempty"`     // file system path reference(s).
-	Leaders      []model.PIDType    `json:"leaders,omitempty"`       // list of leader PIDs attached to this namespace.
-	LooseThreads []model.PIDType    `json:"loose-threads,omitempty"` // list of "loose" tasks attached to this namespace.
-	Parent       uint64             `json:"parent,omitempty"`        // PID/user: namespace ID of parent namespace.
-	UserUID      int                `json:"user-id,omitempty"`       // user: owner's user ID (UID).
-	UserName     string             `json:"user-name,omitempty"`     // user: name.
+	ID                uint64              `json:"nsid"`                         // namespace ID.
+	Type              string              `json:"type"`                         // "net", "user", et cetera...
+	Owner             uint64              `json:"owner,omitempty"`              // namespace ID of owning user namespace.
+	Ref               model.NamespaceRef  `json:"reference,omitempty"`          // file system path reference(s).
+	Leaders           []model.PIDType     `json:"leaders,omitempty"`            // list of leader PIDs attached to this namespace.
+	LooseThreads      []model.PIDType     `json:"loose-threads,omitempty"`      // list of "loose" tasks attached to this namespace.
+	Parent            uint64              `json:"parent,omitempty"`             // PID/user: namespace ID of parent namespace.
+	UserUID           int                 `json:"user-id,omitempty"`            // user: owner's user ID (UID).
+	UserName          string              `json:"user-name,omitempty"`          // user: name.
+	UIDMap            model.IDMap         `json:"uid-map,omitempty"`            // user: user ID mapping.
+	GIDMap            model.IDMap         `json:"gid-map,omitempty"`            // user: group ID mapping.
+	Setgroups         model.Setgroups     `json:"setgroups,omitempty"`          // user: setgroups(2) permission.
+	ClockOffsets      *model.ClockOffsets `json:"clock-offsets,omitempty"`      // time: clock offsets.
+	Nodename          string              `json:"nodename,omitempty"`           // uts: host (node) name.
+	Domainname        string              `json:"domainname,omitempty"`         // uts: NIS domain name.
+	CgroupRoot        string              `json:"cgroup-root,omitempty"`        // cgroup: root in the cgroup hierarchy.
+	IPCObjects        *model.IPCObjects   `json:"ipc-objects,omitempty"`        // ipc: IPC objects.
+	NetworkInterfaces NetworkInterfaces   `json:"network-interfaces,omitempty"` // net: network interfaces.
+	NetNSIDs          NetNSIDs            `json:"netnsids,omitempty"`           // net: nsids assigned to other network namespaces.
+	Sockets           []model.Socket      `json:"sockets,omitempty"`            // net: sockets.
 }
 
 // NamespaceMarshal adds those fields to [NamespaceUnmarshal] we marshal as a
@@ -168,6 +179,31 @@ func (d NamespacesDict) marshalNamespace(ns model.Namespace, usernames map[uint3
 	if owner := ns.Owner(); owner != nil {
 		aux.Owner = owner.(model.Namespace).ID().Ino
 	}
+	// Time namespaces might come with their clock offsets...
+	if tns, ok := ns.(model.TimeOffsets); ok {
+		aux.ClockOffsets = tns.ClockOffsets()
+	}
+	// ...and UTS namespaces with their host and domain names.
+	if uns, ok := ns.(model.UTSNames); ok {
+		aux.Nodename = uns.Nodename()
+		aux.Domainname = uns.Domainname()
+	}
+	// ...and cgroup namespaces with their roots in the cgroup hierarchy.
+	if cns, ok := ns.(model.CgroupNamespaceRoot); ok {
+		aux.CgroupRoot = cns.CgroupRoot()
+	}
+	// ...and IPC namespaces with their IPC objects.
+	if ins, ok := ns.(model.IPCInventory); ok {
+		aux.IPCObjects = ins.IPCObjects()
+	}
+	// ...and network namespaces with their network interfaces.
+	if nns, ok := ns.(model.NetworkInventory); ok {
+		aux.NetworkInterfaces = nns.NetworkInterfaces()
+		aux.NetNSIDs = nns.NetNSIDs()
+	}
+	if sns, ok := ns.(model.SocketInventory); ok {
+		aux.Sockets = sns.Sockets()
+	}
 	// Now take care of hierarchical PID and user namespaces...
 	if hns, ok := ns.(model.Hierarchy); ok {
 		if parent := hns.Parent(); parent != nil {
@@ -185,6 +221,11 @@ func (d NamespacesDict) marshalNamespace(ns model.Namespace, usernames map[uint3
 	// And now take care of what is special for user namespaces; such as
 	// enforcing sending a user ID, even if it is 0/root (which often will be
 	// the case).
+	if idm, ok := ns.(model.IDMapping); ok {
+		aux.UIDMap = idm.UIDMap()
+		aux.GIDMap = idm.GIDMap()
+		aux.Setgroups = idm.Setgroups()
+	}
 	username := usernames[uint32(uns.UID())]
 	return json.Marshal(&struct {
 		NamespaceMarshal
@@ -249,10 +290,33 @@ func (d NamespacesDict) UnmarshalNamespace(data []byte) (model.Namespace, error)
 		parentns := d.Get(species.NamespaceIDfromInode(aux.Parent), nstype)
 		parentns.(namespaces.HierarchyConfigurer).AddChild(hns)
 	}
-	// Set the user namespace's user ID, if applicable. Please note that we
+	// Set the time namespace's clock offsets, if applicable.
+	if tns, ok := ns.(namespaces.TimeConfigurer); ok {
+		tns.SetClockOffsets(aux.ClockOffsets)
+	}
+	// Set the UTS namespace's host and domain names, if applicable.
+	if uns, ok := ns.(namespaces.UTSConfigurer); ok {
+		uns.SetUTSNames(aux.Nodename, aux.Domainname)
+	}
+	// Set the cgroup namespace's root, if applicable.
+	if cns, ok := ns.(namespaces.CgroupConfigurer); ok {
+		cns.SetCgroupRoot(aux.CgroupRoot)
+	}
+	// Set the IPC namespace's IPC objects, if applicable.
+	if ins, ok := ns.(namespaces.IPCConfigurer); ok {
+		ins.SetIPCObjects(aux.IPCObjects)
+	}
+	// Set the network namespace's network interfaces, if applicable.
+	if nns, ok := ns.(namespaces.NetConfigurer); ok {
+		nns.SetNetworkInterfaces(aux.NetworkInterfaces)
+		nns.SetNetNSIDs(aux.NetNSIDs)
+		nns.SetSockets(aux.Sockets)
+	}
+	// Set the user namespace's user ID and ID mappings, if applicable. Please note that we
 	// here do not resolve the references to the owned namespaces.
 	if uns, ok := ns.(namespaces.UserConfigurer); ok {
 		uns.SetOwnerUID(aux.UserUID)
+		uns.SetIDMaps(aux.UIDMap, aux.GIDMap, aux.Setgroups)
 	}
 	// Phew, done!
 	return ns, nil
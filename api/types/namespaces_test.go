@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/netip"
 	"os"
 	"regexp"
 	"slices"
@@ -119,7 +120,7 @@ var _ = BeforeSuite(func(ctx context.Context) {
 	// "nearly-all-ns" and ... containerz!
 	allns = discover.Namespaces(
 		discover.WithStandardDiscovery(),
-		discover.NotFromFds(), discover.NotFromBindmounts(),
+		discover.NotFromFds(), discover.NotFromBindmounts(), discover.NotFromNsfs(),
 		discover.WithMounts(),
 		discover.WithContainerizer(cizer))
 
@@ -170,6 +171,31 @@ func refifnotempty(ref model.NamespaceRef) string {
 	return `"reference": ` + string(b) + `,`
 }
 
+func idmapsifnotempty(ns model.Namespace) string {
+	idm := ns.(model.IDMapping)
+	var s string
+	for _, field := range []struct {
+		name  string
+		idmap model.IDMap
+	}{
+		{"uid-map", idm.UIDMap()},
+		{"gid-map", idm.GIDMap()},
+	} {
+		if len(field.idmap) == 0 {
+			continue
+		}
+		b, err := json.Marshal(field.idmap)
+		if err != nil {
+			panic(err)
+		}
+		s += `"` + field.name + `": ` + string(b) + `,`
+	}
+	if sg := idm.Setgroups(); sg != model.SetgroupsUnknown {
+		s += `"setgroups": "` + string(sg) + `",`
+	}
+	return s
+}
+
 var _ = Describe("namespaces JSON", func() {
 
 	It("always gets a Namespace from the dictionary", func() {
@@ -220,6 +246,7 @@ var _ = Describe("namespaces JSON", func() {
 				"nsid": %d,
 				"type": "user",
 				%s
+				%s
 				"leaders": %s,
 				"ealdorman": %d,
 				"parent": %d,
@@ -228,6 +255,7 @@ var _ = Describe("namespaces JSON", func() {
 			}`,
 			userns.ID().Ino,
 			refifnotempty(userns.Ref()),
+			idmapsifnotempty(userns),
 			pidlist(userns.LeaderPIDs()),
 			userns.Ealdorman().PID,
 			parentuserns.ID().Ino,
@@ -242,6 +270,7 @@ var _ = Describe("namespaces JSON", func() {
 				"nsid": %d,
 				"type": "user",
 				%s
+				%s
 				"parent": %d,
 				"children": %s,
 				"user-id": %d,
@@ -251,6 +280,7 @@ var _ = Describe("namespaces JSON", func() {
 			}`,
 			parentuserns.ID().Ino,
 			refifnotempty(parentuserns.Ref()),
+			idmapsifnotempty(parentuserns),
 			parentuserns.(model.Hierarchy).Parent().(model.Namespace).ID().Ino,
 			childlist(parentuserns.(model.Hierarchy)),
 			parentuserns.(model.Ownership).UID(),
@@ -267,6 +297,7 @@ var _ = Describe("namespaces JSON", func() {
 				"nsid": %d,
 				"type": "user",
 				%s
+				%s
 				"leaders": %s,
 				"ealdorman": %d,
 				"children": %s,
@@ -275,6 +306,7 @@ var _ = Describe("namespaces JSON", func() {
 			}`,
 			grandpa.ID().Ino,
 			refifnotempty(grandpa.Ref()),
+			idmapsifnotempty(grandpa),
 			pidlist(grandpa.LeaderPIDs()),
 			grandpa.Ealdorman().PID,
 			childlist(grandpa.(model.Hierarchy)),
@@ -303,6 +335,9 @@ var _ = Describe("namespaces JSON", func() {
 		uns, err := nsdict.UnmarshalNamespace(j)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(uns).To(BeSameNamespace(userns))
+		Expect(uns.(model.IDMapping).UIDMap()).To(Equal(userns.(model.IDMapping).UIDMap()))
+		Expect(uns.(model.IDMapping).GIDMap()).To(Equal(userns.(model.IDMapping).GIDMap()))
+		Expect(uns.(model.IDMapping).Setgroups()).To(Equal(userns.(model.IDMapping).Setgroups()))
 
 		// Check that unmarshalling a (flat) namespace also works correctly.
 		ns := allns.Processes[model.PIDType(os.Getpid())].Namespaces[model.NetNS]
@@ -312,6 +347,98 @@ var _ = Describe("namespaces JSON", func() {
 		ns2, err := nsdict.UnmarshalNamespace(j)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(ns2).To(BeSameNamespace(ns))
+
+		// Check that the clock offsets of time namespaces survive.
+		timens := namespaces.NewWithSimpleRef(species.CLONE_NEWTIME, species.NamespaceIDfromInode(123), "/foobar")
+		timens.(namespaces.TimeConfigurer).SetClockOffsets(&model.ClockOffsets{
+			Monotonic: time.Hour, Boottime: -time.Second})
+		j, err = nsdict.marshalNamespace(timens, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"clock-offsets":{"monotonic":3600000000000,"boottime":-1000000000}`))
+		timens2, err := NewNamespacesDict(nil).UnmarshalNamespace(j)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timens2.(model.TimeOffsets).ClockOffsets()).To(Equal(
+			timens.(model.TimeOffsets).ClockOffsets()))
+
+		// Check that the host and domain names of UTS namespaces survive.
+		utsns := namespaces.NewWithSimpleRef(species.CLONE_NEWUTS, species.NamespaceIDfromInode(123), "/foobar")
+		utsns.(namespaces.UTSConfigurer).SetUTSNames("foo", "bar")
+		j, err = nsdict.marshalNamespace(utsns, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"nodename":"foo","domainname":"bar"`))
+		utsns2, err := NewNamespacesDict(nil).UnmarshalNamespace(j)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utsns2.(model.UTSNames).Nodename()).To(Equal("foo"))
+		Expect(utsns2.(model.UTSNames).Domainname()).To(Equal("bar"))
+
+		// Check that the roots of cgroup namespaces survive.
+		cgroupns := namespaces.NewWithSimpleRef(species.CLONE_NEWCGROUP, species.NamespaceIDfromInode(123), "/foobar")
+		cgroupns.(namespaces.CgroupConfigurer).SetCgroupRoot("/foo.slice")
+		j, err = nsdict.marshalNamespace(cgroupns, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"cgroup-root":"/foo.slice"`))
+		cgroupns2, err := NewNamespacesDict(nil).UnmarshalNamespace(j)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cgroupns2.(model.CgroupNamespaceRoot).CgroupRoot()).To(Equal("/foo.slice"))
+
+		// Check that the IPC objects of IPC namespaces survive.
+		ipcns := namespaces.NewWithSimpleRef(species.CLONE_NEWIPC, species.NamespaceIDfromInode(123), "/foobar")
+		ipcobjects := &model.IPCObjects{
+			SharedMemory: []model.SysVSharedMemory{{
+				SysVIPCObject: model.SysVIPCObject{Key: 42, ID: 1, Perms: 0o600},
+				Size:          4096,
+				Attached:      2,
+			}},
+			POSIXMessageQueues: []model.POSIXMessageQueue{{Name: "foo", Mode: 0o644}},
+			MqueuePath:         "/dev/mqueue",
+		}
+		ipcns.(namespaces.IPCConfigurer).SetIPCObjects(ipcobjects)
+		j, err = nsdict.marshalNamespace(ipcns, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"ipc-objects":{"shm":[{"key":42,`))
+		ipcns2, err := NewNamespacesDict(nil).UnmarshalNamespace(j)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ipcns2.(model.IPCInventory).IPCObjects()).To(Equal(ipcobjects))
+
+		// Check that the network interfaces of network namespaces survive.
+		netns := namespaces.NewWithSimpleRef(species.CLONE_NEWNET, species.NamespaceIDfromInode(123), "/foobar")
+		netifs := []model.NetworkInterface{{
+			Index:     1,
+			Name:      "lo",
+			MTU:       65536,
+			OperState: model.OperStateUnknown,
+			Addresses: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/8")},
+		}, {
+			Index:     2,
+			Name:      "eth0",
+			Kind:      "veth",
+			MTU:       1500,
+			OperState: model.OperStateUp,
+			Link:      5,
+			LinkNetNS: species.NamespaceIDfromInode(456),
+		}}
+		netns.(namespaces.NetConfigurer).SetNetworkInterfaces(netifs)
+		nsids := map[int32]species.NamespaceID{0: species.NamespaceIDfromInode(456)}
+		netns.(namespaces.NetConfigurer).SetNetNSIDs(nsids)
+		socks := []model.Socket{{
+			Protocol: model.SocketProtocolTCP,
+			State:    model.SocketStateListen,
+			Inode:    42,
+			Local:    netip.MustParseAddrPort("0.0.0.0:8080"),
+			Remote:   netip.MustParseAddrPort("0.0.0.0:0"),
+			PIDs:     []model.PIDType{666},
+		}}
+		netns.(namespaces.NetConfigurer).SetSockets(socks)
+		j, err = nsdict.marshalNamespace(netns, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"network-interfaces":[{"index":1,"name":"lo",`))
+		Expect(string(j)).To(ContainSubstring(`"link":5,"link-netns":456}`))
+		Expect(string(j)).To(ContainSubstring(`"netnsids":{"0":456}`))
+		netns2, err := NewNamespacesDict(nil).UnmarshalNamespace(j)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(netns2.(model.NetworkInventory).NetworkInterfaces()).To(Equal(netifs))
+		Expect(netns2.(model.NetworkInventory).NetNSIDs()).To(Equal(nsids))
+		Expect(netns2.(model.SocketInventory).Sockets()).To(Equal(socks))
 	})
 
 	It("marshals NamespacesDict", func() {
@@ -325,6 +452,7 @@ var _ = Describe("namespaces JSON", func() {
 				"nsid": %[1]d,
 				"type": "user",
 				%s
+				%s
 				"leaders": %s,
 				"ealdorman": %d,
 				"parent": %d,
@@ -334,6 +462,7 @@ var _ = Describe("namespaces JSON", func() {
 		}`,
 			userns.ID().Ino,
 			refifnotempty(userns.Ref()),
+			idmapsifnotempty(userns),
 			pidlist(userns.LeaderPIDs()),
 			userns.Ealdorman().PID,
 			userns.(model.Hierarchy).Parent().(model.Namespace).ID().Ino,
@@ -0,0 +1,217 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+)
+
+// NetworkInterfaces is a JSON marshallable list of network interfaces,
+// referencing the network namespaces of link interfaces by their inode numbers
+// only.
+type NetworkInterfaces []model.NetworkInterface
+
+// networkInterface is the JSON serializable twin to a network interface,
+// referencing the network namespace of its link interface by inode number.
+type networkInterface struct {
+	model.NetworkInterface
+	LinkNetNS uint64 `json:"link-netns,omitempty"` // network namespace of the Link interface, unless the same.
+}
+
+// MarshalJSON emits the list of network interfaces, with the network
+// namespaces of link interfaces as inode numbers.
+func (n NetworkInterfaces) MarshalJSON() ([]byte, error) {
+	aux := make([]networkInterface, 0, len(n))
+	for _, netif := range n {
+		aux = append(aux, networkInterface{
+			NetworkInterface: netif,
+			LinkNetNS:        netif.LinkNetNS.Ino,
+		})
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes a list of network interfaces.
+func (n *NetworkInterfaces) UnmarshalJSON(data []byte) error {
+	var aux []networkInterface
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*n = make(NetworkInterfaces, 0, len(aux))
+	for _, netif := range aux {
+		netif.NetworkInterface.LinkNetNS = species.NamespaceIDfromInode(netif.LinkNetNS)
+		*n = append(*n, netif.NetworkInterface)
+	}
+	return nil
+}
+
+// NetNSIDs is a JSON marshallable map of the nsids a network namespace
+// assigned to other network namespaces, referencing the latter by their inode
+// numbers only.
+type NetNSIDs map[int32]species.NamespaceID
+
+// MarshalJSON emits an object (map/dictionary) of nsids with the inode
+// numbers of their network namespaces.
+func (n NetNSIDs) MarshalJSON() ([]byte, error) {
+	aux := make(map[int32]uint64, len(n))
+	for nsid, netnsid := range n {
+		aux[nsid] = netnsid.Ino
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes an object (map/dictionary) of nsids with the inode
+// numbers of their network namespaces.
+func (n *NetNSIDs) UnmarshalJSON(data []byte) error {
+	var aux map[int32]uint64
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*n = make(NetNSIDs, len(aux))
+	for nsid, ino := range aux {
+		(*n)[nsid] = species.NamespaceIDfromInode(ino)
+	}
+	return nil
+}
+
+// NetworkTopology is a JSON marshallable network topology, referencing the
+// network namespaces of network interfaces by their inode numbers only.
+type NetworkTopology model.NetworkTopology
+
+// networkInterfaceRef is the JSON serializable twin to a network interface
+// reference.
+type networkInterfaceRef struct {
+	NetNS uint64 `json:"netns"` // network namespace of the interface.
+	Index int    `json:"index"` // interface index.
+}
+
+// networkTopologyNode is the JSON serializable twin to a network topology
+// node.
+type networkTopologyNode struct {
+	model.NetworkTopologyNode
+	NetNS uint64 `json:"netns"` // network namespace of the interface.
+}
+
+// networkTopologyEdge is the JSON serializable twin to a network topology
+// edge.
+type networkTopologyEdge struct {
+	From     networkInterfaceRef   `json:"from"`
+	To       networkInterfaceRef   `json:"to"`
+	Relation model.NetworkRelation `json:"relation"`
+}
+
+// networkTopology is the JSON serializable twin to a network topology.
+type networkTopology struct {
+	Nodes []networkTopologyNode `json:"nodes,omitempty"`
+	Edges []networkTopologyEdge `json:"edges,omitempty"`
+}
+
+// MarshalJSON emits the nodes and edges of the network topology, with network
+// namespaces as inode numbers.
+func (t NetworkTopology) MarshalJSON() ([]byte, error) {
+	aux := networkTopology{}
+	for _, node := range t.Nodes {
+		aux.Nodes = append(aux.Nodes, networkTopologyNode{
+			NetworkTopologyNode: node,
+			NetNS:               node.NetNS.Ino,
+		})
+	}
+	for _, edge := range t.Edges {
+		aux.Edges = append(aux.Edges, networkTopologyEdge{
+			From:     networkInterfaceRef{NetNS: edge.From.NetNS.Ino, Index: edge.From.Index},
+			To:       networkInterfaceRef{NetNS: edge.To.NetNS.Ino, Index: edge.To.Index},
+			Relation: edge.Relation,
+		})
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes the nodes and edges of a network topology.
+func (t *NetworkTopology) UnmarshalJSON(data []byte) error {
+	var aux networkTopology
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*t = NetworkTopology{}
+	for _, node := range aux.Nodes {
+		node.NetworkTopologyNode.NetNS = species.NamespaceIDfromInode(node.NetNS)
+		t.Nodes = append(t.Nodes, node.NetworkTopologyNode)
+	}
+	for _, edge := range aux.Edges {
+		t.Edges = append(t.Edges, model.NetworkTopologyEdge{
+			From:     edge.From.ref(),
+			To:       edge.To.ref(),
+			Relation: edge.Relation,
+		})
+	}
+	return nil
+}
+
+// ref returns the network interface reference with the full network
+// namespace identifier.
+func (r networkInterfaceRef) ref() model.NetworkInterfaceRef {
+	return model.NetworkInterfaceRef{
+		NetNS: species.NamespaceIDfromInode(r.NetNS),
+		Index: r.Index,
+	}
+}
+
+// UnixSocketGraph is a JSON marshallable graph of connected UNIX domain
+// sockets, referencing the network namespaces of sockets by their inode
+// numbers only.
+type UnixSocketGraph model.UnixSocketGraph
+
+// unixSocketNode is the JSON serializable twin to a UNIX domain socket graph
+// node.
+type unixSocketNode struct {
+	model.UnixSocketNode
+	NetNS uint64 `json:"netns"` // network namespace of the socket.
+}
+
+// unixSocketGraph is the JSON serializable twin to a UNIX domain socket graph.
+type unixSocketGraph struct {
+	Nodes []unixSocketNode       `json:"nodes,omitempty"`
+	Edges []model.UnixSocketEdge `json:"edges,omitempty"`
+}
+
+// MarshalJSON emits the nodes and edges of the UNIX domain socket graph, with
+// network namespaces as inode numbers.
+func (g UnixSocketGraph) MarshalJSON() ([]byte, error) {
+	aux := unixSocketGraph{Edges: g.Edges}
+	for _, node := range g.Nodes {
+		aux.Nodes = append(aux.Nodes, unixSocketNode{
+			UnixSocketNode: node,
+			NetNS:          node.NetNS.Ino,
+		})
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes the nodes and edges of a UNIX domain socket graph.
+func (g *UnixSocketGraph) UnmarshalJSON(data []byte) error {
+	var aux unixSocketGraph
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*g = UnixSocketGraph{Edges: aux.Edges}
+	for _, node := range aux.Nodes {
+		node.UnixSocketNode.NetNS = species.NamespaceIDfromInode(node.NetNS)
+		g.Nodes = append(g.Nodes, node.UnixSocketNode)
+	}
+	return nil
+}
@@ -234,6 +234,107 @@ var _ = Describe("process JSON", func() {
 		Expect(j2).To(MatchJSON(j))
 	})
 
+	It("un/marshals Process capabilities", func() {
+		capabilities := &model.Capabilities{
+			Effective:   []string{"cap_net_raw", "cap_sys_admin"},
+			Permitted:   []string{"cap_net_raw", "cap_sys_admin"},
+			Inheritable: []string{},
+			Bounding:    []string{"cap_net_raw", "cap_sys_admin"},
+		}
+		proc := &model.Process{
+			PID:  42,
+			PPID: 1,
+			ProTaskCommon: model.ProTaskCommon{
+				Name:         "foo",
+				Namespaces:   namespaceset,
+				Capabilities: capabilities,
+			},
+		}
+		proc.Tasks = []*model.Task{{
+			TID:     42,
+			Process: proc,
+			ProTaskCommon: model.ProTaskCommon{
+				Name:         "foo",
+				Namespaces:   namespaceset,
+				Capabilities: capabilities,
+			},
+		}}
+		j, err := json.Marshal((*Process)(proc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(
+			`"capabilities":{"effective":["cap_net_raw","cap_sys_admin"],`))
+
+		p := &Process{}
+		Expect(p.unmarshalJSON(j, NewNamespacesDict(nil))).To(Succeed())
+		Expect(p.Capabilities).To(Equal(capabilities))
+		Expect(p.Capabilities.HasEffective("CAP_SYS_ADMIN")).To(BeTrue())
+		Expect(p.Tasks).To(HaveLen(1))
+		Expect(p.Tasks[0].Capabilities).To(Equal(capabilities))
+	})
+
+	It("un/marshals Process credentials", func() {
+		credentials := &model.Credentials{
+			UIDs:           model.CredentialIDs{Real: 1000, Effective: 0, Saved: 0, FS: 1000},
+			GIDs:           model.CredentialIDs{Real: 1000, Effective: 1000, Saved: 1000, FS: 1000},
+			Groups:         []uint32{4, 27},
+			NoNewPrivs:     true,
+			Seccomp:        model.SeccompFilter,
+			SeccompFilters: 1,
+		}
+		proc := &model.Process{
+			PID:  42,
+			PPID: 1,
+			ProTaskCommon: model.ProTaskCommon{
+				Name:        "foo",
+				Namespaces:  namespaceset,
+				Credentials: credentials,
+			},
+		}
+		proc.Tasks = []*model.Task{{
+			TID:     42,
+			Process: proc,
+			ProTaskCommon: model.ProTaskCommon{
+				Name:        "foo",
+				Namespaces:  namespaceset,
+				Credentials: credentials,
+			},
+		}}
+		j, err := json.Marshal((*Process)(proc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(
+			`"credentials":{"uids":{"real":1000,"effective":0,"saved":0,"fs":1000},`))
+		Expect(string(j)).To(ContainSubstring(
+			`"no-new-privs":true,"seccomp":"filter","seccomp-filters":1}`))
+
+		p := &Process{}
+		Expect(p.unmarshalJSON(j, NewNamespacesDict(nil))).To(Succeed())
+		Expect(p.Credentials).To(Equal(credentials))
+		Expect(p.Tasks).To(HaveLen(1))
+		Expect(p.Tasks[0].Credentials).To(Equal(credentials))
+	})
+
+	It("un/marshals Process LSM contexts", func() {
+		proc := &model.Process{
+			PID:  42,
+			PPID: 1,
+			ProTaskCommon: model.ProTaskCommon{
+				Name:       "foo",
+				Namespaces: namespaceset,
+			},
+			LSMContexts: model.LSMContexts{
+				{LSM: "apparmor", Context: "docker-default (enforce)"},
+			},
+		}
+		j, err := json.Marshal((*Process)(proc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(
+			`"lsm-contexts":[{"lsm":"apparmor","context":"docker-default (enforce)"}]`))
+
+		p := &Process{}
+		Expect(p.unmarshalJSON(j, NewNamespacesDict(nil))).To(Succeed())
+		Expect(p.LSMContexts).To(Equal(proc.LSMContexts))
+	})
+
 	It("marshals ProcessTable", func() {
 		pt := NewProcessTable(WithProcessTable(model.ProcessTable{proc1.PID: proc1, proc2.PID: proc2}))
 		j, err := json.Marshal(pt)
@@ -0,0 +1,96 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"github.com/thediveo/lxkns/model"
+)
+
+// NamespaceSharings is a list of namespaces shared by multiple containers,
+// with each namespace sharing referencing the namespace and the containers
+// by their identifiers.
+//
+// NamespaceSharings is only a marshalling and unmarshalling vehicle:
+// unmarshalling NamespaceSharings doesn't recreate the namespaces, containers,
+// and groups, but only their identifiers.
+type NamespaceSharings []NamespaceSharing
+
+// NamespaceSharing is a [model.NamespaceSharing] with references to the
+// shared namespace, the sharing containers, and their common group.
+type NamespaceSharing struct {
+	// Identifier (inode number) of the shared namespace.
+	ID uint64 `json:"nsid"`
+	// Type of the shared namespace, such as "net".
+	Type string `json:"type"`
+	// true if all containers belong to the same group intended to share
+	// namespaces, such as a Kubernetes pod.
+	Intended bool `json:"intended"`
+	// true if the shared namespace is an initial namespace, so the
+	// containers share it with the host.
+	Initial bool `json:"initial,omitempty"`
+	// Common group of the containers if the sharing is intended.
+	Group *SharingGroup `json:"group,omitempty"`
+	// Containers sharing the namespace.
+	Containers []SharingContainer `json:"containers"`
+}
+
+// SharingContainer identifies a container sharing a namespace, together with
+// the groups it belongs to.
+type SharingContainer struct {
+	ID     string         `json:"id"`               // container identifier.
+	Name   string         `json:"name"`             // container name.
+	Type   string         `json:"type"`             // container type, such as "docker.com".
+	Groups []SharingGroup `json:"groups,omitempty"` // groups the container belongs to.
+}
+
+// SharingGroup identifies a group of containers.
+type SharingGroup struct {
+	Name string `json:"name"` // group name.
+	Type string `json:"type"` // group type, such as "io.kubernetes.pod".
+}
+
+// NewNamespaceSharings returns the specified namespace sharings in their
+// order, ready for marshalling.
+func NewNamespaceSharings(sharings model.NamespaceSharings) NamespaceSharings {
+	nssharings := NamespaceSharings{}
+	for _, sharing := range sharings {
+		nssharing := NamespaceSharing{
+			ID:         sharing.Namespace.ID().Ino,
+			Type:       sharing.Namespace.Type().Name(),
+			Intended:   sharing.Intended(),
+			Initial:    sharing.Initial,
+			Containers: []SharingContainer{},
+		}
+		if group := sharing.Group; group != nil {
+			nssharing.Group = &SharingGroup{Name: group.Name, Type: group.Type}
+		}
+		for _, cntr := range sharing.Containers {
+			container := SharingContainer{
+				ID:   cntr.ID,
+				Name: cntr.Name,
+				Type: cntr.Type,
+			}
+			for _, group := range cntr.Groups {
+				container.Groups = append(container.Groups, SharingGroup{
+					Name: group.Name,
+					Type: group.Type,
+				})
+			}
+			nssharing.Containers = append(nssharing.Containers, container)
+		}
+		nssharings = append(nssharings, nssharing)
+	}
+	return nssharings
+}
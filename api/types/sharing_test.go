@@ -0,0 +1,86 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("namespace sharings", func() {
+
+	It("marshals and unmarshals shared namespaces with their containers", func() {
+		netns := namespaces.New(species.CLONE_NEWNET, species.NamespaceIDfromInode(42), nil)
+		pod := &model.Group{Name: "default/foo", Type: "io.kubernetes.pod"}
+		sandbox := &model.Container{ID: "deadbeef", Name: "sandbox", Type: "containerd.io"}
+		workload := &model.Container{ID: "c0ffee", Name: "workload", Type: "containerd.io"}
+		pod.AddContainer(sandbox)
+		pod.AddContainer(workload)
+		joiner := &model.Container{ID: "f00d", Name: "joiner", Type: "docker.com"}
+		initialpidns := namespaces.New(species.CLONE_NEWPID, species.NamespaceIDfromInode(1), nil)
+
+		sharings := NewNamespaceSharings(model.NamespaceSharings{
+			{Namespace: netns, Containers: model.Containers{sandbox, workload}, Group: pod},
+			{Namespace: netns, Containers: model.Containers{joiner, sandbox}},
+			{Namespace: initialpidns, Containers: model.Containers{joiner, sandbox}, Initial: true},
+		})
+		Expect(sharings).To(HaveLen(3))
+		Expect(sharings[0].Group).To(Equal(&SharingGroup{
+			Name: "default/foo", Type: "io.kubernetes.pod"}))
+		Expect(sharings[1].Group).To(BeNil())
+
+		j, err := json.Marshal(sharings)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[
+			{"nsid":42,"type":"net","intended":true,
+			 "group":{"name":"default/foo","type":"io.kubernetes.pod"},
+			 "containers":[
+				{"id":"deadbeef","name":"sandbox","type":"containerd.io",
+				 "groups":[{"name":"default/foo","type":"io.kubernetes.pod"}]},
+				{"id":"c0ffee","name":"workload","type":"containerd.io",
+				 "groups":[{"name":"default/foo","type":"io.kubernetes.pod"}]}
+			 ]},
+			{"nsid":42,"type":"net","intended":false,
+			 "containers":[
+				{"id":"f00d","name":"joiner","type":"docker.com"},
+				{"id":"deadbeef","name":"sandbox","type":"containerd.io",
+				 "groups":[{"name":"default/foo","type":"io.kubernetes.pod"}]}
+			 ]},
+			{"nsid":1,"type":"pid","intended":false,"initial":true,
+			 "containers":[
+				{"id":"f00d","name":"joiner","type":"docker.com"},
+				{"id":"deadbeef","name":"sandbox","type":"containerd.io",
+				 "groups":[{"name":"default/foo","type":"io.kubernetes.pod"}]}
+			 ]}
+		]`))
+
+		var s NamespaceSharings
+		Expect(json.Unmarshal(j, &s)).To(Succeed())
+		Expect(s).To(Equal(sharings))
+	})
+
+	It("marshals no sharings as empty list", func() {
+		j, err := json.Marshal(NewNamespaceSharings(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[]`))
+	})
+
+})
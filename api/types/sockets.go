@@ -0,0 +1,81 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/model"
+)
+
+// Sockets is a flat list of the sockets of all discovered network namespaces,
+// with each socket attributed to its network namespace, as well as to the
+// containers of the processes having the socket open. It thus directly
+// answers questions such as “which container listens on port 8080 in which
+// network namespace?”
+//
+// Sockets is only a marshalling and unmarshalling vehicle: unmarshalling
+// Sockets doesn't recreate the network namespaces and containers, but only
+// their identifiers.
+type Sockets []Socket
+
+// Socket is a [model.Socket] together with the network namespace it belongs
+// to and the containers having it open.
+type Socket struct {
+	// Identifier (inode number) of the network namespace of this socket.
+	NetNS uint64 `json:"netns"`
+	// Socket details, including the PIDs of processes having it open.
+	model.Socket
+	// Containers of the processes having this socket open, if any.
+	Containers []SocketContainer `json:"containers,omitempty"`
+}
+
+// SocketContainer identifies a container having a socket open.
+type SocketContainer struct {
+	ID   string `json:"id"`   // container identifier.
+	Name string `json:"name"` // container name.
+	Type string `json:"type"` // container type, such as "docker.com".
+}
+
+// NewSockets returns the sockets of the network namespaces in the specified
+// discovery result, ordered by network namespace identifier and then in the
+// order of the sockets of each network namespace. The discovery result must
+// have been produced with [discover.WithSockets] in order to yield any
+// sockets, and additionally with a containerizer in order to attribute
+// sockets to containers.
+func NewSockets(result *discover.Result) Sockets {
+	sockets := Sockets{}
+	for _, netns := range result.SortedNamespaces(model.NetNS) {
+		inventory, ok := netns.(model.SocketInventory)
+		if !ok {
+			continue
+		}
+		for _, socket := range inventory.Sockets() {
+			var containers []SocketContainer
+			for _, cntr := range socket.Containers(result.Processes) {
+				containers = append(containers, SocketContainer{
+					ID:   cntr.ID,
+					Name: cntr.Name,
+					Type: cntr.Type,
+				})
+			}
+			sockets = append(sockets, Socket{
+				NetNS:      netns.ID().Ino,
+				Socket:     socket,
+				Containers: containers,
+			})
+		}
+	}
+	return sockets
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"net/netip"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("sockets", func() {
+
+	It("lists and attributes sockets of network namespaces", func() {
+		netns1 := namespaces.New(species.CLONE_NEWNET, species.NamespaceIDfromInode(1), nil)
+		netns2 := namespaces.New(species.CLONE_NEWNET, species.NamespaceIDfromInode(2), nil)
+		netns2.(namespaces.NetConfigurer).SetSockets([]model.Socket{
+			{
+				Protocol: model.SocketProtocolTCP,
+				State:    model.SocketStateListen,
+				Inode:    666,
+				Local:    netip.MustParseAddrPort("0.0.0.0:8080"),
+				PIDs:     []model.PIDType{42},
+			},
+		})
+		netns1.(namespaces.NetConfigurer).SetSockets([]model.Socket{
+			{Protocol: model.SocketProtocolUNIX, Inode: 777},
+		})
+
+		parent := &model.Process{PID: 41}
+		parent.Container = &model.Container{ID: "deadbeef", Name: "fooserver", Type: "docker.com"}
+		proc := &model.Process{PID: 42, Parent: parent}
+		result := &discover.Result{
+			Processes: model.ProcessTable{41: parent, 42: proc},
+		}
+		result.Namespaces[model.NetNS] = model.NamespaceMap{
+			netns1.ID(): netns1,
+			netns2.ID(): netns2,
+		}
+
+		sockets := NewSockets(result)
+		Expect(sockets).To(ConsistOf(
+			MatchFields(IgnoreExtras, Fields{
+				"NetNS":      Equal(uint64(1)),
+				"Socket":     HaveField("Inode", uint64(777)),
+				"Containers": BeEmpty(),
+			}),
+			MatchFields(IgnoreExtras, Fields{
+				"NetNS":  Equal(uint64(2)),
+				"Socket": HaveField("Local", netip.MustParseAddrPort("0.0.0.0:8080")),
+				"Containers": ConsistOf(SocketContainer{
+					ID: "deadbeef", Name: "fooserver", Type: "docker.com"}),
+			}),
+		))
+		Expect(sockets[0].NetNS).To(Equal(uint64(1)))
+
+		j, err := json.Marshal(sockets)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[
+			{"netns":1,"protocol":"unix","inode":777},
+			{"netns":2,"protocol":"tcp","state":"listen","inode":666,
+			 "local":"0.0.0.0:8080","pids":[42],
+			 "containers":[{"id":"deadbeef","name":"fooserver","type":"docker.com"}]}
+		]`))
+
+		var s Sockets
+		Expect(json.Unmarshal(j, &s)).To(Succeed())
+		Expect(s).To(Equal(sockets))
+	})
+
+})
@@ -0,0 +1,236 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package audit
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+
+	"github.com/thediveo/caps"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+)
+
+// Severity of a finding.
+type Severity string
+
+// The severities of findings, from least to most severe.
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// severityRanks orders severities, with the most severe ranking highest.
+var severityRanks = map[Severity]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// Stable identifiers of the audit rules.
+const (
+	RuleHostNetNamespace  = "host-net-namespace"
+	RuleHostPIDNamespace  = "host-pid-namespace"
+	RuleHostIPCNamespace  = "host-ipc-namespace"
+	RuleHostUTSNamespace  = "host-uts-namespace"
+	RuleHostUserNamespace = "host-user-namespace"
+	RuleHostRootMount     = "host-root-mount"
+	RuleEngineSocketMount = "engine-socket-mount"
+	RuleFullCapabilities  = "full-capabilities"
+)
+
+// Finding is a violation of an audit rule by a container.
+type Finding struct {
+	RuleID    string           `json:"rule-id"`  // stable identifier of the violated rule.
+	Severity  Severity         `json:"severity"` // severity of the violation.
+	Message   string           `json:"message"`  // human-readable details.
+	Container *model.Container `json:"-"`        // offending container.
+}
+
+// Findings is a list of findings, ordered from most to least severe.
+type Findings []Finding
+
+// AtLeast returns only those findings with at least the specified severity,
+// keeping their order.
+func (f Findings) AtLeast(severity Severity) Findings {
+	findings := Findings{}
+	for _, finding := range f {
+		if severityRanks[finding.Severity] >= severityRanks[severity] {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// hostNamespaceRules lists the rules for containers sharing initial namespaces.
+var hostNamespaceRules = []struct {
+	ruleID   string
+	severity Severity
+	nstype   model.NamespaceTypeIndex
+}{
+	{RuleHostNetNamespace, SeverityHigh, model.NetNS},
+	{RuleHostPIDNamespace, SeverityHigh, model.PIDNS},
+	{RuleHostIPCNamespace, SeverityMedium, model.IPCNS},
+	{RuleHostUTSNamespace, SeverityLow, model.UTSNS},
+	{RuleHostUserNamespace, SeverityLow, model.UserNS},
+}
+
+// engineSockets lists the file names of well-known container engine API
+// sockets.
+var engineSockets = []string{
+	"docker.sock",
+	"containerd.sock",
+	"cri-dockerd.sock",
+	"crio.sock",
+	"podman.sock",
+}
+
+// Audit checks the isolation of the containers in the specified discovery
+// result and returns its findings, ordered by decreasing severity, and then
+// by container name and rule identifier.
+func Audit(result *discover.Result) Findings {
+	findings := Findings{}
+	for _, container := range result.Containers {
+		if container.Process == nil {
+			continue
+		}
+		findings = append(findings, auditHostNamespaces(result, container)...)
+		findings = append(findings, auditMounts(result, container)...)
+		findings = append(findings, auditCapabilities(result, container)...)
+	}
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(
+			cmp.Compare(severityRanks[b.Severity], severityRanks[a.Severity]),
+			cmp.Compare(a.Container.Name, b.Container.Name),
+			cmp.Compare(a.RuleID, b.RuleID),
+			cmp.Compare(a.Message, b.Message))
+	})
+	return findings
+}
+
+// auditHostNamespaces reports the initial namespaces a container shares with
+// the host.
+func auditHostNamespaces(result *discover.Result, container *model.Container) Findings {
+	var findings Findings
+	for _, rule := range hostNamespaceRules {
+		initialns := result.InitialNamespaces[rule.nstype]
+		ns := container.Process.Namespaces[rule.nstype]
+		if initialns == nil || ns == nil || ns.ID() != initialns.ID() {
+			continue
+		}
+		findings = append(findings, Finding{
+			RuleID:   rule.ruleID,
+			Severity: rule.severity,
+			Message: fmt.Sprintf("container shares the initial %s namespace %s",
+				ns.Type().Name(), ns.(model.NamespaceStringer).TypeIDString()),
+			Container: container,
+		})
+	}
+	return findings
+}
+
+// auditMounts reports the host's root filesystem and container engine API
+// sockets bind-mounted into the mount namespace of a container.
+func auditMounts(result *discover.Result, container *model.Container) Findings {
+	mntns := container.Process.Namespaces[model.MountNS]
+	if mntns == nil {
+		return nil
+	}
+	mountpaths, ok := result.Mounts[mntns.ID()]
+	if !ok {
+		return nil
+	}
+	var hostroot *mounts.MountPoint
+	if initialmntns := result.InitialNamespaces[model.MountNS]; initialmntns != nil &&
+		initialmntns.ID() != mntns.ID() {
+		if rootpath, ok := result.Mounts[initialmntns.ID()]["/"]; ok {
+			hostroot = rootpath.VisibleMount()
+		}
+	}
+	var findings Findings
+	for _, mountpath := range mountpaths {
+		for _, mountpoint := range mountpath.Mounts {
+			if mountpoint.Hidden {
+				continue
+			}
+			if hostroot != nil && mountpoint.MountPoint != "/" &&
+				mountpoint.Major == hostroot.Major && mountpoint.Minor == hostroot.Minor &&
+				mountpoint.Root == hostroot.Root {
+				findings = append(findings, Finding{
+					RuleID:   RuleHostRootMount,
+					Severity: SeverityCritical,
+					Message: fmt.Sprintf("host root filesystem is bind-mounted at %q",
+						mountpoint.MountPoint),
+					Container: container,
+				})
+			}
+			if slices.Contains(engineSockets, path.Base(mountpoint.Root)) ||
+				slices.Contains(engineSockets, path.Base(mountpoint.MountPoint)) {
+				findings = append(findings, Finding{
+					RuleID:   RuleEngineSocketMount,
+					Severity: SeverityCritical,
+					Message: fmt.Sprintf("container engine socket %q is bind-mounted at %q",
+						mountpoint.Root, mountpoint.MountPoint),
+					Container: container,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// auditCapabilities reports containers holding full capabilities while in the
+// initial user namespace, so these capabilities apply to the host.
+func auditCapabilities(result *discover.Result, container *model.Container) Findings {
+	capabilities := container.Process.Capabilities
+	initialuserns := result.InitialNamespaces[model.UserNS]
+	userns := container.Process.Namespaces[model.UserNS]
+	if capabilities == nil || initialuserns == nil || userns == nil ||
+		userns.ID() != initialuserns.ID() ||
+		!hasFullCapabilities(capabilities) {
+		return nil
+	}
+	return Findings{{
+		RuleID:    RuleFullCapabilities,
+		Severity:  SeverityCritical,
+		Message:   "container holds full capabilities in the initial user namespace",
+		Container: container,
+	}}
+}
+
+// hasFullCapabilities returns true if the effective capabilities contain all
+// capabilities known to the kernel.
+func hasFullCapabilities(capabilities *model.Capabilities) bool {
+	for capno := 0; capno <= caps.LastCapability(); capno++ {
+		name := caps.CapabilityNameByNumber[capno]
+		if name == "" {
+			name = "CAP_" + strconv.Itoa(capno)
+		}
+		if !capabilities.HasEffective(name) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package audit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/thediveo/caps"
+	"github.com/thediveo/go-mntinfo"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// namespacesSet returns a set of namespaces of all types, with their inode
+// numbers starting at the specified base.
+func namespacesSet(base uint64) model.NamespacesSet {
+	var nsset model.NamespacesSet
+	for idx := range nsset {
+		nsset[idx] = namespaces.NewWithSimpleRef(model.TypesByIndex[idx],
+			species.NamespaceID{Dev: 1, Ino: base + uint64(idx)}, "")
+	}
+	return nsset
+}
+
+// newResult returns a discovery result with the initial namespaces and host
+// root mount, as well as a single container with its own namespaces and
+// mount namespace with only a root mount.
+func newResult() (*discover.Result, *model.Container) {
+	proc := &model.Process{
+		PID:           42,
+		ProTaskCommon: model.ProTaskCommon{Namespaces: namespacesSet(2000)},
+	}
+	container := &model.Container{ID: "deadbeef", Name: "foo", PID: 42, Process: proc}
+	proc.Container = container
+	result := &discover.Result{
+		InitialNamespaces: namespacesSet(1000),
+		Processes:         model.ProcessTable{42: proc},
+		Mounts:            discover.NamespacedMountPathMap{},
+		Containers:        model.Containers{container},
+	}
+	result.Mounts[result.InitialNamespaces[model.MountNS].ID()] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+		{MountID: 1, ParentID: 0, MountPoint: "/", Root: "/", Major: 8, Minor: 1, FsType: "ext4"},
+	})
+	result.Mounts[proc.Namespaces[model.MountNS].ID()] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+		{MountID: 1, ParentID: 0, MountPoint: "/", Root: "/", Major: 0, Minor: 42, FsType: "overlay"},
+	})
+	return result, container
+}
+
+// fullCapabilities returns the names of all capabilities the kernel knows, in
+// the same lower case form as the discovery.
+func fullCapabilities() []string {
+	names := make([]string, caps.LastCapability()+1)
+	for idx := range names {
+		name := caps.CapabilityNameByNumber[idx]
+		if name == "" {
+			name = "CAP_" + strconv.Itoa(idx)
+		}
+		names[idx] = strings.ToLower(name)
+	}
+	return names
+}
+
+var _ = Describe("auditing containers", func() {
+
+	It("doesn't report isolated containers", func() {
+		result, _ := newResult()
+		Expect(Audit(result)).To(BeEmpty())
+		Expect(Audit(result)).NotTo(BeNil())
+	})
+
+	It("skips containers without process", func() {
+		result, container := newResult()
+		container.Process.Namespaces = result.InitialNamespaces
+		container.Process = nil
+		Expect(Audit(result)).To(BeEmpty())
+	})
+
+	It("reports initial namespaces shared with the host", func() {
+		result, container := newResult()
+		container.Process.Namespaces[model.UserNS] = result.InitialNamespaces[model.UserNS]
+		container.Process.Namespaces[model.NetNS] = result.InitialNamespaces[model.NetNS]
+		Expect(Audit(result)).To(HaveExactElements(
+			And(
+				HaveField("RuleID", RuleHostNetNamespace),
+				HaveField("Severity", SeverityHigh),
+				HaveField("Message", ContainSubstring("net:[1006]")),
+				HaveField("Container", container)),
+			And(
+				HaveField("RuleID", RuleHostUserNamespace),
+				HaveField("Severity", SeverityLow)),
+		))
+	})
+
+	It("reports bind-mounted host root and engine sockets", func() {
+		result, container := newResult()
+		result.Mounts[container.Process.Namespaces[model.MountNS].ID()] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", Root: "/", Major: 0, Minor: 42, FsType: "overlay"},
+			{MountID: 2, ParentID: 1, MountPoint: "/host", Root: "/", Major: 8, Minor: 1, FsType: "ext4"},
+			{MountID: 3, ParentID: 1, MountPoint: "/etc/hosts", Root: "/var/lib/docker/hosts", Major: 8, Minor: 1, FsType: "ext4"},
+			{MountID: 4, ParentID: 1, MountPoint: "/var/run/docker.sock", Root: "/run/docker.sock", Major: 0, Minor: 25, FsType: "tmpfs"},
+		})
+		Expect(Audit(result)).To(ConsistOf(
+			And(
+				HaveField("RuleID", RuleHostRootMount),
+				HaveField("Severity", SeverityCritical),
+				HaveField("Message", ContainSubstring(`"/host"`))),
+			And(
+				HaveField("RuleID", RuleEngineSocketMount),
+				HaveField("Severity", SeverityCritical),
+				HaveField("Message", ContainSubstring(`"/var/run/docker.sock"`))),
+		))
+	})
+
+	It("doesn't report host root mounts of containers in the initial mount namespace", func() {
+		result, container := newResult()
+		container.Process.Namespaces[model.MountNS] = result.InitialNamespaces[model.MountNS]
+		Expect(Audit(result)).To(BeEmpty())
+	})
+
+	It("reports full capabilities in the initial user namespace", func() {
+		result, container := newResult()
+		container.Process.Capabilities = &model.Capabilities{Effective: fullCapabilities()}
+		Expect(Audit(result)).To(BeEmpty())
+
+		container.Process.Namespaces[model.UserNS] = result.InitialNamespaces[model.UserNS]
+		Expect(Audit(result)).To(HaveExactElements(
+			And(
+				HaveField("RuleID", RuleFullCapabilities),
+				HaveField("Severity", SeverityCritical)),
+			HaveField("RuleID", RuleHostUserNamespace),
+		))
+
+		container.Process.Capabilities.Effective = []string{"cap_chown", "cap_net_raw"}
+		Expect(Audit(result)).To(HaveExactElements(
+			HaveField("RuleID", RuleHostUserNamespace),
+		))
+
+		container.Process.Capabilities.Effective = fullCapabilities()[1:]
+		Expect(Audit(result)).To(HaveExactElements(
+			HaveField("RuleID", RuleHostUserNamespace),
+		))
+
+		// as many capabilities as the kernel knows, yet one of them missing.
+		container.Process.Capabilities.Effective = append(fullCapabilities()[1:], "cap_bogus")
+		Expect(Audit(result)).To(HaveExactElements(
+			HaveField("RuleID", RuleHostUserNamespace),
+		))
+	})
+
+	It("orders findings by severity and container name", func() {
+		result, container := newResult()
+		container.Process.Namespaces[model.UTSNS] = result.InitialNamespaces[model.UTSNS]
+		proc := &model.Process{
+			PID:           666,
+			ProTaskCommon: model.ProTaskCommon{Namespaces: namespacesSet(3000)},
+		}
+		proc.Namespaces[model.UTSNS] = result.InitialNamespaces[model.UTSNS]
+		proc.Namespaces[model.PIDNS] = result.InitialNamespaces[model.PIDNS]
+		other := &model.Container{ID: "c0ffee", Name: "bar", PID: 666, Process: proc}
+		result.Containers = append(result.Containers, other)
+		Expect(Audit(result)).To(HaveExactElements(
+			And(HaveField("RuleID", RuleHostPIDNamespace), HaveField("Container.Name", "bar")),
+			And(HaveField("RuleID", RuleHostUTSNamespace), HaveField("Container.Name", "bar")),
+			And(HaveField("RuleID", RuleHostUTSNamespace), HaveField("Container.Name", "foo")),
+		))
+	})
+
+	It("returns findings of at least a given severity", func() {
+		findings := Findings{
+			{RuleID: RuleFullCapabilities, Severity: SeverityCritical},
+			{RuleID: RuleHostIPCNamespace, Severity: SeverityMedium},
+			{RuleID: RuleHostUTSNamespace, Severity: SeverityLow},
+		}
+		Expect(findings.AtLeast(SeverityLow)).To(Equal(findings))
+		Expect(findings.AtLeast(SeverityMedium)).To(HaveExactElements(
+			HaveField("RuleID", RuleFullCapabilities),
+			HaveField("RuleID", RuleHostIPCNamespace)))
+		Expect(findings.AtLeast(SeverityHigh)).To(HaveExactElements(
+			HaveField("RuleID", RuleFullCapabilities)))
+		Expect(Findings{}.AtLeast(SeverityCritical)).To(BeEmpty())
+	})
+
+})
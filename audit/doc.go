@@ -0,0 +1,39 @@
+/*
+Package audit checks the isolation of containers, based on a namespace
+discovery result, and reports its findings with stable rule identifiers and
+severities.
+
+# Usage
+
+The audit rules work on the information gathered by a discovery, so the
+discovery needs to include containers, mount paths, and capabilities:
+
+	result := discover.Namespaces(
+		discover.WithFullDiscovery(),
+		discover.WithContainerizer(cizer),
+		discover.WithPIDMapper(),
+		discover.WithCapabilities(),
+	)
+	for _, finding := range audit.Audit(result) {
+		fmt.Printf("%s %s: %s\n",
+			finding.Severity, finding.RuleID, finding.Message)
+	}
+
+Rules whose information is missing from the discovery result simply don't
+report any findings.
+
+# Rules
+
+  - [RuleHostNetNamespace]: container shares the initial network namespace.
+  - [RuleHostPIDNamespace]: container shares the initial PID namespace.
+  - [RuleHostIPCNamespace]: container shares the initial IPC namespace.
+  - [RuleHostUTSNamespace]: container shares the initial UTS namespace.
+  - [RuleHostUserNamespace]: container shares the initial user namespace.
+  - [RuleHostRootMount]: the root of the host's filesystem is bind-mounted
+    into the container.
+  - [RuleEngineSocketMount]: a container engine API socket is bind-mounted
+    into the container.
+  - [RuleFullCapabilities]: container holds full capabilities in the initial
+    user namespace.
+*/
+package audit
@@ -36,15 +36,22 @@ const (
 // passed command, where the returned function returns true if the given
 // Linux-kernel namespace passes the namespace type filter.
 func New(cmd *cobra.Command) func(model.Namespace) bool {
+	filtermask := Mask(cmd)
+	return func(ns model.Namespace) bool {
+		return ns.Type()&filtermask != 0
+	}
+}
+
+// Mask returns the namespace types selected by the filter flag of the passed
+// command, OR'ed together.
+func Mask(cmd *cobra.Command) species.NamespaceType {
 	filters := cmd.PersistentFlags().Lookup(FilterFlagName).
 		Value.(*enumflag.EnumFlagValue[species.NamespaceType]).GetSliceValue()
 	filtermask := species.NamespaceType(0)
 	for _, f := range filters {
 		filtermask |= f
 	}
-	return func(ns model.Namespace) bool {
-		return ns.Type()&filtermask != 0
-	}
+	return filtermask
 }
 
 // The default user-controlled namespace filters: showing all types of
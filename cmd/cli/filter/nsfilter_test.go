@@ -62,6 +62,8 @@ var _ = Describe("--filter flag", func() {
 			species.CLONE_NEWUTS,
 			species.CLONE_NEWUSER,
 		))
+		Expect(Mask(&cmd)).To(Equal(species.CLONE_NEWNS | species.CLONE_NEWCGROUP |
+			species.CLONE_NEWUTS | species.CLONE_NEWUSER))
 	})
 
 })
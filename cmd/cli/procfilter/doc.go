@@ -0,0 +1,10 @@
+/*
+Package procfilter provides CLI-controlled filtering of processes by their
+credentials, seccomp modes, and no_new_privs flags. It provides the “--euid”,
+“--seccomp”, and “--no-new-privs” CLI flags.
+
+Use [procfilter.New] to get a process filter function configured from these
+flags, and [procfilter.DiscoveryOption] to get the discovery option needed in
+order to discover process credentials in the first place.
+*/
+package procfilter
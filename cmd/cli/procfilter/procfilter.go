@@ -0,0 +1,143 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package procfilter
+
+import (
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/thediveo/clippy/cliplugin"
+	"github.com/thediveo/enumflag/v2"
+	"github.com/thediveo/go-plugger/v3"
+
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/model"
+)
+
+// Names of the CLI flags provided in this package.
+const (
+	EUIDFlagName       = "euid"
+	SeccompFlagName    = "seccomp"
+	NoNewPrivsFlagName = "no-new-privs"
+)
+
+// NoNewPrivs selects processes based on their no_new_privs flag.
+type NoNewPrivs enumflag.Flag
+
+// The process selections based on the no_new_privs flag.
+const (
+	NoNewPrivsAny   NoNewPrivs = iota // don't select on no_new_privs.
+	NoNewPrivsSet                     // only processes with no_new_privs set.
+	NoNewPrivsUnset                   // only processes without no_new_privs.
+)
+
+// Maps no_new_privs selections to their CLI flag values.
+var noNewPrivsIds = map[NoNewPrivs][]string{
+	NoNewPrivsAny:   {"any"},
+	NoNewPrivsSet:   {"set"},
+	NoNewPrivsUnset: {"unset"},
+}
+
+// Maps seccomp modes to their CLI flag values.
+var seccompIds = map[model.SeccompMode][]string{
+	model.SeccompDisabled: {string(model.SeccompDisabled)},
+	model.SeccompStrict:   {string(model.SeccompStrict)},
+	model.SeccompFilter:   {string(model.SeccompFilter)},
+}
+
+// Enabled returns true if processes should be filtered by their credentials,
+// otherwise false.
+func Enabled(cmd *cobra.Command) bool {
+	flags := cmd.PersistentFlags()
+	return flags.Changed(EUIDFlagName) ||
+		flags.Changed(SeccompFlagName) ||
+		flags.Changed(NoNewPrivsFlagName)
+}
+
+// DiscoveryOption returns a [discover.WithCredentials] option func when
+// filtering processes by their credentials has been requested on the passed
+// cmd, otherwise nil.
+func DiscoveryOption(cmd *cobra.Command) discover.DiscoveryOption {
+	if !Enabled(cmd) {
+		return nil
+	}
+	return discover.WithCredentials()
+}
+
+// New returns a process filter function configured from the flags of the
+// passed command, where the returned function returns true if the given
+// process passes the filter. Processes without discovered credentials never
+// pass the filter. New returns nil if no filtering has been requested.
+func New(cmd *cobra.Command) func(*model.Process) bool {
+	if !Enabled(cmd) {
+		return nil
+	}
+	flags := cmd.PersistentFlags()
+	euids, _ := flags.GetUintSlice(EUIDFlagName)
+	seccomps := flags.Lookup(SeccompFlagName).
+		Value.(*enumflag.EnumFlagValue[model.SeccompMode]).GetSliceValue()
+	nonewprivs := flags.Lookup(NoNewPrivsFlagName).
+		Value.(*enumflag.EnumFlagValue[NoNewPrivs]).GetValue()
+	return func(proc *model.Process) bool {
+		credentials := proc.Credentials
+		if credentials == nil {
+			return false
+		}
+		if len(euids) != 0 && !slices.Contains(euids, uint(credentials.UIDs.Effective)) {
+			return false
+		}
+		if len(seccomps) != 0 && !slices.Contains(seccomps, credentials.Seccomp) {
+			return false
+		}
+		switch nonewprivs {
+		case NoNewPrivsSet:
+			return credentials.NoNewPrivs
+		case NoNewPrivsUnset:
+			return !credentials.NoNewPrivs
+		}
+		return true
+	}
+}
+
+// Register our plugin functions for delayed registration of CLI flags we bring
+// into the game and the things to check or carry out before the selected
+// command is finally run.
+func init() {
+	plugger.Group[cliplugin.SetupCLI]().Register(
+		SetupCLI, plugger.WithPlugin("lxkns/procfilter"))
+}
+
+// SetupCLI adds the "--euid", "--seccomp", and "--no-new-privs" flags to the
+// specified command. The "--euid" and "--seccomp" flags accept sets of values,
+// either separated by commas, or specified using multiple flags.
+func SetupCLI(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.UintSlice(EUIDFlagName, nil,
+		"shows only processes with the specified effective UIDs")
+	var seccompsValue []model.SeccompMode
+	flags.Var(
+		enumflag.NewSlice(&seccompsValue, SeccompFlagName, seccompIds,
+			enumflag.EnumCaseSensitive),
+		SeccompFlagName,
+		"shows only processes with the specified seccomp modes; can be\n"+
+			"'disabled', 'strict', 'filter'")
+	nonewprivsValue := NoNewPrivsAny
+	flags.Var(
+		enumflag.New(&nonewprivsValue, NoNewPrivsFlagName, noNewPrivsIds,
+			enumflag.EnumCaseSensitive),
+		NoNewPrivsFlagName,
+		"shows only processes with the no_new_privs flag 'set' or 'unset'; can\n"+
+			"also be 'any'")
+}
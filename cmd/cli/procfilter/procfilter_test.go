@@ -0,0 +1,92 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package procfilter
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/thediveo/safe"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func proc(euid uint32, seccomp model.SeccompMode, nonewprivs bool) *model.Process {
+	return &model.Process{
+		ProTaskCommon: model.ProTaskCommon{
+			Credentials: &model.Credentials{
+				UIDs:       model.CredentialIDs{Effective: euid},
+				Seccomp:    seccomp,
+				NoNewPrivs: nonewprivs,
+			},
+		},
+	}
+}
+
+func execute(args ...string) (*cobra.Command, error) {
+	cmd := &cobra.Command{}
+	cmd.SetArgs(args)
+	SetupCLI(cmd)
+
+	var out safe.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	return cmd, cmd.Execute()
+}
+
+var _ = Describe("process credentials filter flags", func() {
+
+	It("doesn't filter unless asked to", func() {
+		cmd, err := execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Enabled(cmd)).To(BeFalse())
+		Expect(DiscoveryOption(cmd)).To(BeNil())
+		Expect(New(cmd)).To(BeNil())
+	})
+
+	It("rejects unknown seccomp modes", func() {
+		_, err := execute("--seccomp=foobar")
+		Expect(err).To(MatchError(MatchRegexp(`^invalid argument "foobar"`)))
+	})
+
+	It("filters by effective UIDs", func() {
+		cmd, err := execute("--euid=0,1000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(DiscoveryOption(cmd)).NotTo(BeNil())
+		flt := New(cmd)
+		Expect(flt(proc(0, model.SeccompDisabled, false))).To(BeTrue())
+		Expect(flt(proc(1000, model.SeccompFilter, true))).To(BeTrue())
+		Expect(flt(proc(42, model.SeccompDisabled, false))).To(BeFalse())
+		Expect(flt(&model.Process{})).To(BeFalse())
+	})
+
+	It("filters by seccomp modes and no_new_privs", func() {
+		cmd, err := execute("--seccomp=disabled", "--seccomp=strict", "--no-new-privs=unset")
+		Expect(err).NotTo(HaveOccurred())
+		flt := New(cmd)
+		Expect(flt(proc(0, model.SeccompDisabled, false))).To(BeTrue())
+		Expect(flt(proc(0, model.SeccompStrict, false))).To(BeTrue())
+		Expect(flt(proc(0, model.SeccompFilter, false))).To(BeFalse())
+		Expect(flt(proc(0, model.SeccompDisabled, true))).To(BeFalse())
+
+		cmd, err = execute("--no-new-privs=set")
+		Expect(err).NotTo(HaveOccurred())
+		flt = New(cmd)
+		Expect(flt(proc(0, model.SeccompDisabled, true))).To(BeTrue())
+		Expect(flt(proc(0, model.SeccompDisabled, false))).To(BeFalse())
+	})
+
+})
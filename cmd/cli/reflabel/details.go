@@ -0,0 +1,55 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package reflabel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// NamespaceTypeIDString returns the type and ID of the specified namespace in
+// the form of “net:[4026531840]”. UTS namespaces with a known host name are
+// identified by their host name instead, such as “uts:"foobar"”, as this is
+// much easier to read than inode numbers.
+func NamespaceTypeIDString(ns model.Namespace) string {
+	if uns, ok := ns.(model.UTSNames); ok && uns.Nodename() != "" {
+		return ns.Type().Name() + ":" + strconv.Quote(uns.Nodename())
+	}
+	return ns.(model.NamespaceStringer).TypeIDString()
+}
+
+// NamespaceDetailsLabel returns a string describing type-specific details of
+// the specified namespace, such as the clock offsets of time namespaces, the
+// NIS domain names of UTS namespaces, or the roots of cgroup namespaces. It
+// returns an empty string if there are no details known.
+func NamespaceDetailsLabel(ns model.Namespace) string {
+	switch ns := ns.(type) {
+	case model.TimeOffsets:
+		if offsets := ns.ClockOffsets(); offsets != nil {
+			return fmt.Sprintf("[clock offsets %s]", offsets)
+		}
+	case model.UTSNames:
+		if domainname := ns.Domainname(); domainname != "" && domainname != "(none)" {
+			return fmt.Sprintf("[domain name %q]", domainname)
+		}
+	case model.CgroupNamespaceRoot:
+		if root := ns.CgroupRoot(); root != "" {
+			return fmt.Sprintf("[cgroup root %q]", root)
+		}
+	}
+	return ""
+}
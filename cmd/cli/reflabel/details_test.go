@@ -0,0 +1,58 @@
+// Copyright 2020 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package reflabel
+
+import (
+	"time"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("namespace details", func() {
+
+	It("labels UTS namespaces by their host names", func() {
+		netns := namespaces.New(species.CLONE_NEWNET, species.NamespaceIDfromInode(42), nil)
+		Expect(NamespaceTypeIDString(netns)).To(Equal("net:[42]"))
+		Expect(NamespaceDetailsLabel(netns)).To(BeEmpty())
+
+		utsns := namespaces.New(species.CLONE_NEWUTS, species.NamespaceIDfromInode(42), nil)
+		Expect(NamespaceTypeIDString(utsns)).To(Equal("uts:[42]"))
+		utsns.(namespaces.UTSConfigurer).SetUTSNames("foobar", "(none)")
+		Expect(NamespaceTypeIDString(utsns)).To(Equal(`uts:"foobar"`))
+		Expect(NamespaceDetailsLabel(utsns)).To(BeEmpty())
+		utsns.(namespaces.UTSConfigurer).SetUTSNames("foobar", "baz")
+		Expect(NamespaceDetailsLabel(utsns)).To(Equal(`[domain name "baz"]`))
+	})
+
+	It("labels time namespaces with their clock offsets", func() {
+		timens := namespaces.New(species.CLONE_NEWTIME, species.NamespaceIDfromInode(42), nil)
+		Expect(NamespaceDetailsLabel(timens)).To(BeEmpty())
+		timens.(namespaces.TimeConfigurer).SetClockOffsets(&model.ClockOffsets{Boottime: time.Second})
+		Expect(NamespaceDetailsLabel(timens)).To(Equal("[clock offsets monotonic 0s, boottime +1s]"))
+	})
+
+	It("labels cgroup namespaces with their roots", func() {
+		cgroupns := namespaces.New(species.CLONE_NEWCGROUP, species.NamespaceIDfromInode(42), nil)
+		Expect(NamespaceDetailsLabel(cgroupns)).To(BeEmpty())
+		cgroupns.(namespaces.CgroupConfigurer).SetCgroupRoot("/foo.slice")
+		Expect(NamespaceDetailsLabel(cgroupns)).To(Equal(`[cgroup root "/foo.slice"]`))
+	})
+
+})
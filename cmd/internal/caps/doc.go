@@ -1,5 +0,0 @@
-/*
-Package caps provides textual information about the effective capabilities of a
-process.
-*/
-package caps
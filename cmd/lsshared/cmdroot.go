@@ -0,0 +1,138 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/thediveo/clippy"
+	_ "github.com/thediveo/clippy/debug"
+
+	"github.com/thediveo/lxkns"
+	apitypes "github.com/thediveo/lxkns/api/types"
+	"github.com/thediveo/lxkns/cmd/cli/filter"
+	"github.com/thediveo/lxkns/cmd/cli/reflabel"
+	"github.com/thediveo/lxkns/cmd/cli/silent"
+	"github.com/thediveo/lxkns/cmd/cli/style"
+	"github.com/thediveo/lxkns/cmd/cli/turtles"
+	"github.com/thediveo/lxkns/decorator/kuhbernetes"
+	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/internal/xstrings"
+	"github.com/thediveo/lxkns/model"
+)
+
+// lsshared lists the namespaces shared by multiple containers, either as text
+// or as JSON.
+func lsshared(cmd *cobra.Command, _ []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cizer := turtles.Containerizer(ctx, cmd)
+	defer cizer.Close()
+	allns := discover.Namespaces(
+		discover.WithStandardDiscovery(),
+		discover.WithContainerizer(cizer),
+		discover.WithPIDMapper(), // recommended when using WithContainerizer.
+	)
+	// Unless explicitly asked for, skip the user and time namespaces most
+	// containers share with the host anyway.
+	nstypes := model.DefaultSharedNamespaceTypes
+	if cmd.PersistentFlags().Changed(filter.FilterFlagName) {
+		nstypes = filter.Mask(cmd)
+	}
+	// Sharing namespaces inside the same pod is intended, while all other
+	// sharing is unexpected.
+	sharings := allns.Containers.SharedNamespaces(
+		allns.InitialNamespaces, nstypes, kuhbernetes.PodGroupType)
+	if unexpected, _ := cmd.PersistentFlags().GetBool("unexpected"); unexpected {
+		sharings = sharings.Unexpected()
+	}
+	if asjson, _ := cmd.PersistentFlags().GetBool("json"); asjson {
+		jsondata, err := json.MarshalIndent(apitypes.NewNamespaceSharings(sharings), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(jsondata))
+		return err
+	}
+	return renderSharings(cmd.OutOrStdout(), sharings)
+}
+
+// renderSharings renders the specified namespace sharings as text to the
+// specified writer, listing the sharing containers below each shared
+// namespace.
+func renderSharings(out io.Writer, sharings model.NamespaceSharings) error {
+	if len(sharings) == 0 {
+		_, err := fmt.Fprintln(out, "no shared namespaces")
+		return err
+	}
+	for _, sharing := range sharings {
+		ns := sharing.Namespace
+		label := xstrings.Join(style.Styles[ns.Type().Name()].
+			V(ns.(model.NamespaceStringer).TypeIDString()).String(),
+			reflabel.NamespaceDetailsLabel(ns))
+		if group := sharing.Group; group != nil {
+			label += fmt.Sprintf(" shared inside %s %q", group.Type, group.Name)
+		} else if sharing.Initial {
+			label += " shared with the host"
+		} else {
+			label += " shared unexpectedly"
+		}
+		if _, err := fmt.Fprintln(out, label); err != nil {
+			return err
+		}
+		for _, container := range sharing.Containers {
+			clabel := fmt.Sprintf("   ⋄─ container %q (%s)",
+				style.ContainerStyle.V(container.Name), container.Type)
+			if sharing.Group == nil {
+				for _, group := range container.Groups {
+					clabel += fmt.Sprintf(" in %s %q", group.Type, group.Name)
+				}
+			}
+			if _, err := fmt.Fprintln(out, clabel); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newRootCmd creates the root command with usage and version information, as
+// well as the available CLI flags (including descriptions).
+func newRootCmd() (rootCmd *cobra.Command) {
+	rootCmd = &cobra.Command{
+		Use:     "lsshared",
+		Short:   "lsshared shows the namespaces shared by containers",
+		Version: lxkns.SemVersion,
+		Args:    cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return clippy.BeforeCommand(cmd)
+		},
+		RunE: lsshared,
+	}
+	silent.PreferSilence(rootCmd)
+	// Sets up the flags.
+	rootCmd.PersistentFlags().BoolP(
+		"unexpected", "x", false,
+		"shows only namespaces shared unexpectedly, such as outside pods")
+	rootCmd.PersistentFlags().Bool(
+		"json", false,
+		"output shared namespaces as JSON")
+	clippy.AddFlags(rootCmd)
+	return
+}
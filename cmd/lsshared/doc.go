@@ -0,0 +1,47 @@
+/*
+lsshared lists the namespaces shared by multiple containers, together with the
+containers sharing them.
+
+Sharing namespaces between the containers of the same Kubernetes pod is
+intended. In contrast, all other sharing is unexpected, such as containers
+joining the network namespace of an unrelated container using “--network
+container:x”, or containers sharing the initial PID namespace with the host
+using “--pid host”.
+
+Unless told otherwise using the “--filter” flag, lsshared leaves out user and
+time namespaces, as most containers share the initial user and time
+namespaces with the host.
+
+# Usage
+
+To use lsshared:
+
+	lsshared [flag]
+
+For example, to list only unexpectedly shared network and PID namespaces:
+
+	lsshared -x -f net,pid
+
+# Flags
+
+The following lsshared flags are available:
+
+	-c, --color color[=always]   colorize the output; can be 'always' (default if omitted), 'auto',
+	                             or 'never' (default auto)
+	    --dump                   dump colorization theme to stdout (for saving to ~/.lxknsrc.yaml)
+	-f, --filter filter          shows only selected namespace types; can be 'cgroup'/'c', 'ipc'/'i', 'mnt'/'m',
+	                             'net'/'n', 'pid'/'p', 'time/t', 'user'/'U', 'uts'/'u'
+	                             (default [mnt,cgroup,uts,ipc,user,pid,net,time])
+	-h, --help                   help for lsshared
+	    --json                   output shared namespaces as JSON
+	    --no-containers          skip container discovery
+	    --proc proc[=name]       process name style; can be 'name' (default if omitted), 'basename',
+	                             or 'exe' (default name)
+	    --theme theme            colorization theme 'dark' or 'light' (default dark)
+	    --treestyle treestyle    select the tree render style; can be 'line' or 'ascii' (default line)
+	-x, --unexpected             shows only namespaces shared unexpectedly, such as outside pods
+	-v, --version                version for lsshared
+	    --wait duration          max duration to wait for container engine workload synchronization
+	                             before continuing (default 3s)
+*/
+package main
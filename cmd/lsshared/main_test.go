@@ -0,0 +1,114 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/thediveo/clippy/debug"
+	"github.com/thediveo/safe"
+
+	"github.com/thediveo/lxkns/cmd/cli/turtles"
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("lists shared namespaces", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).Within(2 * time.Second).WithPolling(100 * time.Millisecond).
+				ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("fails for unknown CLI flag", func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"--foobar"})
+		var out safe.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).NotTo(Succeed())
+		Expect(out.String()).To(MatchRegexp(`^Error: unknown flag: --foobar`))
+	})
+
+	It("lists no shared namespaces without containers", func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"--" + turtles.NoContainersFlagName})
+		var out safe.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(Equal("no shared namespaces\n"))
+
+		cmd = newRootCmd()
+		cmd.SetArgs([]string{"--json", "-x", "--" + turtles.NoContainersFlagName})
+		var out2 safe.Buffer
+		cmd.SetOut(&out2)
+		cmd.SetErr(&out2)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out2.String()).To(MatchJSON(`[]`))
+	})
+
+	It("renders shared namespaces", func() {
+		netns1 := namespaces.NewWithSimpleRef(species.CLONE_NEWNET, species.NamespaceIDfromInode(1), "")
+		netns2 := namespaces.NewWithSimpleRef(species.CLONE_NEWNET, species.NamespaceIDfromInode(2), "")
+		pod := &model.Group{Name: "default/foo", Type: "io.kubernetes.pod"}
+		sandbox := &model.Container{Name: "sandbox", Type: "containerd.io"}
+		workload := &model.Container{Name: "workload", Type: "containerd.io"}
+		pod.AddContainer(sandbox)
+		pod.AddContainer(workload)
+		joiner := &model.Container{Name: "joiner", Type: "docker.com"}
+		timens := namespaces.NewWithSimpleRef(species.CLONE_NEWTIME, species.NamespaceIDfromInode(3), "")
+		timens.(namespaces.TimeConfigurer).SetClockOffsets(&model.ClockOffsets{Boottime: time.Second})
+		pidns := namespaces.NewWithSimpleRef(species.CLONE_NEWPID, species.NamespaceIDfromInode(4), "")
+
+		var out safe.Buffer
+		Expect(renderSharings(&out, model.NamespaceSharings{
+			{Namespace: netns1, Containers: model.Containers{sandbox, workload}, Group: pod},
+			{Namespace: netns2, Containers: model.Containers{joiner, workload}},
+			{Namespace: timens, Containers: model.Containers{joiner, sandbox}},
+			{Namespace: pidns, Containers: model.Containers{joiner, sandbox}, Initial: true},
+		})).To(Succeed())
+		Expect(out.String()).To(Equal(`net:[1] shared inside io.kubernetes.pod "default/foo"
+   ⋄─ container "sandbox" (containerd.io)
+   ⋄─ container "workload" (containerd.io)
+net:[2] shared unexpectedly
+   ⋄─ container "joiner" (docker.com)
+   ⋄─ container "workload" (containerd.io) in io.kubernetes.pod "default/foo"
+time:[3] [clock offsets monotonic 0s, boottime +1s] shared unexpectedly
+   ⋄─ container "joiner" (docker.com)
+   ⋄─ container "sandbox" (containerd.io) in io.kubernetes.pod "default/foo"
+pid:[4] shared with the host
+   ⋄─ container "joiner" (docker.com)
+   ⋄─ container "sandbox" (containerd.io) in io.kubernetes.pod "default/foo"
+`))
+	})
+
+})
@@ -50,12 +50,18 @@ func newRootCmd() (rootCmd *cobra.Command) {
 			defer cancel()
 			cizer := turtles.Containerizer(ctx, cmd)
 			defer cizer.Close()
-			allns := discover.Namespaces(
+			opts := []discover.DiscoveryOption{
 				discover.WithStandardDiscovery(),
 				discover.WithContainerizer(cizer),
 				discover.WithPIDMapper(), // recommended when using WithContainerizer.
 				task.DiscoveryOption(cmd),
-			)
+			}
+			// Only the details show owned namespaces, so only then we need to
+			// discover their UTS names and cgroup roots.
+			if details {
+				opts = append(opts, discover.WithUTSNames(), discover.WithCgroupRoots())
+			}
+			allns := discover.Namespaces(opts...)
 			_, err := fmt.Fprint(cmd.OutOrStdout(),
 				asciitree.Render(
 					allns.UserNSRoots,
@@ -64,6 +70,7 @@ func newRootCmd() (rootCmd *cobra.Command) {
 						Filter:                  filter.New(rootCmd),
 						NamespaceIcon:           icon.NamespaceIcon(cmd),
 						NamespaceReferenceLabel: reflabel.NamespaceReferenceLabel(cmd),
+						IDMap:                   allns.IDMap,
 					},
 					style.NamespaceStyler))
 			return err
@@ -73,7 +80,7 @@ func newRootCmd() (rootCmd *cobra.Command) {
 	// Sets up the flags.
 	rootCmd.PersistentFlags().BoolP(
 		"details", "d", false,
-		"shows details, such as owned namespaces")
+		"shows details, such as owned namespaces and ID mappings")
 	clippy.AddFlags(rootCmd)
 	return
 }
@@ -20,7 +20,7 @@ The following lsuns flags are available:
 	    --cgroup cgformat        control group name display; can be 'full' or 'short' (default short)
 	-c, --color color[=always]   colorize the output; can be 'always' (default if omitted), 'auto',
 	                             or 'never' (default auto)
-	-d, --details                shows details, such as owned namespaces
+	-d, --details                shows details, such as owned namespaces and ID mappings
 	    --dump                   dump colorization theme to stdout (for saving to ~/.lxknsrc.yaml)
 	-f, --filter filter          shows only selected namespace types; can be 'cgroup'/'c', 'ipc'/'i', 'mnt'/'m',
 	                             'net'/'n', 'pid'/'p', 'user'/'U', 'uts'/'u' (default [mnt,cgroup,uts,ipc,user,pid,net])
@@ -111,7 +111,7 @@ var _ = Describe("renders user namespaces", func() {
 		Expect(cmd.Execute()).To(Succeed())
 		output := out.String()
 
-		Expect(output).To(MatchRegexp(fmt.Sprintf(`(?m)^user:\[%d\] .*$`,
+		Expect(output).To(MatchRegexp(fmt.Sprintf(`(?m)^user:\[%d\] .* \[.*uid map .*, gid map .*\]$`,
 			ourUsernsID)))
 		Expect(output).To(MatchRegexp(fmt.Sprintf(`
 (?m)^[├└]─ user:\[%d\] process .*
@@ -23,6 +23,7 @@ import (
 
 	"github.com/thediveo/go-asciitree/v2"
 
+	"github.com/thediveo/lxkns/cmd/cli/reflabel"
 	"github.com/thediveo/lxkns/cmd/cli/style"
 	"github.com/thediveo/lxkns/discover"
 	"github.com/thediveo/lxkns/internal/xslices"
@@ -44,6 +45,9 @@ type UserNSVisitor struct {
 	// render function for namespace references in form of either process names
 	// (as well as additional process properties) or file system references.
 	NamespaceReferenceLabel func(model.Namespace) string
+	// optional user and group ID translator, for showing the host UIDs of the
+	// root users in user namespaces.
+	IDMap model.IDMapper
 }
 
 var _ asciitree.Visitor = (*UserNSVisitor)(nil)
@@ -78,12 +82,83 @@ func (v *UserNSVisitor) Label(node any) (label string) {
 	return
 }
 
+// idMappingLabel returns a text label describing the ID mappings of a user
+// namespace, if known, flagging user namespaces as either “rootless” or
+// “idmapped” when they don't map IDs 1:1. Rootless user namespaces have been
+// created by a non-root user, whereas idmapped user namespaces have been
+// created by root. Additionally, the host UID of the user namespace's root user
+// is shown, if known.
+func (v *UserNSVisitor) idMappingLabel(node any) string {
+	idm, ok := node.(model.IDMapping)
+	if !ok || (len(idm.UIDMap()) == 0 && len(idm.GIDMap()) == 0) {
+		return ""
+	}
+	var kind string
+	if !idm.UIDMap().IsIdentity() || !idm.GIDMap().IsIdentity() {
+		kind = "idmapped, "
+		if uns, ok := node.(model.Ownership); ok && uns.UID() != 0 {
+			kind = "rootless, "
+		}
+	}
+	label := fmt.Sprintf("[%suid map %s, gid map %s",
+		kind, style.OwnerStyle.V(idMapText(idm.UIDMap())), style.OwnerStyle.V(idMapText(idm.GIDMap())))
+	if setgroups := idm.Setgroups(); setgroups != model.SetgroupsUnknown {
+		label += fmt.Sprintf(", setgroups %s", setgroups)
+	}
+	if hostuid, ok := v.hostRootUID(node); ok {
+		label += fmt.Sprintf(", root is host UID %d", style.OwnerStyle.V(hostuid))
+		if user, err := user.LookupId(fmt.Sprintf("%d", hostuid)); err == nil {
+			label += fmt.Sprintf(" (%q)", style.OwnerStyle.V(user.Username))
+		}
+	}
+	return label + "]"
+}
+
+// hostRootUID returns the UID in the topmost user namespace of the hierarchy
+// that the root user of the specified user namespace maps onto. It returns
+// false if the specified user namespace already is the topmost user namespace,
+// or if the root user cannot be translated.
+func (v *UserNSVisitor) hostRootUID(node any) (uint32, bool) {
+	if v.IDMap == nil {
+		return 0, false
+	}
+	userns, ok := node.(model.Namespace)
+	if !ok {
+		return 0, false
+	}
+	hostns := userns
+	for {
+		parent := hostns.(model.Hierarchy).Parent()
+		if parent == nil {
+			break
+		}
+		hostns = parent.(model.Namespace)
+	}
+	if hostns == userns {
+		return 0, false
+	}
+	return v.IDMap.Translate(0, model.UserIDs, userns, hostns)
+}
+
+// idMapText returns the textual representation of an ID mapping, abbreviating
+// identity mappings.
+func idMapText(idmap model.IDMap) string {
+	if idmap.IsIdentity() {
+		return "1:1"
+	}
+	return idmap.String()
+}
+
 // Get returns the user namespace text label for the current node (which is
 // always a user namespace), as well as the list of properties (owned
 // non-user namespaces) and the list of child user namespace nodes.
 func (v *UserNSVisitor) Get(node any) (label string, properties []string, children []any) {
-	// Determine the label text for this user namespace.
+	// Determine the label text for this user namespace. In case a detailed
+	// tree has been requested, also show the ID mappings.
 	label = v.Label(node)
+	if v.Details {
+		label = xstrings.Join(label, v.idMappingLabel(node))
+	}
 	// Determine the children of this user namespace, which are in turn user
 	// namespaces.
 	if hierns, ok := node.(model.Hierarchy); ok {
@@ -109,10 +184,11 @@ func (v *UserNSVisitor) Get(node any) (label string, properties []string, childr
 						continue
 					}
 					style := style.Styles[ns.Type().Name()]
-					s := fmt.Sprintf("%s%s %s",
+					s := xstrings.Join(fmt.Sprintf("%s%s %s",
 						v.NamespaceIcon(ns),
-						style.V(ns.(model.NamespaceStringer).TypeIDString()),
-						v.NamespaceReferenceLabel(ns))
+						style.V(reflabel.NamespaceTypeIDString(ns)),
+						v.NamespaceReferenceLabel(ns)),
+						reflabel.NamespaceDetailsLabel(ns))
 					properties = append(properties, s)
 				}
 			}
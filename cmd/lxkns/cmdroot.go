@@ -35,7 +35,6 @@ import (
 	"github.com/thediveo/lxkns"
 	_ "github.com/thediveo/lxkns/cmd/cli/silent"
 	"github.com/thediveo/lxkns/cmd/cli/turtles"
-	"github.com/thediveo/lxkns/cmd/internal/caps"
 	"github.com/thediveo/lxkns/decorator"
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/ops"
@@ -162,7 +161,10 @@ func lxknsservice(cmd *cobra.Command, _ []string) error {
 	}
 
 	slog.Info("running as", slog.Int("userid", os.Geteuid()))
-	mycaps := strings.Join(caps.ProcessCapabilities(model.PIDType(os.Getpid())), ", ")
+	mycaps := ""
+	if capabilities, err := model.ReadCapabilities("/proc/self/status"); err == nil {
+		mycaps = strings.Join(capabilities.Effective, ", ")
+	}
 	if mycaps == "" {
 		mycaps = "<none>"
 	}
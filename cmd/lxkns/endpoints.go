@@ -18,25 +18,82 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/thediveo/lxkns/api/types"
+	"github.com/thediveo/lxkns/audit"
 	"github.com/thediveo/lxkns/containerizer"
+	"github.com/thediveo/lxkns/decorator/kuhbernetes"
 	"github.com/thediveo/lxkns/discover"
+	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/species"
 )
 
+// namespacesQueryOptions maps the query parameters of the namespaces endpoint
+// to the additional discovery options they opt in to. As these discoveries
+// either need to switch into namespaces or to read additional proc
+// filesystem entries of all processes, they default to off.
+var namespacesQueryOptions = map[string]discover.DiscoveryOption{
+	"with-uts-names":          discover.WithUTSNames(),
+	"with-cgroup-roots":       discover.WithCgroupRoots(),
+	"with-ipc-objects":        discover.WithIPCObjects(),
+	"with-network-interfaces": discover.WithNetworkInterfaces(),
+	"with-capabilities":       discover.WithCapabilities(),
+	"with-credentials":        discover.WithCredentials(),
+	"with-lsm-contexts":       discover.WithLSMContexts(),
+}
+
+// discoverNamespaces runs a namespace discovery with the specified options,
+// aborting it when the client goes away before the discovery has finished.
+// In case the discovery was aborted, discoverNamespaces replies with an error
+// status and returns nil.
+func discoverNamespaces(w http.ResponseWriter, req *http.Request, opts ...discover.DiscoveryOption) *discover.Result {
+	result, err := discover.NamespacesContext(req.Context(), opts...)
+	if err != nil {
+		slog.Warn("namespace discovery aborted",
+			slog.String("path", req.URL.Path),
+			slog.String("err", err.Error()))
+		http.Error(w, "namespace discovery aborted", http.StatusServiceUnavailable)
+		return nil
+	}
+	return result
+}
+
 // GetNamespacesHandler takes a containerizer and then returns a handler
 // function that returns the results of a namespace discovery, as JSON.
-// Additionally, we opt in to mount path+point discovery.
+// Additionally, we opt in to mount path+point discovery. Further details can
+// be opted in using query parameters, such as "?with-capabilities=true"; a
+// query parameter without a value also opts in.
 func GetNamespacesHandler(cizer containerizer.Containerizer) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		allns := discover.Namespaces(
+		opts := []discover.DiscoveryOption{
 			discover.WithFullDiscovery(),
 			discover.WithContainerizer(cizer),
 			discover.WithPIDMapper(), // recommended when using WithContainerizer.
 			discover.WithAffinityAndScheduling(),
 			discover.WithTaskAffinityAndScheduling(),
-		)
+		}
+		query := req.URL.Query()
+		for name, opt := range namespacesQueryOptions {
+			if !query.Has(name) {
+				continue
+			}
+			enabled := true
+			if value := query.Get(name); value != "" {
+				var err error
+				if enabled, err = strconv.ParseBool(value); err != nil {
+					http.Error(w, "invalid value for query parameter "+name, http.StatusBadRequest)
+					return
+				}
+			}
+			if enabled {
+				opts = append(opts, opt)
+			}
+		}
+		allns := discoverNamespaces(w, req, opts...)
+		if allns == nil {
+			return
+		}
 		// Note bene: set header before writing the header with the status code;
 		// actually makes sense, innit?
 		w.Header().Set("Content-Type", "application/json")
@@ -53,11 +110,14 @@ func GetNamespacesHandler(cizer containerizer.Containerizer) http.HandlerFunc {
 // GetProcessesHandler returns the process table (including tasks) with
 // namespace references, as JSON.
 func GetProcessesHandler(w http.ResponseWriter, req *http.Request) {
-	disco := discover.Namespaces(
+	disco := discoverNamespaces(w, req,
 		discover.FromProcs(),
 		discover.FromTasks(),
 		discover.WithAffinityAndScheduling(),
 	)
+	if disco == nil {
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 
@@ -73,10 +133,14 @@ func GetProcessesHandler(w http.ResponseWriter, req *http.Request) {
 // GetPIDMapHandler returns data for translating PIDs between hierarchical PID
 // namespaces, as JSON.
 func GetPIDMapHandler(w http.ResponseWriter, req *http.Request) {
-	pidmap := discover.NewPIDMap(discover.Namespaces(
+	disco := discoverNamespaces(w, req,
 		discover.WithStandardDiscovery(),
 		discover.FromTasks(),
-		discover.WithNamespaceTypes(species.CLONE_NEWPID)))
+		discover.WithNamespaceTypes(species.CLONE_NEWPID))
+	if disco == nil {
+		return
+	}
+	pidmap := discover.NewPIDMap(disco)
 
 	w.Header().Set("Content-Type", "application/json")
 
@@ -88,3 +152,82 @@ func GetPIDMapHandler(w http.ResponseWriter, req *http.Request) {
 			slog.String("err", err.Error()))
 	}
 }
+
+// GetSocketsHandler returns the sockets of all network namespaces, together
+// with the processes and containers having them open, as JSON.
+func GetSocketsHandler(cizer containerizer.Containerizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		disco := discoverNamespaces(w, req,
+			discover.WithStandardDiscovery(),
+			discover.WithContainerizer(cizer),
+			discover.WithPIDMapper(), // recommended when using WithContainerizer.
+			discover.WithSockets(),
+		)
+		if disco == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(types.NewSockets(disco))
+		if err != nil {
+			slog.Error("sockets discovery failed",
+				slog.String("err", err.Error()))
+		}
+	}
+}
+
+// GetAuditHandler returns the findings of auditing the isolation of all
+// containers, as JSON.
+func GetAuditHandler(cizer containerizer.Containerizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		disco := discoverNamespaces(w, req,
+			discover.WithStandardDiscovery(),
+			discover.WithContainerizer(cizer),
+			discover.WithPIDMapper(), // recommended when using WithContainerizer.
+			discover.WithMounts(),
+			discover.WithCapabilities(),
+		)
+		if disco == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(types.NewAuditFindings(audit.Audit(disco)))
+		if err != nil {
+			slog.Error("container audit failed",
+				slog.String("err", err.Error()))
+		}
+	}
+}
+
+// GetSharingHandler returns the namespaces shared by multiple containers, as
+// JSON. Sharing namespaces within the same Kubernetes pod is considered to be
+// intended, while all other sharing is unexpected. User and time namespaces
+// are left out, as most containers share them with the host.
+func GetSharingHandler(cizer containerizer.Containerizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		disco := discoverNamespaces(w, req,
+			discover.WithStandardDiscovery(),
+			discover.WithContainerizer(cizer),
+			discover.WithPIDMapper(), // recommended when using WithContainerizer.
+		)
+		if disco == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.WriteHeader(http.StatusOK)
+		err := json.NewEncoder(w).Encode(types.NewNamespaceSharings(
+			disco.Containers.SharedNamespaces(disco.InitialNamespaces,
+				model.DefaultSharedNamespaceTypes, kuhbernetes.PodGroupType)))
+		if err != nil {
+			slog.Error("namespace sharing analysis failed",
+				slog.String("err", err.Error()))
+		}
+	}
+}
@@ -57,6 +57,9 @@ func startServer(address string, cizer containerizer.Containerizer) (net.Addr, e
 	r.HandleFunc("/api/namespaces", GetNamespacesHandler(cizer)).Methods("GET")
 	r.HandleFunc("/api/processes", GetProcessesHandler).Methods("GET")
 	r.HandleFunc("/api/pidmap", GetPIDMapHandler).Methods("GET")
+	r.HandleFunc("/api/sockets", GetSocketsHandler(cizer)).Methods("GET")
+	r.HandleFunc("/api/audit", GetAuditHandler(cizer)).Methods("GET")
+	r.HandleFunc("/api/sharing", GetSharingHandler(cizer)).Methods("GET")
 	r.PathPrefix("/api").HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) })
 
 	spa := spaserve.NewSPAHandler(os.DirFS("web/lxkns/build"), "index.html")
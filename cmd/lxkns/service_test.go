@@ -30,6 +30,7 @@ import (
 	"github.com/thediveo/whalewatcher/v2/watcher/moby"
 
 	"github.com/thediveo/lxkns/api/types"
+	"github.com/thediveo/lxkns/audit"
 	"github.com/thediveo/lxkns/containerizer/whalefriend"
 	"github.com/thediveo/lxkns/model"
 
@@ -149,6 +150,8 @@ var _ = Describe("serves API endpoints", Ordered, func() {
 			}
 		}
 		Expect(allns.Result().Processes).NotTo(BeEmpty())
+		Expect(allns.Result().Processes).NotTo(ContainElement(
+			HaveField("Capabilities", Not(BeNil()))))
 		Expect(allns.ContainerModel.Containers.Containers).To(ContainElement(
 			PointTo(MatchFields(IgnoreExtras, Fields{
 				"Name": Equal(sleepyname),
@@ -156,6 +159,33 @@ var _ = Describe("serves API endpoints", Ordered, func() {
 		))
 	})
 
+	It("opts in to namespace and process details", func() {
+		clnt := &http.Client{Timeout: 10 * time.Second}
+		defer clnt.CloseIdleConnections()
+		resp, err := clnt.Get(baseurl + "namespaces?with-capabilities&with-credentials=true&with-ipc-objects=false")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = resp.Body.Close() }()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		allns := types.NewDiscoveryResult()
+		Expect(json.NewDecoder(resp.Body).Decode(allns)).To(Succeed())
+		Expect(allns.Result().Options.DiscoverCapabilities).To(BeTrue())
+		Expect(allns.Result().Options.DiscoverCredentials).To(BeTrue())
+		Expect(allns.Result().Options.DiscoverIPCObjects).To(BeFalse())
+		Expect(allns.Result().Processes).To(ContainElement(
+			HaveField("Capabilities", Not(BeNil()))))
+		Expect(allns.Result().Processes).To(ContainElement(
+			HaveField("Credentials", Not(BeNil()))))
+	})
+
+	It("rejects invalid opt-ins", func() {
+		clnt := &http.Client{Timeout: 10 * time.Second}
+		defer clnt.CloseIdleConnections()
+		resp, err := clnt.Get(baseurl + "namespaces?with-capabilities=perhaps")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = resp.Body.Close() }()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
 	It("discovers processes", func() {
 		clnt := &http.Client{Timeout: 10 * time.Second}
 		defer clnt.CloseIdleConnections()
@@ -180,4 +210,47 @@ var _ = Describe("serves API endpoints", Ordered, func() {
 		Expect(pidmap.PIDMap).NotTo(BeEmpty())
 	})
 
+	It("discovers sockets", func() {
+		clnt := &http.Client{Timeout: 10 * time.Second}
+		defer clnt.CloseIdleConnections()
+		resp, err := clnt.Get(baseurl + "sockets")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = resp.Body.Close() }()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var sockets types.Sockets
+		Expect(json.NewDecoder(resp.Body).Decode(&sockets)).To(Succeed())
+		Expect(sockets).NotTo(BeEmpty())
+	})
+
+	It("audits containers", func() {
+		clnt := &http.Client{Timeout: 10 * time.Second}
+		defer clnt.CloseIdleConnections()
+		resp, err := clnt.Get(baseurl + "audit")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = resp.Body.Close() }()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var findings types.AuditFindings
+		Expect(json.NewDecoder(resp.Body).Decode(&findings)).To(Succeed())
+		// Docker doesn't isolate containers in their own user namespaces
+		// unless being told so.
+		Expect(findings).To(ContainElement(And(
+			HaveField("RuleID", audit.RuleHostUserNamespace),
+			HaveField("Container.Name", sleepyname))))
+	})
+
+	It("analyzes namespace sharing", func() {
+		clnt := &http.Client{Timeout: 10 * time.Second}
+		defer clnt.CloseIdleConnections()
+		resp, err := clnt.Get(baseurl + "sharing")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = resp.Body.Close() }()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var sharings types.NamespaceSharings
+		Expect(json.NewDecoder(resp.Body).Decode(&sharings)).To(Succeed())
+		for _, sharing := range sharings {
+			Expect(sharing.Type).NotTo(BeEmpty())
+			Expect(len(sharing.Containers)).To(BeNumerically(">=", 2))
+		}
+	})
+
 })
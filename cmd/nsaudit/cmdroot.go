@@ -0,0 +1,116 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/thediveo/clippy"
+	_ "github.com/thediveo/clippy/debug"
+	"github.com/thediveo/enumflag/v2"
+
+	"github.com/thediveo/lxkns"
+	apitypes "github.com/thediveo/lxkns/api/types"
+	"github.com/thediveo/lxkns/audit"
+	"github.com/thediveo/lxkns/cmd/cli/silent"
+	"github.com/thediveo/lxkns/cmd/cli/turtles"
+	"github.com/thediveo/lxkns/discover"
+)
+
+// Maps severities to their CLI flag values.
+var severityIds = map[audit.Severity][]string{
+	audit.SeverityLow:      {string(audit.SeverityLow)},
+	audit.SeverityMedium:   {string(audit.SeverityMedium)},
+	audit.SeverityHigh:     {string(audit.SeverityHigh)},
+	audit.SeverityCritical: {string(audit.SeverityCritical)},
+}
+
+// nsaudit audits the isolation of the discovered containers and renders the
+// findings either as a table or as JSON.
+func nsaudit(cmd *cobra.Command, severity audit.Severity) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cizer := turtles.Containerizer(ctx, cmd)
+	defer cizer.Close()
+	allns := discover.Namespaces(
+		discover.WithStandardDiscovery(),
+		discover.WithContainerizer(cizer),
+		discover.WithPIDMapper(), // recommended when using WithContainerizer.
+		discover.WithMounts(),
+		discover.WithCapabilities(),
+	)
+	findings := audit.Audit(allns).AtLeast(severity)
+	asjson, _ := cmd.PersistentFlags().GetBool("json")
+	return renderFindings(cmd.OutOrStdout(), findings, asjson)
+}
+
+// renderFindings renders the specified findings either as a table or as JSON
+// to the specified writer.
+func renderFindings(out io.Writer, findings audit.Findings, asjson bool) error {
+	if asjson {
+		jsondata, err := json.MarshalIndent(apitypes.NewAuditFindings(findings), "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(jsondata))
+		return err
+	}
+	if len(findings) == 0 {
+		_, err := fmt.Fprintln(out, "no findings")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SEVERITY\tRULE\tCONTAINER\tMESSAGE")
+	for _, finding := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			finding.Severity, finding.RuleID, finding.Container.Name, finding.Message)
+	}
+	return w.Flush()
+}
+
+// newRootCmd creates the root command with usage and version information, as
+// well as the available CLI flags (including descriptions).
+func newRootCmd() (rootCmd *cobra.Command) {
+	severity := audit.SeverityLow
+	rootCmd = &cobra.Command{
+		Use:     "nsaudit",
+		Short:   "nsaudit audits the isolation of containers",
+		Version: lxkns.SemVersion,
+		Args:    cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return clippy.BeforeCommand(cmd)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return nsaudit(cmd, severity)
+		},
+	}
+	silent.PreferSilence(rootCmd)
+	// Sets up the flags.
+	rootCmd.PersistentFlags().Bool(
+		"json", false,
+		"output findings as JSON")
+	rootCmd.PersistentFlags().Var(
+		enumflag.New(&severity, "severity", severityIds, enumflag.EnumCaseSensitive),
+		"severity",
+		"shows only findings of at least the specified severity; can be\n"+
+			"'low', 'medium', 'high', 'critical'")
+	clippy.AddFlags(rootCmd)
+	return
+}
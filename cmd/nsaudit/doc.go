@@ -0,0 +1,45 @@
+/*
+nsaudit audits the isolation of containers and lists its findings, ordered from
+most to least severe.
+
+# Usage
+
+To use nsaudit:
+
+	nsaudit [flag]
+
+For example, to list only the high and critical findings as JSON:
+
+	nsaudit --severity high --json
+
+# Flags
+
+The following nsaudit flags are available:
+
+	-h, --help                help for nsaudit
+	    --json                output findings as JSON
+	    --no-containers       skip container discovery
+	    --severity severity   shows only findings of at least the specified severity; can be
+	                          'low', 'medium', 'high', 'critical' (default low)
+	-v, --version             version for nsaudit
+	    --wait duration       max duration to wait for container engine workload synchronization
+	                          before continuing (default 3s)
+
+# Findings
+
+nsaudit reports the following findings, using the rule identifiers of the
+[github.com/thediveo/lxkns/audit] package:
+
+  - host-net-namespace (high): container shares the initial network namespace.
+  - host-pid-namespace (high): container shares the initial PID namespace.
+  - host-ipc-namespace (medium): container shares the initial IPC namespace.
+  - host-uts-namespace (low): container shares the initial UTS namespace.
+  - host-user-namespace (low): container shares the initial user namespace.
+  - host-root-mount (critical): the host's root filesystem is bind-mounted into
+    the container.
+  - engine-socket-mount (critical): a container engine API socket is
+    bind-mounted into the container.
+  - full-capabilities (critical): container holds full capabilities in the
+    initial user namespace.
+*/
+package main
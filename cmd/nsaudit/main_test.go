@@ -0,0 +1,114 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/thediveo/clippy/debug"
+	"github.com/thediveo/safe"
+
+	"github.com/thediveo/lxkns/audit"
+	"github.com/thediveo/lxkns/cmd/cli/turtles"
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("audits containers", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).Within(2 * time.Second).WithPolling(100 * time.Millisecond).
+				ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("fails for unknown CLI flag", func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"--foobar"})
+		var out safe.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).NotTo(Succeed())
+		Expect(out.String()).To(MatchRegexp(`^Error: unknown flag: --foobar`))
+	})
+
+	It("rejects invalid severity", func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"--severity", "foobar"})
+		var out safe.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).NotTo(Succeed())
+		Expect(out.String()).To(ContainSubstring(`invalid argument "foobar"`))
+	})
+
+	It("reports no findings without containers", func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"--" + turtles.NoContainersFlagName})
+		var out safe.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(Equal("no findings\n"))
+
+		cmd = newRootCmd()
+		cmd.SetArgs([]string{"--json", "--" + turtles.NoContainersFlagName})
+		var out2 safe.Buffer
+		cmd.SetOut(&out2)
+		cmd.SetErr(&out2)
+		debug.SetWriter(cmd, GinkgoWriter)
+
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out2.String()).To(MatchJSON(`[]`))
+	})
+
+	It("renders findings", func() {
+		findings := audit.Findings{
+			{
+				RuleID:    audit.RuleFullCapabilities,
+				Severity:  audit.SeverityCritical,
+				Message:   "container holds full capabilities",
+				Container: &model.Container{ID: "deadbeef", Name: "fooserver", Type: "docker.com"},
+			},
+		}
+		var out safe.Buffer
+		Expect(renderFindings(&out, findings, false)).To(Succeed())
+		Expect(out.String()).To(Equal(
+			"SEVERITY  RULE               CONTAINER  MESSAGE\n" +
+				"critical  full-capabilities  fooserver  container holds full capabilities\n"))
+
+		var out2 safe.Buffer
+		Expect(renderFindings(&out2, findings, true)).To(Succeed())
+		Expect(out2.String()).To(MatchJSON(`[
+			{"rule-id":"full-capabilities","severity":"critical",
+			 "message":"container holds full capabilities",
+			 "container":{"id":"deadbeef","name":"fooserver","type":"docker.com"}}
+		]`))
+	})
+
+})
@@ -1,4 +1,4 @@
-// Copyright 2020 Harald Albrecht.
+// Copyright 2026 Harald Albrecht.
 //
 // Licensed under the Apache License, Version 2.0 (the "License"); you may not
 // use this file except in compliance with the License. You may obtain a copy
@@ -12,7 +12,7 @@
 // License for the specific language governing permissions and limitations
 // under the License.
 
-package caps
+package main
 
 import (
 	"testing"
@@ -21,7 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestCaps(t *testing.T) {
+func TestNsauditCmd(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "lxkns/cmd/internal/caps package")
+	RunSpecs(t, "nsaudit command")
 }
@@ -21,7 +21,6 @@ import (
 	"strconv"
 	"strings"
 
-	incaps "github.com/thediveo/lxkns/cmd/internal/caps"
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/species"
 )
@@ -92,7 +91,7 @@ func branchOfProcess(proc *model.Process, euid int) (n node, err error) {
 		return nil, fmt.Errorf("cannot query effective UID of process PID %d",
 			proc.PID)
 	}
-	caps := incaps.ProcessCapabilities(proc.PID)
+	caps := processCapabilities(proc.PID)
 	n = &namespaceNode{
 		ns: userns.(model.Namespace),
 		children: []node{
@@ -339,3 +338,15 @@ func processEuid(proc *model.Process) int {
 	_ = scanner.Err()
 	panic("/proc filesystem broken: no Uid element in status.")
 }
+
+// processCapabilities returns the names of the effective capabilities of the
+// process specified by pid. The capability names are lower case and in
+// lexicographic order. If there is an error determining the effective
+// capabilities, then a nil slice is returned.
+func processCapabilities(pid model.PIDType) []string {
+	capabilities, err := model.ReadCapabilities("/proc/" + strconv.Itoa(int(pid)) + "/status")
+	if err != nil {
+		return nil
+	}
+	return capabilities.Effective
+}
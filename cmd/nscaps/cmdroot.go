@@ -125,12 +125,22 @@ func nscapscmd(cmd *cobra.Command, args []string) error {
 	defer cancel()
 	cizer := turtles.Containerizer(ctx, cmd)
 	defer cizer.Close()
-	allns := discover.Namespaces(
+	opts := []discover.DiscoveryOption{
 		discover.WithStandardDiscovery(),
 		discover.WithContainerizer(cizer),
 		discover.WithPIDMapper(), // recommended when using WithContainerizer.
 		task.DiscoveryOption(cmd),
-	)
+	}
+	// Only a target UTS namespace gets labelled with its host and domain
+	// names, and only a target cgroup namespace with its cgroup root, so only
+	// then we need to discover them.
+	switch nst {
+	case species.CLONE_NEWUTS:
+		opts = append(opts, discover.WithUTSNames())
+	case species.CLONE_NEWCGROUP:
+		opts = append(opts, discover.WithCgroupRoots())
+	}
+	allns := discover.Namespaces(opts...)
 	pidmap := allns.PIDMap
 	rootpidns := allns.Processes[model.PIDType(os.Getpid())].Namespaces[model.PIDNS]
 	// If necessary, translate the PID from its own PID namespace into the
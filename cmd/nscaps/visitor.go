@@ -23,8 +23,9 @@ import (
 
 	"github.com/thediveo/go-asciitree/v2"
 
+	"github.com/thediveo/lxkns/cmd/cli/reflabel"
 	"github.com/thediveo/lxkns/cmd/cli/style"
-	incaps "github.com/thediveo/lxkns/cmd/internal/caps"
+	"github.com/thediveo/lxkns/internal/xstrings"
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/species"
 )
@@ -74,12 +75,14 @@ func (v *NodeVisitor) Label(n any) (label string) {
 	if nsn.isTarget {
 		prefix += "target "
 	}
-	// Finally return the rendered namespace label.
-	return fmt.Sprintf("%s%s%s %s",
+	// Finally return the rendered namespace label, including any
+	// type-specific details, such as the clock offsets of time namespaces.
+	return xstrings.Join(fmt.Sprintf("%s%s%s %s",
 		prefix,
 		v.NamespaceIcon(ns),
-		sty.V(ns.(model.NamespaceStringer).TypeIDString()),
-		v.NamespaceReferenceLabel(ns))
+		sty.V(reflabel.NamespaceTypeIDString(ns)),
+		v.NamespaceReferenceLabel(ns)),
+		reflabel.NamespaceDetailsLabel(ns))
 }
 
 var nscapstyles = map[targetCapsSummary]*style.Style{
@@ -112,7 +115,7 @@ func (v *NodeVisitor) Get(n any) (label string, properties []string, children []
 				if briefCaps {
 					properties = []string{"(process effective capabilities)"}
 				} else {
-					properties = propcaps(incaps.ProcessCapabilities(procPID))
+					properties = propcaps(processCapabilities(procPID))
 					if len(properties) == 0 {
 						properties = []string{"(no effective capabilities)"}
 					}
@@ -121,7 +124,7 @@ func (v *NodeVisitor) Get(n any) (label string, properties []string, children []
 				if briefCaps {
 					properties = []string{"(ALL capabilities)"}
 				} else {
-					properties = propcaps(incaps.ProcessCapabilities(1))
+					properties = propcaps(processCapabilities(1))
 				}
 			}
 		}
@@ -28,6 +28,7 @@ import (
 	"github.com/thediveo/lxkns"
 	"github.com/thediveo/lxkns/cmd/cli/cgrp"
 	"github.com/thediveo/lxkns/cmd/cli/icon"
+	"github.com/thediveo/lxkns/cmd/cli/procfilter"
 	"github.com/thediveo/lxkns/cmd/cli/silent"
 	"github.com/thediveo/lxkns/cmd/cli/style"
 	"github.com/thediveo/lxkns/cmd/cli/task"
@@ -53,7 +54,10 @@ func newRootCmd() (rootCmd *cobra.Command) {
 	leading to process PID 42.
   pidtree -n pid:[4026531836] -p 1
 	shows only the PID namespace hierarchy and processes on the branch
-	leading to process PID 1 in PID namespace 4026531836.`,
+	leading to process PID 1 in PID namespace 4026531836.
+  pidtree --euid 0 --no-new-privs unset
+	shows only processes with effective UID 0 and without no_new_privs,
+	together with their ancestor processes.`,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			return clippy.BeforeCommand(cmd)
 		},
@@ -194,6 +198,7 @@ func renderPIDTreeWithNamespaces(cmd *cobra.Command, out io.Writer, cizer contai
 		discover.WithContainerizer(cizer),
 		discover.WithPIDMapper(), // recommended when using WithContainerizer.
 		task.DiscoveryOption(cmd),
+		procfilter.DiscoveryOption(cmd),
 	)
 	pidmap := allns.PIDMap
 	// You may wonder why lxkns returns a slice of "root" PID and user
@@ -227,6 +232,7 @@ func renderPIDTreeWithNamespaces(cmd *cobra.Command, out io.Writer, cizer contai
 				RootPIDNS:         rootpidns,
 				NamespaceIcon:     icon.NamespaceIcon(cmd),
 				CgroupDisplayName: cgrp.CgroupDisplayName(cmd),
+				Filter:            procfilter.New(cmd),
 			},
 			style.NamespaceStyler))
 	return err
@@ -28,19 +28,28 @@ The following pidtree flags are available:
 	-c, --color color[=always]   colorize the output; can be 'always' (default if omitted), 'auto',
 	                             or 'never' (default auto)
 	    --dump                   dump colorization theme to stdout (for saving to ~/.lxknsrc.yaml)
+	    --euid uints             shows only processes with the specified effective UIDs (default [])
 	-h, --help                   help for pidtree
 	    --icon                   show/hide unicode icons next to namespaces
+	    --no-new-privs flag      shows only processes with the no_new_privs flag 'set' or 'unset'; can
+	                             also be 'any' (default any)
 	-n, --ns string              PID namespace of PID, if not the initial PID namespace;
 	                             either an unsigned int64 value, such as "4026531836", or a
 	                             PID namespace textual representation like "pid:[4026531836]"
 	-p, --pid uint32             PID of process to show PID namespace tree and parent PIDs for
 	    --proc proc[=name]       process name style; can be 'name' (default if omitted), 'basename',
 	                             or 'exe' (default name)
+	    --seccomp seccomp        shows only processes with the specified seccomp modes; can be
+	                             'disabled', 'strict', 'filter' (default [])
 	    --theme theme            colorization theme 'dark' or 'light' (default dark)
 	    --treestyle treestyle    select the tree render style; can be 'line' or 'ascii' (default line)
 	-v, --version                version for pidtree
 	    --wait duration          max duration to wait for container engine workload synchronization (default 3s)
 
+Processes can be filtered by their credentials using the "--euid", "--seccomp",
+and "--no-new-privs" flags. Processes not passing the filter are still shown
+when any of their descendants pass the filter, so the tree stays intact.
+
 # Display
 
 The process tree starts at the topmost PID namespace; when started in the
@@ -102,6 +102,33 @@ unshare -Upmfr /bin/bash -c "
 			pidnsid.Ino, os.Geteuid(), leafpid)))
 	})
 
+	It("CLI --euid filters PID tree", func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{
+			"--" + turtles.NoContainersFlagName,
+			fmt.Sprintf("--euid=%d", os.Geteuid()),
+		})
+		var out safe.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		debug.SetWriter(cmd, GinkgoWriter)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`"stage-2" \(\d+/1\)`))
+
+		cmd = newRootCmd()
+		cmd.SetArgs([]string{
+			"--" + turtles.NoContainersFlagName,
+			fmt.Sprintf("--euid=%d", os.Geteuid()+4242),
+		})
+		var filteredout safe.Buffer
+		cmd.SetOut(&filteredout)
+		cmd.SetErr(&filteredout)
+		debug.SetWriter(cmd, GinkgoWriter)
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(filteredout.String()).To(MatchRegexp(`^pid:\[`))
+		Expect(filteredout.String()).NotTo(ContainSubstring("stage-2"))
+	})
+
 	DescribeTable("rejecting to render invalid PID branches",
 		func(pid model.PIDType, pidnsid species.NamespaceID) {
 			cmd := newRootCmd()
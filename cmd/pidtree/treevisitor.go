@@ -40,6 +40,9 @@ type TreeVisitor struct {
 	NamespaceIcon func(model.Namespace) string
 	// render function for cgroup (path) names
 	CgroupDisplayName func(string) string
+	// optional process filter function; processes not passing the filter are
+	// still shown when any of their descendants pass the filter.
+	Filter func(*model.Process) bool
 }
 
 var _ asciitree.Visitor = (*TreeVisitor)(nil)
@@ -88,6 +91,9 @@ func (v *TreeVisitor) Get(node any) (label string, properties []string, children
 
 		childPIDNsSet := map[species.NamespaceID]struct{}{}
 		for _, childProc := range childProcs {
+			if !v.visible(childProc) {
+				continue
+			}
 			if childProc.Namespaces[model.PIDNS] == nodePIDNs {
 				children = append(children, childProc)
 				continue
@@ -138,8 +144,25 @@ func (v *TreeVisitor) Get(node any) (label string, properties []string, children
 		leaders := slices.Clone(node.(model.Namespace).Leaders())
 		slices.SortFunc(leaders, model.SortProcessByPID)
 		for _, proc := range leaders {
+			if !v.visible(proc) {
+				continue
+			}
 			children = append(children, proc)
 		}
 	}
 	return label, nil, children
 }
+
+// visible returns true if the specified process passes the process filter, or
+// if any of its descendants passes the filter.
+func (v *TreeVisitor) visible(proc *model.Process) bool {
+	if v.Filter == nil || v.Filter(proc) {
+		return true
+	}
+	for _, child := range proc.Children {
+		if v.visible(child) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,382 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/species"
+)
+
+// Changes lists the objects of a particular kind that have been added,
+// removed, or changed between two discovery results. The objects are sorted by
+// their identifying keys, so comparing the same two discovery results always
+// yields the same Changes.
+type Changes[T any] struct {
+	Added   []T         // objects only present in the newer discovery result.
+	Removed []T         // objects only present in the older discovery result.
+	Changed []Change[T] // objects present in both results, but with changed properties.
+}
+
+// Change describes an object present in two discovery results, but with some
+// of its properties having changed.
+type Change[T any] struct {
+	Old T // object from the older discovery result.
+	New T // object from the newer discovery result.
+}
+
+// Empty returns true if there are no changes at all.
+func (c Changes[T]) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// ResultDiff describes what has changed between two discovery results, as
+// returned by [Diff].
+//
+// Namespaces are identified by their type and ID. Processes are identified by
+// their PID together with their start time, so a PID that got reused in the
+// meantime shows up as a removed process and an added process. Containers are
+// identified by their container engine and container IDs, and container
+// engines by their engine IDs. Mount points are identified per mount namespace
+// by their unique 64 bit mount IDs where available, as these never get reused;
+// otherwise, by their (reusable) mount IDs.
+type ResultDiff struct {
+	Namespaces       Changes[model.Namespace]
+	Processes        Changes[*model.Process]
+	Containers       Changes[*model.Container]
+	ContainerEngines Changes[*model.ContainerEngine]
+	// Mount point changes per mount namespace; mount namespaces without any
+	// changes to their mount points are left out.
+	Mounts map[species.NamespaceID]Changes[*mounts.MountPoint]
+}
+
+// Empty returns true if both discovery results that were compared are
+// considered to be the same.
+func (d *ResultDiff) Empty() bool {
+	return d.Namespaces.Empty() && d.Processes.Empty() &&
+		d.Containers.Empty() && d.ContainerEngines.Empty() &&
+		len(d.Mounts) == 0
+}
+
+// Diff returns the differences between an older (“before”) and a newer
+// (“after”) discovery result. A nil discovery result is taken as an empty
+// discovery result; this way, diffing against a nil older result lists
+// everything from the newer discovery result as added.
+//
+// Please note that Diff only reports changes to those properties of
+// namespaces, processes, et cetera that have actually been discovered. For
+// instance, if mount points were discovered only in one of the two results,
+// then all the mount points will be reported as either added or removed.
+func Diff(before, after *Result) *ResultDiff {
+	if before == nil {
+		before = &Result{}
+	}
+	if after == nil {
+		after = &Result{}
+	}
+	d := &ResultDiff{
+		Mounts: map[species.NamespaceID]Changes[*mounts.MountPoint]{},
+	}
+
+	// Namespaces: diff each type of namespace separately and then join the
+	// per-type changes, so they are sorted by type first and ID second.
+	for nstypeidx := range model.NamespaceTypesCount {
+		changes := diffKeyed(before.Namespaces[nstypeidx], after.Namespaces[nstypeidx],
+			namespaceChanged, compareNamespaces)
+		d.Namespaces.Added = append(d.Namespaces.Added, changes.Added...)
+		d.Namespaces.Removed = append(d.Namespaces.Removed, changes.Removed...)
+		d.Namespaces.Changed = append(d.Namespaces.Changed, changes.Changed...)
+	}
+
+	d.Processes = diffKeyed(processesByKey(before.Processes), processesByKey(after.Processes),
+		processChanged, compareProcesses)
+	d.Containers = diffKeyed(containersByKey(before.Containers), containersByKey(after.Containers),
+		containerChanged, compareContainers)
+	d.ContainerEngines = diffKeyed(enginesByKey(before.ContainerEngines), enginesByKey(after.ContainerEngines),
+		engineChanged, compareEngines)
+
+	mntnsids := slices.Collect(maps.Keys(before.Mounts))
+	mntnsids = append(mntnsids, slices.Collect(maps.Keys(after.Mounts))...)
+	for _, mntnsid := range mntnsids {
+		if _, ok := d.Mounts[mntnsid]; ok {
+			continue
+		}
+		changes := diffKeyed(mountPointsByID(before.Mounts[mntnsid]), mountPointsByID(after.Mounts[mntnsid]),
+			mountPointChanged, compareMountPoints)
+		if changes.Empty() {
+			continue
+		}
+		d.Mounts[mntnsid] = changes
+	}
+	return d
+}
+
+// diffKeyed returns the changes between two maps of objects, with the objects
+// identified by their map keys. The changed function tells whether an object
+// present in both maps has changed, and the compare function defines the order
+// of the objects in the returned lists.
+func diffKeyed[K comparable, T any](
+	before, after map[K]T,
+	changed func(old, new T) bool,
+	compare func(a, b T) int,
+) (c Changes[T]) {
+	for key, oldobj := range before {
+		newobj, ok := after[key]
+		if !ok {
+			c.Removed = append(c.Removed, oldobj)
+			continue
+		}
+		if changed(oldobj, newobj) {
+			c.Changed = append(c.Changed, Change[T]{Old: oldobj, New: newobj})
+		}
+	}
+	for key, newobj := range after {
+		if _, ok := before[key]; !ok {
+			c.Added = append(c.Added, newobj)
+		}
+	}
+	slices.SortFunc(c.Added, compare)
+	slices.SortFunc(c.Removed, compare)
+	slices.SortFunc(c.Changed, func(a, b Change[T]) int { return compare(a.New, b.New) })
+	return
+}
+
+func compareNamespaces(a, b model.Namespace) int {
+	return cmp.Or(
+		cmp.Compare(model.TypeIndex(a.Type()), model.TypeIndex(b.Type())),
+		cmp.Compare(a.ID().Ino, b.ID().Ino),
+		cmp.Compare(a.ID().Dev, b.ID().Dev))
+}
+
+// namespaceChanged returns true if the reference, owner, parent, or leader
+// processes of a namespace have changed, or any of its type-specific details,
+// such as the host name of a UTS namespace.
+func namespaceChanged(old, new model.Namespace) bool {
+	if !slices.Equal(old.Ref(), new.Ref()) ||
+		ownerID(old) != ownerID(new) ||
+		parentID(old) != parentID(new) ||
+		namespaceDetailsChanged(old, new) {
+		return true
+	}
+	return !slices.Equal(sortedPIDs(old.LeaderPIDs()), sortedPIDs(new.LeaderPIDs()))
+}
+
+// namespaceDetailsChanged returns true if the host and domain names, ID
+// mappings, clock offsets, or cgroup root of a namespace have changed.
+func namespaceDetailsChanged(old, new model.Namespace) bool {
+	if oldnames, ok := old.(model.UTSNames); ok {
+		newnames := new.(model.UTSNames)
+		if oldnames.Nodename() != newnames.Nodename() ||
+			oldnames.Domainname() != newnames.Domainname() {
+			return true
+		}
+	}
+	if oldmapping, ok := old.(model.IDMapping); ok {
+		newmapping := new.(model.IDMapping)
+		if !slices.Equal(oldmapping.UIDMap(), newmapping.UIDMap()) ||
+			!slices.Equal(oldmapping.GIDMap(), newmapping.GIDMap()) ||
+			oldmapping.Setgroups() != newmapping.Setgroups() {
+			return true
+		}
+	}
+	if oldoffsets, ok := old.(model.TimeOffsets); ok {
+		oldclocks := oldoffsets.ClockOffsets()
+		newclocks := new.(model.TimeOffsets).ClockOffsets()
+		if (oldclocks == nil) != (newclocks == nil) ||
+			(oldclocks != nil && *oldclocks != *newclocks) {
+			return true
+		}
+	}
+	if oldroot, ok := old.(model.CgroupNamespaceRoot); ok {
+		if oldroot.CgroupRoot() != new.(model.CgroupNamespaceRoot).CgroupRoot() {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerID returns the ID of the owning user namespace, or species.NoneID.
+func ownerID(ns model.Namespace) species.NamespaceID {
+	if owner, ok := ns.Owner().(model.Namespace); ok && owner != nil {
+		return owner.ID()
+	}
+	return species.NoneID
+}
+
+// parentID returns the ID of the parent PID or user namespace, or
+// species.NoneID.
+func parentID(ns model.Namespace) species.NamespaceID {
+	if hns, ok := ns.(model.Hierarchy); ok {
+		if parent, ok := hns.Parent().(model.Namespace); ok && parent != nil {
+			return parent.ID()
+		}
+	}
+	return species.NoneID
+}
+
+func sortedPIDs(pids []model.PIDType) []model.PIDType {
+	slices.Sort(pids)
+	return pids
+}
+
+// processKey identifies a process not only by its PID, but also its start
+// time, in order to correctly tell apart processes in case of PID reuse.
+type processKey struct {
+	pid       model.PIDType
+	starttime uint64
+}
+
+func processesByKey(pt model.ProcessTable) map[processKey]*model.Process {
+	procs := make(map[processKey]*model.Process, len(pt))
+	for _, proc := range pt {
+		procs[processKey{pid: proc.PID, starttime: proc.Starttime}] = proc
+	}
+	return procs
+}
+
+func compareProcesses(a, b *model.Process) int {
+	return cmp.Or(cmp.Compare(a.PID, b.PID), cmp.Compare(a.Starttime, b.Starttime))
+}
+
+// processChanged returns true if the name, command line, parent, control
+// groups, freezer state, or any of the joined namespaces of a process have
+// changed.
+func processChanged(old, new *model.Process) bool {
+	if old.Name != new.Name ||
+		old.PPID != new.PPID ||
+		!slices.Equal(old.Cmdline, new.Cmdline) ||
+		old.CpuCgroup != new.CpuCgroup ||
+		old.FridgeCgroup != new.FridgeCgroup ||
+		old.FridgeFrozen != new.FridgeFrozen {
+		return true
+	}
+	for nstypeidx := range old.Namespaces {
+		if namespaceID(old.Namespaces[nstypeidx]) != namespaceID(new.Namespaces[nstypeidx]) {
+			return true
+		}
+	}
+	return false
+}
+
+func namespaceID(ns model.Namespace) species.NamespaceID {
+	if ns == nil {
+		return species.NoneID
+	}
+	return ns.ID()
+}
+
+// containerKey identifies a container by its managing container engine and its
+// ID, as the same container ID might show up with different engines, such as
+// Docker and its underlying containerd.
+type containerKey struct {
+	engineID string
+	id       string
+}
+
+func containersByKey(containers model.Containers) map[containerKey]*model.Container {
+	cntrs := make(map[containerKey]*model.Container, len(containers))
+	for _, cntr := range containers {
+		cntrs[containerKey{engineID: engineID(cntr), id: cntr.ID}] = cntr
+	}
+	return cntrs
+}
+
+func engineID(c *model.Container) string {
+	if c.Engine == nil {
+		return ""
+	}
+	return c.Engine.ID
+}
+
+func compareContainers(a, b *model.Container) int {
+	return cmp.Or(cmp.Compare(engineID(a), engineID(b)), cmp.Compare(a.ID, b.ID))
+}
+
+// containerChanged returns true if the name, initial process, paused state, or
+// labels of a container have changed.
+func containerChanged(old, new *model.Container) bool {
+	return old.Name != new.Name ||
+		old.PID != new.PID ||
+		old.Paused != new.Paused ||
+		!maps.Equal(old.Labels, new.Labels)
+}
+
+func enginesByKey(engines []*model.ContainerEngine) map[string]*model.ContainerEngine {
+	engs := make(map[string]*model.ContainerEngine, len(engines))
+	for _, engine := range engines {
+		engs[engine.ID] = engine
+	}
+	return engs
+}
+
+func compareEngines(a, b *model.ContainerEngine) int {
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// engineChanged returns true if the version, API endpoint, PID, or labels of a
+// container engine have changed.
+func engineChanged(old, new *model.ContainerEngine) bool {
+	return old.Version != new.Version ||
+		old.API != new.API ||
+		old.PID != new.PID ||
+		!maps.Equal(old.Labels, new.Labels)
+}
+
+// mountPointKey identifies a mount point by its unique 64 bit mount ID, if
+// known, and otherwise by its reusable mount ID.
+type mountPointKey struct {
+	uniqueID uint64
+	mountID  int
+}
+
+func mountPointsByID(mountpaths mounts.MountPathMap) map[mountPointKey]*mounts.MountPoint {
+	mountpoints := map[mountPointKey]*mounts.MountPoint{}
+	for _, mountpath := range mountpaths {
+		for _, mountpoint := range mountpath.Mounts {
+			key := mountPointKey{uniqueID: mountpoint.UniqueID}
+			if key.uniqueID == 0 {
+				key.mountID = mountpoint.MountID
+			}
+			mountpoints[key] = mountpoint
+		}
+	}
+	return mountpoints
+}
+
+func compareMountPoints(a, b *mounts.MountPoint) int {
+	return cmp.Or(cmp.Compare(a.MountID, b.MountID), cmp.Compare(a.UniqueID, b.UniqueID))
+}
+
+// mountPointChanged returns true if any of the mount information or the
+// visibility of a mount point has changed.
+func mountPointChanged(old, new *mounts.MountPoint) bool {
+	return old.Hidden != new.Hidden ||
+		old.ParentID != new.ParentID ||
+		old.Major != new.Major ||
+		old.Minor != new.Minor ||
+		old.Root != new.Root ||
+		old.MountPoint != new.MountPoint ||
+		!slices.Equal(old.MountOptions, new.MountOptions) ||
+		!maps.Equal(old.Tags, new.Tags) ||
+		old.FsType != new.FsType ||
+		old.Source != new.Source ||
+		old.SuperOptions != new.SuperOptions
+}
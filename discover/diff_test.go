@@ -0,0 +1,263 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"github.com/thediveo/go-mntinfo"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func diffResult() *Result {
+	return &Result{
+		Namespaces: *model.NewAllNamespaces(),
+		Processes:  model.ProcessTable{},
+		Mounts:     NamespacedMountPathMap{},
+	}
+}
+
+func addNamespace(r *Result, nstype species.NamespaceType, ino uint64, ref string) model.Namespace {
+	nsid := species.NamespaceID{Dev: 1, Ino: ino}
+	ns := namespaces.NewWithSimpleRef(nstype, nsid, ref)
+	r.Namespaces[model.TypeIndex(nstype)][nsid] = ns
+	return ns
+}
+
+func addProcess(r *Result, pid model.PIDType, starttime uint64, name string) *model.Process {
+	proc := &model.Process{
+		PID: pid,
+		ProTaskCommon: model.ProTaskCommon{
+			Name:      name,
+			Starttime: starttime,
+		},
+	}
+	r.Processes[pid] = proc
+	return proc
+}
+
+var _ = Describe("diffing discovery results", func() {
+
+	It("finds no changes between the same results", func() {
+		r := diffResult()
+		addNamespace(r, species.CLONE_NEWNET, 1, "/proc/1/ns/net")
+		addProcess(r, 1, 42, "init")
+		Expect(Diff(r, r).Empty()).To(BeTrue())
+		Expect(Diff(nil, nil).Empty()).To(BeTrue())
+	})
+
+	It("treats a nil result as empty", func() {
+		r := diffResult()
+		addNamespace(r, species.CLONE_NEWNET, 1, "/proc/1/ns/net")
+		addProcess(r, 1, 42, "init")
+		d := Diff(nil, r)
+		Expect(d.Namespaces.Added).To(HaveLen(1))
+		Expect(d.Processes.Added).To(HaveLen(1))
+		d = Diff(r, nil)
+		Expect(d.Namespaces.Removed).To(HaveLen(1))
+		Expect(d.Processes.Removed).To(HaveLen(1))
+	})
+
+	It("diffs namespaces", func() {
+		before := diffResult()
+		addNamespace(before, species.CLONE_NEWNET, 1, "/proc/1/ns/net")
+		addNamespace(before, species.CLONE_NEWNET, 2, "/proc/2/ns/net")
+		addNamespace(before, species.CLONE_NEWUTS, 3, "/proc/1/ns/uts")
+
+		after := diffResult()
+		addNamespace(after, species.CLONE_NEWNET, 1, "/proc/1/ns/net")
+		addNamespace(after, species.CLONE_NEWIPC, 5, "/proc/1/ns/ipc")
+		addNamespace(after, species.CLONE_NEWNET, 4, "/proc/1/ns/net")
+		addNamespace(after, species.CLONE_NEWUTS, 3, "/proc/666/ns/uts")
+
+		d := Diff(before, after)
+		Expect(d.Namespaces.Added).To(HaveExactElements(
+			HaveField("ID().Ino", uint64(5)),
+			HaveField("ID().Ino", uint64(4)),
+		)) // ...sorted by type index first: IPC comes before net.
+		Expect(d.Namespaces.Removed).To(HaveExactElements(HaveField("ID().Ino", uint64(2))))
+		Expect(d.Namespaces.Changed).To(HaveExactElements(
+			And(
+				HaveField("Old.Ref()", model.NamespaceRef{"/proc/1/ns/uts"}),
+				HaveField("New.Ref()", model.NamespaceRef{"/proc/666/ns/uts"})),
+		))
+	})
+
+	It("diffs namespace details", func() {
+		before := diffResult()
+		addNamespace(before, species.CLONE_NEWUTS, 1, "/proc/1/ns/uts").(namespaces.UTSConfigurer).
+			SetUTSNames("foo", "(none)")
+		addNamespace(before, species.CLONE_NEWUSER, 2, "/proc/1/ns/user").(namespaces.UserConfigurer).
+			SetIDMaps(model.IDMap{{First: 0, LowerFirst: 0, Count: 42}}, nil, model.SetgroupsAllow)
+		addNamespace(before, species.CLONE_NEWTIME, 3, "/proc/1/ns/time").(namespaces.TimeConfigurer).
+			SetClockOffsets(&model.ClockOffsets{})
+		addNamespace(before, species.CLONE_NEWCGROUP, 4, "/proc/1/ns/cgroup").(namespaces.CgroupConfigurer).
+			SetCgroupRoot("/")
+
+		after := diffResult()
+		addNamespace(after, species.CLONE_NEWUTS, 1, "/proc/1/ns/uts").(namespaces.UTSConfigurer).
+			SetUTSNames("bar", "(none)")
+		addNamespace(after, species.CLONE_NEWUSER, 2, "/proc/1/ns/user").(namespaces.UserConfigurer).
+			SetIDMaps(model.IDMap{{First: 0, LowerFirst: 1000, Count: 42}}, nil, model.SetgroupsAllow)
+		addNamespace(after, species.CLONE_NEWTIME, 3, "/proc/1/ns/time").(namespaces.TimeConfigurer).
+			SetClockOffsets(&model.ClockOffsets{Boottime: 42})
+		addNamespace(after, species.CLONE_NEWCGROUP, 4, "/proc/1/ns/cgroup").(namespaces.CgroupConfigurer).
+			SetCgroupRoot("/foo.slice")
+
+		d := Diff(before, after)
+		Expect(d.Namespaces.Added).To(BeEmpty())
+		Expect(d.Namespaces.Removed).To(BeEmpty())
+		Expect(d.Namespaces.Changed).To(ConsistOf(
+			HaveField("New.Nodename()", "bar"),
+			HaveField("New.UIDMap()", model.IDMap{{First: 0, LowerFirst: 1000, Count: 42}}),
+			HaveField("New.ClockOffsets().Boottime", BeEquivalentTo(42)),
+			HaveField("New.CgroupRoot()", "/foo.slice"),
+		))
+		Expect(Diff(before, before).Empty()).To(BeTrue())
+	})
+
+	It("diffs processes, handling PID reuse", func() {
+		before := diffResult()
+		addProcess(before, 1, 1, "init")
+		addProcess(before, 42, 100, "foo")
+		addProcess(before, 666, 200, "bar")
+
+		after := diffResult()
+		addProcess(after, 1, 1, "systemd")
+		addProcess(after, 42, 300, "baz")
+		addProcess(after, 666, 200, "bar")
+
+		d := Diff(before, after)
+		Expect(d.Processes.Added).To(HaveExactElements(HaveField("Name", "baz")))
+		Expect(d.Processes.Removed).To(HaveExactElements(HaveField("Name", "foo")))
+		Expect(d.Processes.Changed).To(HaveExactElements(
+			And(
+				HaveField("Old.Name", "init"),
+				HaveField("New.Name", "systemd")),
+		))
+	})
+
+	It("detects processes that switched namespaces", func() {
+		before := diffResult()
+		netns1 := addNamespace(before, species.CLONE_NEWNET, 1, "/proc/1/ns/net")
+		addProcess(before, 1, 1, "init").Namespaces[model.NetNS] = netns1
+
+		after := diffResult()
+		netns2 := addNamespace(after, species.CLONE_NEWNET, 2, "/proc/1/ns/net")
+		addProcess(after, 1, 1, "init").Namespaces[model.NetNS] = netns2
+
+		d := Diff(before, after)
+		Expect(d.Processes.Changed).To(HaveLen(1))
+	})
+
+	It("diffs containers and engines", func() {
+		before := diffResult()
+		engine := &model.ContainerEngine{ID: "engine-1", Version: "1.0"}
+		before.ContainerEngines = []*model.ContainerEngine{engine}
+		for _, id := range []string{"cntr-1", "cntr-2"} {
+			c := &model.Container{ID: id, Name: id, PID: 42}
+			engine.AddContainer(c)
+			before.Containers = append(before.Containers, c)
+		}
+
+		after := diffResult()
+		engine = &model.ContainerEngine{ID: "engine-1", Version: "1.1"}
+		after.ContainerEngines = []*model.ContainerEngine{engine}
+		for _, id := range []string{"cntr-1", "cntr-3"} {
+			c := &model.Container{ID: id, Name: id, PID: 42, Paused: id == "cntr-1"}
+			engine.AddContainer(c)
+			after.Containers = append(after.Containers, c)
+		}
+
+		d := Diff(before, after)
+		Expect(d.ContainerEngines.Added).To(BeEmpty())
+		Expect(d.ContainerEngines.Changed).To(HaveExactElements(HaveField("New.Version", "1.1")))
+		Expect(d.Containers.Added).To(HaveExactElements(HaveField("ID", "cntr-3")))
+		Expect(d.Containers.Removed).To(HaveExactElements(HaveField("ID", "cntr-2")))
+		Expect(d.Containers.Changed).To(HaveExactElements(
+			And(
+				HaveField("Old.Paused", false),
+				HaveField("New.Paused", true)),
+		))
+	})
+
+	It("diffs mount points per mount namespace", func() {
+		mntnsid := species.NamespaceID{Dev: 1, Ino: 42}
+		before := diffResult()
+		before.Mounts[mntnsid] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", FsType: "ext4"},
+			{MountID: 2, ParentID: 1, MountPoint: "/tmp", FsType: "tmpfs"},
+		})
+		before.Mounts[species.NamespaceID{Dev: 1, Ino: 666}] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", FsType: "ext4"},
+		})
+
+		after := diffResult()
+		after.Mounts[mntnsid] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", FsType: "ext4", MountOptions: []string{"ro"}},
+			{MountID: 3, ParentID: 1, MountPoint: "/run", FsType: "tmpfs"},
+		})
+		after.Mounts[species.NamespaceID{Dev: 1, Ino: 666}] = mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", FsType: "ext4"},
+		})
+
+		d := Diff(before, after)
+		Expect(d.Mounts).To(HaveLen(1))
+		Expect(d.Mounts).To(HaveKey(mntnsid))
+		changes := d.Mounts[mntnsid]
+		Expect(changes.Added).To(HaveExactElements(HaveField("MountPoint", "/run")))
+		Expect(changes.Removed).To(HaveExactElements(HaveField("MountPoint", "/tmp")))
+		Expect(changes.Changed).To(HaveExactElements(
+			HaveField("New.MountOptions", ConsistOf("ro"))))
+	})
+
+	It("identifies mount points by their unique IDs, if known", func() {
+		mntnsid := species.NamespaceID{Dev: 1, Ino: 42}
+		withUniqueIDs := func(mountpaths mounts.MountPathMap, uniqueids map[int]uint64) mounts.MountPathMap {
+			for _, mountpath := range mountpaths {
+				for _, mountpoint := range mountpath.Mounts {
+					mountpoint.UniqueID = uniqueids[mountpoint.MountID]
+				}
+			}
+			return mountpaths
+		}
+		before := diffResult()
+		before.Mounts[mntnsid] = withUniqueIDs(mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", FsType: "ext4"},
+			{MountID: 2, ParentID: 1, MountPoint: "/tmp", FsType: "tmpfs"},
+		}), map[int]uint64{1: 0x100000001, 2: 0x100000002})
+
+		// the tmpfs got unmounted and a new tmpfs mounted at the same place,
+		// reusing the mount ID, but not the unique mount ID.
+		after := diffResult()
+		after.Mounts[mntnsid] = withUniqueIDs(mounts.NewMountPathMap([]mntinfo.Mountinfo{
+			{MountID: 1, ParentID: 0, MountPoint: "/", FsType: "ext4"},
+			{MountID: 2, ParentID: 1, MountPoint: "/tmp", FsType: "tmpfs"},
+		}), map[int]uint64{1: 0x100000001, 2: 0x100000003})
+
+		d := Diff(before, after)
+		Expect(d.Mounts).To(HaveKey(mntnsid))
+		changes := d.Mounts[mntnsid]
+		Expect(changes.Added).To(HaveExactElements(HaveField("UniqueID", uint64(0x100000003))))
+		Expect(changes.Removed).To(HaveExactElements(HaveField("UniqueID", uint64(0x100000002))))
+		Expect(changes.Changed).To(BeEmpty())
+	})
+
+})
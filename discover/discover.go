@@ -27,6 +27,7 @@ import (
 	"slices"
 
 	"github.com/thediveo/cpus"
+	"github.com/thediveo/go-plugger/v3"
 
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/species"
@@ -37,16 +38,22 @@ import (
 type Result struct {
 	Options           DiscoverOpts             // options used during discovery.
 	Namespaces        model.AllNamespaces      // all discovered namespaces, subject to filtering according to Options.
-	InitialNamespaces model.NamespacesSet      // the 7 initial namespaces.
+	InitialNamespaces model.NamespacesSet      // the initial namespaces, that is, the namespaces of PID 1 as far as discovered.
 	UserNSRoots       []model.Namespace        // the topmost user namespace(s) in the hierarchy.
 	PIDNSRoots        []model.Namespace        // the topmost PID namespace(s) in the hierarchy.
 	Processes         model.ProcessTable       // processes checked for namespaces.
 	PIDMap            model.PIDMapper          `json:"-"` // optional PID translator.
+	IDMap             model.IDMapper           `json:"-"` // user and group ID translator.
 	Mounts            NamespacedMountPathMap   // per mount-namespace mount paths and mount points.
 	Containers        model.Containers         // all alive containers found.
 	ContainerEngines  []*model.ContainerEngine // all container engines found, including workload-less engines.
 	SocketProcessMap  SocketProcesses          // optional socket inode number to process(es) mapping.
+	TunProcessMap     TunProcesses             // optional TUN/TAP network namespace to process(es) mapping.
+	NetworkTopology   model.NetworkTopology    // optional topology of network interfaces across network namespaces.
+	UnixSocketGraph   model.UnixSocketGraph    // optional graph of connected UNIX domain sockets across network namespaces.
 	OnlineCPUs        cpus.List                // optional list of online CPUs when discovering process/task affinities.
+	Errors            []Issue                  // problems encountered during discovery, such as missing privileges.
+	Warnings          []Issue                  // things that went missing during discovery, such as vanished processes.
 }
 
 // SocketProcesses maps socket inode numbers to processes that have open file
@@ -59,6 +66,13 @@ type Result struct {
 // network namespaces from sockets.
 type SocketProcesses map[uint64][]model.PIDType
 
+// TunProcesses maps the network namespaces of TUN/TAP netdevs to the processes
+// that have open file descriptors for these netdevs. Such processes, for
+// instance VPN daemons and VM managers, keep network namespaces alive without
+// necessarily being attached to them. This mapping is only discovered when
+// scanning open file descriptors for namespaces.
+type TunProcesses map[species.NamespaceID][]model.PIDType
+
 // SortNamespaces returns a sorted copy of a list of namespaces. The
 // namespaces are sorted by their namespace ids in ascending order.
 func SortNamespaces(nslist []model.Namespace) []model.Namespace {
@@ -146,70 +160,81 @@ func rootNamespaces(nsmap model.NamespaceMap) []model.Namespace {
 // discoverySequence contains the namespace type indices in the order of
 // preferred discovery. While often the order of sequence doesn't matter,
 // there are few cases where it makes coding discovery functionality easier
-// when there is a guaranteed type order in place.
-var discoverySequence = []model.NamespaceTypeIndex{
-	model.UserNS,
-	model.PIDNS,
-}
-
-// Completes discoveryOrder to finally contain all namespace types indices;
-// the exact ordering of the remaining namespace types doesn't matter. Only
-// that user namespaces come first, then PID namespaces, then all other
-// namespace types.
-func init() {
+// when there is a guaranteed type order in place: user namespaces come first,
+// then PID namespaces, then all other namespace types; the exact ordering of
+// the remaining namespace types doesn't matter.
+var discoverySequence = func() []model.NamespaceTypeIndex {
+	sequence := []model.NamespaceTypeIndex{model.UserNS, model.PIDNS}
 	for typeidx := range model.NamespaceTypesCount {
 		if typeidx != model.UserNS && typeidx != model.PIDNS {
-			discoverySequence = append(discoverySequence, typeidx)
+			sequence = append(sequence, typeidx)
 		}
 	}
-}
+	return sequence
+}()
 
 // Namespaces returns the Linux kernel namespaces found, based on discovery
 // options specified in the call. It is allowed to pass nil discovery options to
 // allow more concise code without the need for lots of “if”s. The discovery
 // results also specify the initial namespaces, as well the process table/tree
 // on which the discovery bases at least in part.
+//
+// Namespaces is [NamespacesContext] with a background context that never gets
+// cancelled.
 func Namespaces(options ...DiscoveryOption) *Result {
-	opts := DiscoverOpts{
-		Labels: map[string]string{},
-	}
-	for _, opt := range options {
-		if opt != nil {
-			opt(&opts)
-		}
-	}
-	// If no namespace types are specified for discovery, we take this as
-	// discovering all types of namespaces.
-	if opts.NamespaceTypes == 0 {
-		opts.NamespaceTypes = species.AllNS
-	}
+	result, _ := NamespacesContext(context.Background(), options...)
+	return result
+}
+
+// NamespacesContext returns the Linux kernel namespaces found, based on
+// discovery options specified in the call, in the same way as [Namespaces].
+// Additionally, NamespacesContext aborts the discovery as soon as the specified
+// context gets cancelled or its deadline expires. In this case, it returns the
+// incomplete discovery result gathered so far, together with the context's
+// error.
+//
+// Problems encountered during discovery, such as processes vanishing or missing
+// privileges, are reported in the result's Errors and Warnings fields.
+func NamespacesContext(ctx context.Context, options ...DiscoveryOption) (*Result, error) {
+	opts := newDiscoverOpts(options...)
 	result := &Result{Options: opts}
-	if opts.ScanTasks {
-		result.Processes = model.NewProcessTableWithTasks(opts.DiscoverFreezerState)
-	} else {
-		result.Processes = model.NewProcessTable(opts.DiscoverFreezerState)
+	procfs := opts.procfsRoot()
+	result.Processes = model.NewProcessTableFromProcfsConcurrently(ctx,
+		opts.DiscoverFreezerState, opts.ScanTasks, procfs, opts.Concurrency)
+	if err := ctx.Err(); err != nil {
+		return result, err
 	}
+	slog.Info("discovered processes", slog.Int("count", len(result.Processes)))
 	// Finish initialization.
 	for idx := range result.Namespaces {
 		result.Namespaces[idx] = model.NamespaceMap{}
 	}
-	// Now go for discovery: we run the available discovery functions in
-	// sequence, subject to the following rules for the When field:
-	//   - []: call discovery function once; it'll know what to do.
-	//   - [...]: call discovery function multiple times, once for each
-	//     namespace type listed in the When field, and in the same order of
-	//     sequence.
-	for _, discoverer := range discoverers {
-		if len(*discoverer.When) == 0 {
-			discoverer.Discover(opts.NamespaceTypes, "/proc", result)
-		} else {
-			for _, nstypeidx := range *discoverer.When {
-				if nstype := model.TypesByIndex[nstypeidx]; opts.NamespaceTypes&nstype != 0 {
-					discoverer.Discover(nstype, "/proc", result)
-				}
+	// Now go for discovery: we run the registered discoverers in sequence,
+	// subject to the following rules for their sequences of namespace types:
+	//   - []: call discoverer once; it'll know what to do.
+	//   - [...]: call discoverer multiple times, once for each namespace type
+	//     listed in its sequence, and in the same order of sequence.
+	for _, discoverer := range plugger.Group[Discoverer]().Symbols() {
+		sequence := discoverer.Sequence()
+		if len(sequence) == 0 {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			discoverer.Discover(ctx, opts.NamespaceTypes, procfs, result)
+			continue
+		}
+		for _, nstypeidx := range sequence {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			if nstype := model.TypesByIndex[nstypeidx]; opts.NamespaceTypes&nstype != 0 {
+				discoverer.Discover(ctx, nstype, procfs, result)
 			}
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
 	// Fill in some additional convenience fields in the result.
 	if opts.NamespaceTypes&species.CLONE_NEWUSER != 0 {
 		result.UserNSRoots = rootNamespaces(result.Namespaces[model.UserNS])
@@ -217,60 +242,72 @@ func Namespaces(options ...DiscoveryOption) *Result {
 	if opts.NamespaceTypes&species.CLONE_NEWPID != 0 {
 		result.PIDNSRoots = rootNamespaces(result.Namespaces[model.PIDNS])
 	}
+	// The initial namespaces are the namespaces of the initial process, as
+	// seen in the proc filesystem in use.
+	if initproc := result.Processes[1]; initproc != nil {
+		result.InitialNamespaces = initproc.Namespaces
+	}
 
-	if slog.Default().Enabled(context.Background(), slog.LevelInfo) {
+	if slog.Default().Enabled(ctx, slog.LevelInfo) {
 		counts := map[string]int{}
 		for nstypeidx, nsmap := range result.Namespaces {
 			counts[model.TypesByIndex[nstypeidx].Name()] = len(nsmap)
 		}
-		slog.Info("discovered namespaces", slog.Any("counts", counts))
+		slog.Info("discovered namespaces", slog.Any("counts", counts),
+			slog.Int("errors", len(result.Errors)),
+			slog.Int("warnings", len(result.Warnings)))
 	}
 
 	// Do we need a PID mapping between PID namespaces?
 	if opts.withPIDmap {
 		result.PIDMap = NewPIDMap(result)
 	}
+	// The ID mapping between user namespaces comes cheap, as we already know
+	// the ID mappings of the individual user namespaces anyway.
+	if opts.NamespaceTypes&species.CLONE_NEWUSER != 0 {
+		result.IDMap = NewIDMap(result)
+	}
 
 	// Optionally discover alive containers (and engines) and relate the
 	// containers to processes and vice versa.
-	discoverContainers(result)
+	discoverContainers(ctx, result)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
 
 	// Pick up leader process CPU affinity and scheduling setup.
-	discoverAffinity(result)
+	discoverAffinity(ctx, result)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	// Pick up process and task capabilities and credentials, as well as
+	// process security contexts.
+	discoverProcessDetails(ctx, procfs, result)
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
 
 	// As a C oldie it gives me the shivers to return a pointer to what might
 	// look like an "auto" local struct ;)
-	return result
-}
-
-// discoveryFunc implements some Linux kernel namespace discovery
-// functionality.
-type discoveryFunc func(species.NamespaceType, string, *Result)
-
-// discoverer describes a single discoveryFunc and when to call it: once, per
-// each namespace type and for which namespace types in what sequence. Please
-// note that we use a reference to a slice here, as discoverySequence will
-// only be only completed during the init() phase, but after(!)
-// discoverySequence has been set to its initial value. Sigh.
-type discoverer struct {
-	When     *[]model.NamespaceTypeIndex // indices of namespace types this discovery function discovers.
-	Discover discoveryFunc               // the concrete namespace discovery functionality.
+	return result, nil
 }
 
-// Run a discoveryFunc only once per discovery, because it needs to work on
-// multiple namespace types in a single discovery call, and doesn't like
-// multiple per-type calls.
-var discoveronce = []model.NamespaceTypeIndex{}
-
-// The sequence of discovery functions implemented in lxkns, and how to call
-// them. Because discoverySequence will only be completed after this list has
-// been initialized, we need to "late bind" it by reference (pointers to
-// slices, where has the world come to ... mumble ... mumble...)
-var discoverers = []discoverer{
-	{&discoverySequence, discoverFromProc},
-	{&discoveronce, discoverFromFd},
-	{&discoveronce, discoverBindmounts},
-	{&[]model.NamespaceTypeIndex{model.UserNS, model.PIDNS}, discoverHierarchy},
-	{&discoverySequence, resolveOwnership},
-	{&discoveronce, discoverFromMountinfo},
+// newDiscoverOpts returns the discovery options resulting from applying the
+// specified options.
+func newDiscoverOpts(options ...DiscoveryOption) DiscoverOpts {
+	opts := DiscoverOpts{
+		Labels: map[string]string{},
+	}
+	for _, opt := range options {
+		if opt != nil {
+			opt(&opts)
+		}
+	}
+	// If no namespace types are specified for discovery, we take this as
+	// discovering all types of namespaces.
+	if opts.NamespaceTypes == 0 {
+		opts.NamespaceTypes = species.AllNS
+	}
+	return opts
 }
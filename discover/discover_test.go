@@ -15,11 +15,21 @@
 package discover
 
 import (
+	"fmt"
 	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"time"
 
+	"github.com/thediveo/testbasher"
+	"golang.org/x/sys/unix"
+
 	"github.com/thediveo/lxkns/internal/namespaces"
 	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nstest"
+	"github.com/thediveo/lxkns/ops"
 	"github.com/thediveo/lxkns/species"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -105,4 +115,112 @@ var _ = Describe("Discover", func() {
 		}
 	})
 
+	It("discovers the same namespaces concurrently", func() {
+		seqns := Namespaces(WithStandardDiscovery(), FromTasks())
+		concns := Namespaces(WithStandardDiscovery(), FromTasks(), WithConcurrency(4))
+		Expect(concns.Options.Concurrency).To(Equal(4))
+		for nstypeidx := range seqns.Namespaces {
+			Expect(slices.Collect(maps.Keys(concns.Namespaces[nstypeidx]))).To(
+				ConsistOf(slices.Collect(maps.Keys(seqns.Namespaces[nstypeidx]))))
+		}
+		myself := model.PIDType(os.Getpid())
+		Expect(concns.Processes).To(HaveKey(myself))
+		for nstypeidx, ns := range concns.Processes[myself].Namespaces {
+			if ns == nil {
+				continue
+			}
+			seqproc := seqns.Processes[myself]
+			Expect(seqproc.Namespaces[nstypeidx]).NotTo(BeNil())
+			Expect(ns.ID()).To(Equal(seqproc.Namespaces[nstypeidx].ID()))
+			Expect(ns.Ref()).To(Equal(seqproc.Namespaces[nstypeidx].Ref()))
+		}
+	})
+
+	It("discovers the same namespaces via pidfds", func() {
+		pathns := Namespaces(WithStandardDiscovery())
+		pidfdns := Namespaces(WithStandardDiscovery(), WithPidfds())
+		Expect(pidfdns.Options.ScanPidfds).To(BeTrue())
+		for nstypeidx := range pathns.Namespaces {
+			Expect(slices.Collect(maps.Keys(pidfdns.Namespaces[nstypeidx]))).To(
+				ContainElements(slices.Collect(maps.Keys(pathns.Namespaces[nstypeidx]))))
+		}
+		myself := model.PIDType(os.Getpid())
+		Expect(pidfdns.Processes).To(HaveKey(myself))
+		for nstypeidx, ns := range pidfdns.Processes[myself].Namespaces {
+			if ns == nil {
+				continue
+			}
+			Expect(ns.ID()).To(Equal(pathns.Processes[myself].Namespaces[nstypeidx].ID()))
+		}
+	})
+
+	It("doesn't attribute namespaces to a different process via pidfds", func() {
+		myself := model.NewProcess(model.PIDType(os.Getpid()), false)
+		Expect(myself).NotTo(BeNil())
+		nsref := "/proc/self/ns/net"
+		lookup, ok := lookupPidfdNamespaces(0, myself, "/proc", nsref, species.CLONE_NEWNET, false)
+		if !ok {
+			Skip("kernel doesn't support getting namespaces from pidfds")
+		}
+		Expect(lookup.ns.err).NotTo(HaveOccurred())
+		Expect(ops.NamespacePath(nsref).ID()).To(Equal(lookup.ns.nsid))
+
+		myself.Starttime++
+		lookup, ok = lookupPidfdNamespaces(0, myself, "/proc", nsref, species.CLONE_NEWNET, false)
+		Expect(ok).To(BeTrue())
+		Expect(lookup.ns.err).To(MatchError(unix.ESRCH))
+	})
+
+	It("discovers the initial namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		// The script's shell becomes the initial process of the proc
+		// filesystem mounted at procfs, which we reach through the shell's
+		// root in order to not depend on mount propagation.
+		procfs := GinkgoT().TempDir()
+		scripts.Script("main", fmt.Sprintf(`
+unshare -pf --mount-proc=%s $stage2
+`, procfs))
+		scripts.Script("stage2", fmt.Sprintf(`
+read -r pid _ </proc/self/stat
+echo $pid # prints the PID of this shell in our PID namespace.
+namespaceid %[1]s/1/ns/pid # prints the PID namespace ID of this shell.
+read # wait for test to proceed()
+`, procfs))
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		var pid model.PIDType
+		cmd.Decode(&pid)
+		pidnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(FromProcs(),
+			WithProcfsRoot(fmt.Sprintf("/proc/%d/root%s", pid, procfs)))
+		Expect(allns.InitialNamespaces[model.PIDNS]).NotTo(BeNil())
+		Expect(allns.InitialNamespaces[model.PIDNS].ID()).To(Equal(pidnsid))
+	})
+
+	It("discovers from a proc filesystem mounted elsewhere", func() {
+		procfs := filepath.Join(GinkgoT().TempDir(), "proc")
+		Expect(os.Symlink("/proc", procfs)).To(Succeed())
+
+		stdns := Namespaces(WithStandardDiscovery(), WithPIDMapper())
+		elsens := Namespaces(WithStandardDiscovery(), WithPIDMapper(), WithProcfsRoot(procfs+"/"))
+		Expect(elsens.Options.ProcfsRoot).To(Equal(procfs))
+		for nstypeidx := range stdns.Namespaces {
+			Expect(slices.Collect(maps.Keys(elsens.Namespaces[nstypeidx]))).To(
+				ConsistOf(slices.Collect(maps.Keys(stdns.Namespaces[nstypeidx]))))
+		}
+		myself := model.PIDType(os.Getpid())
+		Expect(elsens.Processes).To(HaveKey(myself))
+		Expect(elsens.Processes[myself].CpuCgroup).To(Equal(stdns.Processes[myself].CpuCgroup))
+		netns := elsens.Processes[myself].Namespaces[model.NetNS]
+		Expect(netns.Ref()).To(HaveExactElements(HavePrefix(procfs + "/")))
+		Expect(elsens.PIDMap.Translate(myself, elsens.Processes[myself].Namespaces[model.PIDNS],
+			elsens.Processes[myself].Namespaces[model.PIDNS])).To(Equal(myself))
+	})
+
 })
@@ -0,0 +1,142 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"context"
+	"slices"
+
+	"github.com/thediveo/go-plugger/v3"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+)
+
+// Discoverer discovers namespaces from a particular source, such as the
+// processes, their open file descriptors, or bind-mounts, adding them to a
+// discovery [Result]. Discoverers need to check the passed context at
+// reasonable intervals and bail out early when the context has been
+// cancelled.
+//
+// This type doubles as an exposed plugin symbol type for use with
+// [plugger/v3]. The built-in discoverers are registered as the plugins named
+// "proc", "fd", "bindmounts", "nsfs", "hierarchy", "ownership", "mountinfo",
+// "ipc", "netif", and "sockets", and run in this order. Discoverers of
+// additional namespace sources should be placed before the "hierarchy"
+// plugin, so that the hierarchy and ownership of the namespaces they add will
+// be discovered too:
+//
+//	func init() {
+//	    plugger.Group[discover.Discoverer]().Register(
+//	        discover.NewDiscoverer(discoverPinnedNetns),
+//	        plugger.WithPlugin("pinned-netns"),
+//	        plugger.WithPlacement("<hierarchy"))
+//	}
+//
+// [plugger/v3]: https://github.com/thediveo/go-plugger
+type Discoverer interface {
+	// Sequence returns the indices of the namespace types, in order of
+	// sequence, for which Discover is to be called individually. If empty,
+	// Discover is called only once per discovery, for all namespace types to
+	// be discovered.
+	Sequence() []model.NamespaceTypeIndex
+	// Discover namespaces of the specified type(s) from the proc filesystem
+	// mounted at procfs (or elsewhere), adding them to the discovery result.
+	Discover(ctx context.Context, nstype species.NamespaceType, procfs string, result *Result)
+}
+
+// DiscoverFunc implements discovering namespaces for a [Discoverer] created
+// using [NewDiscoverer].
+type DiscoverFunc func(ctx context.Context, nstype species.NamespaceType, procfs string, result *Result)
+
+// NewDiscoverer returns a [Discoverer] calling the specified discovery
+// function either once per discovery, or for each of the namespace types in
+// the specified sequence. Use [DiscoverySequence] in order to call the
+// discovery function for all namespace types in the same order as the
+// built-in discoverers do.
+func NewDiscoverer(fn DiscoverFunc, sequence ...model.NamespaceTypeIndex) Discoverer {
+	return &discoverer{sequence: sequence, discover: fn}
+}
+
+// discoverer is a [DiscoverFunc] together with the sequence of namespace types
+// to call it for.
+type discoverer struct {
+	sequence []model.NamespaceTypeIndex
+	discover DiscoverFunc
+}
+
+func (d *discoverer) Sequence() []model.NamespaceTypeIndex { return d.sequence }
+
+func (d *discoverer) Discover(ctx context.Context, nstype species.NamespaceType, procfs string, result *Result) {
+	d.discover(ctx, nstype, procfs, result)
+}
+
+// DiscoverySequence returns the indices of all namespace types in the order of
+// preferred discovery: user namespaces come first, then PID namespaces, then
+// all other namespace types.
+func DiscoverySequence() []model.NamespaceTypeIndex {
+	return slices.Clone(discoverySequence)
+}
+
+// NamespaceConfigurer allows discoverers to configure the namespaces they
+// discover, such as setting their references and owners. All namespaces
+// created by [NewNamespace] implement this interface.
+type NamespaceConfigurer = namespaces.NamespaceConfigurer
+
+// NewNamespace returns a new namespace object of the specified type and with
+// the specified ID and reference, for use by discoverers adding namespaces to
+// a discovery [Result].
+func NewNamespace(nstype species.NamespaceType, nsid species.NamespaceID, ref model.NamespaceRef) model.Namespace {
+	return namespaces.New(nstype, nsid, ref)
+}
+
+// Report records an issue with the discovery result, sorting it either into
+// the warnings in case of something that isn't there (anymore), or into the
+// errors otherwise. Report allows discoverers to report problems encountered
+// during their discovery.
+func (r *Result) Report(src IssueSource, pid model.PIDType, nsid species.NamespaceID, path string, err error) {
+	r.report(src, pid, nsid, path, err)
+}
+
+// Registers the built-in discoverers. Please note that plugger applies the
+// placement hints in the lexicographical order of plugin names, so we need to
+// choose them carefully in order to get our built-in discoverers into the
+// correct order.
+func init() {
+	group := plugger.Group[Discoverer]()
+	group.Register(NewDiscoverer(discoverFromProc, discoverySequence...),
+		plugger.WithPlugin("proc"), plugger.WithPlacement("<"))
+	group.Register(NewDiscoverer(discoverFromFd),
+		plugger.WithPlugin("fd"), plugger.WithPlacement("<bindmounts"))
+	group.Register(NewDiscoverer(discoverBindmounts),
+		plugger.WithPlugin("bindmounts"), plugger.WithPlacement(">fd"))
+	group.Register(NewDiscoverer(discoverFromNsfs, discoverySequence...),
+		plugger.WithPlugin("nsfs"), plugger.WithPlacement("<hierarchy"))
+	group.Register(NewDiscoverer(discoverHierarchy, model.UserNS, model.PIDNS),
+		plugger.WithPlugin("hierarchy"), plugger.WithPlacement(">bindmounts"))
+	group.Register(NewDiscoverer(resolveOwnership, discoverySequence...),
+		plugger.WithPlugin("ownership"), plugger.WithPlacement(">hierarchy"))
+	group.Register(NewDiscoverer(discoverFromMountinfo),
+		plugger.WithPlugin("mountinfo"), plugger.WithPlacement(">ownership"))
+	group.Register(NewDiscoverer(discoverIPCObjects),
+		plugger.WithPlugin("ipc"), plugger.WithPlacement(">"))
+	group.Register(NewDiscoverer(discoverNetworkInterfaces),
+		plugger.WithPlugin("netif"), plugger.WithPlacement(">"))
+	group.Register(NewDiscoverer(discoverSockets),
+		plugger.WithPlugin("sockets"), plugger.WithPlacement(">"))
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thediveo/go-plugger/v3"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("discoverer plugins", func() {
+
+	It("registers the built-in discoverers in order", func() {
+		Expect(plugger.Group[Discoverer]().Plugins()).To(HaveExactElements(
+			"proc", "fd", "bindmounts", "nsfs", "hierarchy", "ownership", "mountinfo", "ipc", "netif", "sockets"))
+		Expect(DiscoverySequence()).To(HaveExactElements(discoverySequence))
+		Expect(DiscoverySequence()[:2]).To(HaveExactElements(model.UserNS, model.PIDNS))
+	})
+
+	It("runs additional discoverers", func() {
+		DeferCleanup(plugger.Group[Discoverer]().Restore, plugger.Group[Discoverer]().Backup())
+
+		pinnedid := species.NamespaceID{Dev: 1, Ino: 42}
+		var nstypes []species.NamespaceType
+		plugger.Group[Discoverer]().Register(
+			NewDiscoverer(func(_ context.Context, nstype species.NamespaceType, _ string, result *Result) {
+				nstypes = append(nstypes, nstype)
+				if nstype != species.CLONE_NEWNET {
+					return
+				}
+				Expect(result.Namespaces[model.NetNS]).NotTo(BeEmpty())
+				ns := NewNamespace(nstype, pinnedid, nil)
+				ns.(NamespaceConfigurer).SetRef(model.NamespaceRef{"/run/netns/pinned"})
+				result.Namespaces[model.NetNS][pinnedid] = ns
+				result.Report(SourceBindmounts, 0, pinnedid, "/run/netns/pinned", errors.New("D'OH!"))
+			}, model.UserNS, model.NetNS),
+			plugger.WithPlugin("pinned"), plugger.WithPlacement("<hierarchy"))
+		Expect(plugger.Group[Discoverer]().Plugins()).To(HaveExactElements(
+			"proc", "fd", "bindmounts", "nsfs", "pinned", "hierarchy", "ownership", "mountinfo", "ipc", "netif", "sockets"))
+
+		allns := Namespaces(FromProcs(), WithNamespaceTypes(species.CLONE_NEWNET))
+		Expect(nstypes).To(HaveExactElements(species.CLONE_NEWNET))
+		Expect(allns.Namespaces[model.NetNS]).To(HaveKeyWithValue(pinnedid,
+			HaveField("Ref()", model.NamespaceRef{"/run/netns/pinned"})))
+		Expect(allns.Errors).To(ContainElement(And(
+			HaveField("Namespace", pinnedid),
+			MatchError(ContainSubstring("D'OH!")))))
+	})
+
+})
@@ -17,17 +17,23 @@
 package discover
 
 import (
+	"context"
+
 	"github.com/thediveo/cpus"
 
 	"github.com/thediveo/lxkns/model"
 )
 
 // discoverAffinity discovers the CPU affinity lists for either the leader
-// processes of all discovered namespaces, or for all tasks, if requested.
-func discoverAffinity(result *Result) {
+// processes of all discovered namespaces, or for all tasks, if requested. It
+// stops as soon as the specified context gets cancelled.
+func discoverAffinity(ctx context.Context, result *Result) {
 	switch {
 	case result.Options.DiscoverTaskAffinityScheduling:
 		for _, proc := range result.Processes {
+			if ctx.Err() != nil {
+				return
+			}
 			for _, task := range proc.Tasks {
 				_ = task.RetrieveAffinity()
 				if task.TID == proc.PID {
@@ -43,6 +49,9 @@ func discoverAffinity(result *Result) {
 	case result.Options.DiscoverAffinityScheduling:
 		for nstype := range model.NamespaceTypesCount {
 			for _, ns := range result.Namespaces[nstype] {
+				if ctx.Err() != nil {
+					return
+				}
 				for _, leader := range ns.Leaders() {
 					if leader.Affinity != nil {
 						continue
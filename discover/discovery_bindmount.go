@@ -21,6 +21,7 @@ package discover
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -52,7 +53,7 @@ type BindmountedNamespaceInfo struct {
 // to be run only once per discovery: but it will search not only in the current
 // mount namespace, but also in other mount namespaces (subject to having
 // capabilities in them).
-func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
+func discoverBindmounts(ctx context.Context, _ species.NamespaceType, procfs string, result *Result) {
 	if !result.Options.ScanBindmounts {
 		slog.Info("skipping discovery of bind-mounted namespaces",
 			slog.String("src", "bind-mounts"))
@@ -61,7 +62,7 @@ func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
 	slog.Debug("discovery of bind-mounted namespaces",
 		slog.String("src", "bind-mounts"))
 
-	debugEnabled := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
 	total := 0
 	// In order to avoid multiple visits to the same namespace, keep track of
 	// which mount namespaces not to visit again.
@@ -104,7 +105,7 @@ func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
 			// a process older than the one we already know.
 			typeidx := model.TypeIndex(bmntns.Type)
 			ns, ok := result.Namespaces[typeidx][bmntns.ID]
-			if !ok || NewlyProcfsPathIsBetter(bmntns.Ref, ns.Ref(), result.Processes) {
+			if !ok || newlyProcfsPathIsBetter(procfs, bmntns.Ref, ns.Ref(), result.Processes) {
 				// As we haven't seen this namespace yet, record it with our
 				// results.
 				ns = namespaces.New(bmntns.Type, bmntns.ID, nil)
@@ -135,6 +136,9 @@ func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
 	// Now try to clear the back log of mount namespaces to visit and to
 	// search for further bind-mounted namespaces.
 	for len(mountnsBacklog) > 0 {
+		if ctx.Err() != nil {
+			return
+		}
 		var mntns model.Namespace // NEVER merge this into the following pop operation!
 		mntns, mountnsBacklog = mountnsBacklog[0], mountnsBacklog[1:]
 		if _, ok := visitedmntns[mntns.ID()]; ok {
@@ -143,7 +147,7 @@ func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
 		if debugEnabled {
 			slog.Debug("scanning mount namespace for further bind-mounted namespaces...",
 				slog.String("namespace", mntns.(model.NamespaceStringer).TypeIDString()),
-				slog.String("ref", refString(mntns, result)))
+				slog.String("ref", refString(mntns, procfs, result)))
 		}
 		visitedmntns[mntns.ID()] = struct{}{}
 
@@ -153,9 +157,10 @@ func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
 				slog.String("namespace", mntns.(model.NamespaceStringer).TypeIDString()),
 				slog.String("ref", mntns.Ref().String()),
 				slog.String("err", err.Error()))
+			result.report(SourceBindmounts, 0, mntns.ID(), mntns.Ref().String(), err)
 			continue
 		}
-		ownedbindmounts := ownedBindMounts(mnteer, debugEnabled)
+		ownedbindmounts := ownedBindMounts(mnteer, mntns.ID(), result, debugEnabled)
 		mnteer.Close()
 		updateNamespaces(ownedbindmounts)
 	}
@@ -164,20 +169,19 @@ func discoverBindmounts(_ species.NamespaceType, _ string, result *Result) {
 }
 
 // refString returns a printable namespace reference, additionally resolving
-// /proc-based reference elements to the names of their corresponding processes,
-// if found in the additionally specified process table (from the discovery
-// result).
-func refString(mntns model.Namespace, r *Result) string {
+// procfs-based reference elements to the names of their corresponding
+// processes, if found in the additionally specified process table (from the
+// discovery result). procfs is the path of the proc filesystem in use.
+func refString(mntns model.Namespace, procfs string, r *Result) string {
 	refs := mntns.Ref()
 	s := []string{}
 	for _, ref := range refs {
-		if strings.HasPrefix(ref, "/proc/") {
-			if f := strings.SplitN(ref, "/", 4); len(f) >= 3 {
-				// PIDs are unsigned, but passed as int32...
-				if pid, err := strconv.ParseUint(f[2], 10, 31); err == nil {
-					if proc := r.Processes[model.PIDType(pid)]; proc != nil {
-						s = append(s, fmt.Sprintf("%s[=%s]", ref, proc.Name))
-					}
+		if rel, ok := strings.CutPrefix(ref, procfs+"/"); ok {
+			pidstr, _, _ := strings.Cut(rel, "/")
+			// PIDs are unsigned, but passed as int32...
+			if pid, err := strconv.ParseUint(pidstr, 10, 31); err == nil {
+				if proc := r.Processes[model.PIDType(pid)]; proc != nil {
+					s = append(s, fmt.Sprintf("%s[=%s]", ref, proc.Name))
 				}
 			}
 		}
@@ -186,9 +190,35 @@ func refString(mntns model.Namespace, r *Result) string {
 	return strings.Join(s, "»")
 }
 
+// resolveNamespaceRef returns a path to the namespace with the specified
+// reference that can be opened from the current mount namespace. Namespaces
+// bind-mounted in other mount namespaces are resolved using a mountineer for
+// the mount namespace they're bind-mounted in; the returned closer then
+// releases this mountineer and must only be called when done with the path.
+func resolveNamespaceRef(ref model.NamespaceRef, result *Result) (string, func(), error) {
+	switch len(ref) {
+	case 0:
+		return "", nil, errors.New("cannot resolve zero namespace reference")
+	case 1:
+		return ref[0], func() {}, nil
+	}
+	mnteer, err := mountineer.New(ref[:len(ref)-1], result.Namespaces[model.UserNS])
+	if err != nil {
+		return "", nil, err
+	}
+	path, err := mnteer.Resolve(ref[len(ref)-1])
+	if err != nil {
+		mnteer.Close()
+		return "", nil, err
+	}
+	return path, mnteer.Close, nil
+}
+
 // Returns a list of bind-mounted namespaces for process with PID, including
-// owning user namespace ID information.
-func ownedBindMounts(mnteer *mountineer.Mountineer, debugEnabled bool) []BindmountedNamespaceInfo {
+// owning user namespace ID information. Problems with individual bind-mounts
+// are reported with the discovery result, quoting the ID of the mount
+// namespace mntnsid being scanned.
+func ownedBindMounts(mnteer *mountineer.Mountineer, mntnsid species.NamespaceID, result *Result, debugEnabled bool) []BindmountedNamespaceInfo {
 	// Please note that while the mount details of /proc/[PID]/mountinfo tell us
 	// about bind-mounted namespaces with their types and inodes, they don't
 	// tell us the device IDs of those namespaces. Argh, again we need to go
@@ -203,6 +233,7 @@ func ownedBindMounts(mnteer *mountineer.Mountineer, debugEnabled bool) []Bindmou
 		if err != nil {
 			slog.Error("cannot resolve reference",
 				slog.String("ref", bindmount.MountPoint), slog.String("err", err.Error()))
+			result.report(SourceBindmounts, mnteer.PID(), mntnsid, bindmount.MountPoint, err)
 			continue
 		}
 		if debugEnabled {
@@ -225,6 +256,7 @@ func ownedBindMounts(mnteer *mountineer.Mountineer, debugEnabled bool) []Bindmou
 			slog.Error("cannot determine owning user namespace",
 				slog.String("ref", string(ns)),
 				slog.String("err", err.Error()))
+			result.report(SourceBindmounts, mnteer.PID(), mntnsid, bindmount.MountPoint, err)
 		}
 		ownedbindmounts = append(ownedbindmounts, BindmountedNamespaceInfo{
 			Type:      nstype,
@@ -27,6 +27,7 @@ import (
 	"github.com/thediveo/lxkns/decorator"
 	_ "github.com/thediveo/lxkns/decorator/all" // register all decorator plugins
 	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
 )
 
 // discoverContainers discovers alive containers using the optionally specified
@@ -34,7 +35,10 @@ import (
 // between containers and processes (and thus also namespaces). Also translates
 // container PIDs for containers in containers when their container engine PIDs
 // are known so that PID translation is possible.
-func discoverContainers(result *Result) {
+//
+// In case the passed context gets cancelled while the containerizer is
+// working, the cancellation is reported as an issue with the discovery result.
+func discoverContainers(ctx context.Context, result *Result) {
 	if result.Options.Containerizer == nil {
 		return
 	}
@@ -52,7 +56,7 @@ func discoverContainers(result *Result) {
 	if overseer, ok := result.Options.Containerizer.(containerizer.Overseer); ok {
 		// We got all the engines and with them their individual workloads, so
 		// we now derive the total workload.
-		engines = overseer.EnginesInclContainers(context.Background(), result.Processes, result.PIDMap)
+		engines = overseer.EnginesInclContainers(ctx, result.Processes, result.PIDMap)
 		count := 0
 		for _, engine := range engines {
 			count += len(engine.Containers)
@@ -64,13 +68,16 @@ func discoverContainers(result *Result) {
 	} else {
 		// We only get the total workload, and now derive their engines; this
 		// will miss out engines without workloads.
-		containers = result.Options.Containerizer.Containers(context.Background(), result.Processes, result.PIDMap)
+		containers = result.Options.Containerizer.Containers(ctx, result.Processes, result.PIDMap)
 		engineSet := sets.New[*model.ContainerEngine]()
 		for _, container := range containers {
 			engineSet.Add(container.Engine)
 		}
 		engines = engineSet.Elements()
 	}
+	if err := ctx.Err(); err != nil {
+		result.report(SourceContainers, 0, species.NoneID, "", err)
+	}
 
 	// Update the discovery information with the containers found and establish
 	// the links between container and process information model objects. Also
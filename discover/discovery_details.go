@@ -0,0 +1,150 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+)
+
+// discoverNamespaceDetails discovers type-specific details of the specified
+// namespace from the proc filesystem entries of its most senior leader
+// process, such as the ID mappings of user namespaces and the clock offsets
+// of time namespaces. Where opted in, it additionally discovers the host names
+// of UTS namespaces and the roots of cgroup namespaces; as these require
+// switching into the namespaces, they are skipped otherwise. Namespaces
+// without leader processes are skipped.
+func discoverNamespaceDetails(ns model.Namespace, procfs string, opts *DiscoverOpts) {
+	ealdorman := ns.Ealdorman()
+	if ealdorman == nil {
+		return
+	}
+	if setDetails := readNamespaceDetails(ns.Type(), ealdorman.PID, procfs, opts); setDetails != nil {
+		setDetails(ns)
+	}
+}
+
+// readNamespaceDetails reads the type-specific details of the namespace of
+// the specified type the process with the specified PID is joined to, as
+// described for [discoverNamespaceDetails]. It returns a function for later
+// setting the details read on the namespace, or nil if there are no details.
+// This allows the [Watcher] to read details without locking its discovery
+// result.
+func readNamespaceDetails(nstype species.NamespaceType, pid model.PIDType, procfs string, opts *DiscoverOpts) func(model.Namespace) {
+	base := procfs + "/" + strconv.Itoa(int(pid)) + "/"
+	switch nstype {
+	case species.CLONE_NEWUSER:
+		return readIDMaps(base)
+	case species.CLONE_NEWTIME:
+		if offsets, err := model.ReadClockOffsets(base + "timens_offsets"); err == nil {
+			return func(ns model.Namespace) {
+				ns.(namespaces.TimeConfigurer).SetClockOffsets(offsets)
+			}
+		}
+	case species.CLONE_NEWUTS:
+		if opts.DiscoverUTSNames {
+			return readUTSNames(base + "ns/uts")
+		}
+	case species.CLONE_NEWCGROUP:
+		if opts.DiscoverCgroupRoots {
+			return readCgroupRoot(pid, procfs, base+"ns/cgroup")
+		}
+	}
+	return nil
+}
+
+// readUTSNames reads the host (node) and NIS domain names of a UTS namespace
+// by briefly switching into it using the specified namespace reference path.
+// As there is no proc filesystem entry giving us the names of other UTS
+// namespaces, we need to ask uname(2) instead from within the UTS namespace.
+// Switching requires the necessary privileges, otherwise the names simply
+// remain unknown.
+func readUTSNames(ref string) func(model.Namespace) {
+	utsname, err := ops.Execute(func() (utsname unix.Utsname) {
+		_ = unix.Uname(&utsname)
+		return
+	}, ops.NewTypedNamespacePath(ref, species.CLONE_NEWUTS))
+	if err != nil {
+		slog.Debug("cannot switch into UTS namespace",
+			slog.String("ref", ref),
+			slog.String("err", err.Error()))
+		return nil
+	}
+	return func(ns model.Namespace) {
+		ns.(namespaces.UTSConfigurer).SetUTSNames(
+			unix.ByteSliceToString(utsname.Nodename[:]),
+			unix.ByteSliceToString(utsname.Domainname[:]))
+	}
+}
+
+// readCgroupRoot reads the root of a cgroup namespace in the cgroup
+// hierarchy, based on the control group of the specified leader process. It
+// compares the leader's control group as seen from the current cgroup
+// namespace with how it looks from inside the cgroup namespace, by briefly
+// switching into the latter using the specified namespace reference path.
+// Switching requires the necessary privileges, otherwise the root simply
+// remains unknown.
+func readCgroupRoot(pid model.PIDType, procfs string, ref string) func(model.Namespace) {
+	outside := model.ProcessCpuCgroup(pid, procfs)
+	inside, err := ops.Execute(func() string {
+		return model.ProcessCpuCgroup(pid, procfs)
+	}, ops.NewTypedNamespacePath(ref, species.CLONE_NEWCGROUP))
+	if err != nil {
+		slog.Debug("cannot switch into cgroup namespace",
+			slog.String("ref", ref),
+			slog.String("err", err.Error()))
+		return nil
+	}
+	root, ok := cgroupRoot(outside, inside)
+	if !ok {
+		return nil
+	}
+	return func(ns model.Namespace) {
+		ns.(namespaces.CgroupConfigurer).SetCgroupRoot(root)
+	}
+}
+
+// cgroupRoot returns the root of a cgroup namespace, given the control group
+// path of a process as seen from outside and from inside the cgroup
+// namespace. As the inside path is relative to the cgroup namespace root, the
+// root is the outside path with the inside path stripped off. However, when
+// the process has been moved outside the cgroup namespace root, the inside
+// path starts with “/..” and the root cannot be determined.
+func cgroupRoot(outside, inside string) (string, bool) {
+	if outside == "" || !strings.HasPrefix(inside, "/") || strings.HasPrefix(inside, "/..") {
+		return "", false
+	}
+	if inside == "/" {
+		return outside, true
+	}
+	root, ok := strings.CutSuffix(outside, inside)
+	if !ok {
+		return "", false
+	}
+	if root == "" {
+		root = "/"
+	}
+	return root, true
+}
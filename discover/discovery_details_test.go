@@ -0,0 +1,170 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"os"
+	"time"
+
+	"github.com/thediveo/testbasher"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nstest"
+	"github.com/thediveo/lxkns/ops"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("Discover namespace details", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("discovers clock offsets of time namespaces", func() {
+		if _, err := os.Stat("/proc/self/timens_offsets"); err != nil {
+			Skip("kernel doesn't support time namespaces")
+		}
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -Tf --monotonic 3600 --boottime 7 $stage2
+`)
+		scripts.Script("stage2", `
+process_namespaceid time # prints the time namespace ID of "the" process.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		timensid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(WithStandardDiscovery())
+		timens := allns.Namespaces[model.TimeNS][timensid]
+		Expect(timens).NotTo(BeNil())
+		Expect(timens.(model.TimeOffsets).ClockOffsets()).To(Equal(&model.ClockOffsets{
+			Monotonic: time.Hour,
+			Boottime:  7 * time.Second,
+		}))
+
+		inittimensid, err := ops.NamespacePath("/proc/self/ns/time").ID()
+		Expect(err).NotTo(HaveOccurred())
+		inittimens := allns.Namespaces[model.TimeNS][inittimensid].(model.TimeOffsets)
+		Expect(inittimens.ClockOffsets()).NotTo(BeNil())
+		Expect(inittimens.ClockOffsets().IsZero()).To(BeTrue())
+	})
+
+	It("discovers host and domain names of UTS namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -u $stage2
+`)
+		scripts.Script("stage2", `
+hostname lxkns-uts-test
+domainname lxkns-domain
+process_namespaceid uts # prints the UTS namespace ID of "the" process.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		utsnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(WithStandardDiscovery(), WithUTSNames())
+		utsns := allns.Namespaces[model.UTSNS][utsnsid]
+		Expect(utsns).NotTo(BeNil())
+		Expect(utsns.(model.UTSNames).Nodename()).To(Equal("lxkns-uts-test"))
+		Expect(utsns.(model.UTSNames).Domainname()).To(Equal("lxkns-domain"))
+
+		myhostname, err := os.Hostname()
+		Expect(err).NotTo(HaveOccurred())
+		myutsnsid, err := ops.NamespacePath("/proc/self/ns/uts").ID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allns.Namespaces[model.UTSNS][myutsnsid].(model.UTSNames).Nodename()).
+			To(Equal(myhostname))
+	})
+
+	It("skips host names and cgroup roots unless opted in", func() {
+		allns := Namespaces(WithStandardDiscovery())
+		Expect(allns.Namespaces[model.UTSNS]).NotTo(BeEmpty())
+		for _, utsns := range allns.Namespaces[model.UTSNS] {
+			Expect(utsns.(model.UTSNames).Nodename()).To(BeEmpty())
+		}
+		Expect(allns.Namespaces[model.CgroupNS]).NotTo(BeEmpty())
+		for _, cgroupns := range allns.Namespaces[model.CgroupNS] {
+			Expect(cgroupns.(model.CgroupNamespaceRoot).CgroupRoot()).To(BeEmpty())
+		}
+	})
+
+	It("discovers roots of cgroup namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -C $stage2
+`)
+		scripts.Script("stage2", `
+process_namespaceid cgroup # prints the cgroup namespace ID of "the" process.
+echo "$$"
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		cgroupnsid := nstest.CmdDecodeNSId(cmd)
+		var pid model.PIDType
+		cmd.Decode(&pid)
+
+		allns := Namespaces(WithStandardDiscovery(), WithCgroupRoots())
+		cgroupns := allns.Namespaces[model.CgroupNS][cgroupnsid]
+		Expect(cgroupns).NotTo(BeNil())
+		// as the process didn't move after creating its cgroup namespace, the
+		// root of its cgroup namespace is its control group.
+		Expect(cgroupns.(model.CgroupNamespaceRoot).CgroupRoot()).To(
+			Equal(allns.Processes[pid].CpuCgroup))
+	})
+
+	DescribeTable("determining cgroup namespace roots",
+		func(outside, inside string, expectedroot string, expectedok bool) {
+			root, ok := cgroupRoot(outside, inside)
+			Expect(ok).To(Equal(expectedok))
+			Expect(root).To(Equal(expectedroot))
+		},
+		Entry("initial cgroup namespace", "/", "/", "/", true),
+		Entry("process at root", "/system.slice/foo.scope", "/", "/system.slice/foo.scope", true),
+		Entry("process below root", "/system.slice/foo.scope/bar", "/bar", "/system.slice/foo.scope", true),
+		Entry("process at root of initial namespace", "/bar", "/bar", "/", true),
+		Entry("process outside root", "/system.slice/bar", "/../bar", "", false),
+		Entry("mismatch", "/system.slice/foo", "/bar", "", false),
+		Entry("unknown", "", "/", "", false),
+	)
+
+})
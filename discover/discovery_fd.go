@@ -23,25 +23,30 @@ package discover
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/thediveo/ioctl"
 	"golang.org/x/sys/unix"
 
+	"github.com/thediveo/lxkns/internal/fanout"
 	"github.com/thediveo/lxkns/internal/namespaces"
 	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nsioctl"
 	"github.com/thediveo/lxkns/ops"
 	"github.com/thediveo/lxkns/ops/relations"
 	"github.com/thediveo/lxkns/species"
 )
 
 // discoverFromFd discovers (1) namespaces from open file descriptors
-// referencing namespaces either directly or instead sockets that are in turn
-// attached to a network namespace, as well as (2) the socket-to-processes
-// mapping in a single run. This way we avoid DRY of repeated open fd socket
+// referencing namespaces either directly or instead sockets and TUN/TAP netdevs
+// that are in turn attached to a network namespace, as well as (2) the
+// socket-to-processes mapping in a single run. This way we avoid DRY of repeated open fd socket
 // scanning.
 //
 // Please note that scanning file descriptors for namespaces and sockets
@@ -51,7 +56,7 @@ import (
 // Since file descriptors are per process only, but not per task/thread, it
 // sufficies to only iterate the process fd entries, leaving out the copies in
 // the task fd entries.
-func discoverFromFd(t species.NamespaceType, procfs string, result *Result) {
+func discoverFromFd(ctx context.Context, t species.NamespaceType, procfs string, result *Result) {
 	if !result.Options.ScanFds && !result.Options.DiscoverSocketProcesses {
 		slog.Info("skipping discovery of fd-referenced namespaces and socket processes")
 		return
@@ -62,23 +67,132 @@ func discoverFromFd(t species.NamespaceType, procfs string, result *Result) {
 	default:
 		slog.Debug("discovering socket processes")
 	}
-	scanFd(t, procfs, false, result)
+	scanFd(ctx, t, procfs, false, result)
 }
 
 const socketPrefix = "socket:["
 const socketPrefixLen = len(socketPrefix)
 
+// tunDevicePath is the link destination of open file descriptors for TUN/TAP
+// netdevs.
+const tunDevicePath = "/dev/net/tun"
+
 // scanFd is discoverFromFd with special test harness handling enabled or
 // disabled.
-func scanFd(_ species.NamespaceType, procfs string, fakeprocfs bool, result *Result) {
-	debugEnabled := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+//
+// The open file descriptors of the individual processes are scanned using up
+// to the configured number of workers in parallel; the results are then merged
+// in the order of PIDs, so that the namespaces found and their references
+// don't depend on the number of workers.
+func scanFd(ctx context.Context, _ species.NamespaceType, procfs string, fakeprocfs bool, result *Result) {
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
 
 	result.SocketProcessMap = SocketProcesses{}
+	result.TunProcessMap = TunProcesses{}
 	/* shorthand */ scanFds := result.Options.ScanFds
 	// Iterate over all known processes, and then over all of their open file
 	// descriptors. The /proc filesystem will give us the required
-	// information.
+	// information. While scanning, the discovery result is only read, but
+	// never updated, so the processes can be scanned in parallel.
+	pids := slices.Sorted(maps.Keys(result.Processes))
+	scans := fanout.Map(ctx, result.Options.Concurrency, pids, func(pid model.PIDType) fdScan {
+		return scanProcessFds(pid, procfs, scanFds, fakeprocfs, result)
+	})
+	if ctx.Err() != nil {
+		return
+	}
 	total := 0
+	for idx, pid := range pids {
+		scan := &scans[idx]
+		for _, issue := range scan.issues {
+			result.report(SourceFd, pid, species.NoneID, issue.path, issue.err)
+		}
+		for _, ino := range scan.sockets {
+			result.SocketProcessMap[ino] = append(result.SocketProcessMap[ino], pid)
+		}
+		for _, netnsid := range scan.tuns {
+			pids := result.TunProcessMap[netnsid]
+			if !slices.Contains(pids, pid) {
+				result.TunProcessMap[netnsid] = append(pids, pid)
+			}
+		}
+		// Check if we already know this namespace, otherwise it's a new
+		// discovery. Add such new discoveries and use the /proc fd path as
+		// a path reference in case we want later to make use of this
+		// namespace. Consumers of these /proc-based fd paths need to have a
+		// clue about how to correctly deal with them in order to reference
+		// the targeted namespace.
+		for _, fdns := range scan.namespaces {
+			nstypeidx := model.TypeIndex(fdns.nstype)
+			if _, ok := result.Namespaces[nstypeidx][fdns.nsid]; ok {
+				continue
+			}
+			foundns := namespaces.NewWithSimpleRef(fdns.nstype, fdns.nsid, fdns.ref)
+			if fdns.ownernsid != species.NoneID {
+				foundns.(namespaces.NamespaceConfigurer).SetOwner(fdns.ownernsid)
+			}
+			if debugEnabled {
+				slog.Debug("found namespace",
+					slog.String("namespace", foundns.(model.NamespaceStringer).TypeIDString()),
+					slog.String("ref", fdns.ref))
+			}
+			result.Namespaces[nstypeidx][fdns.nsid] = foundns
+			total++
+		}
+	}
+	if scanFds {
+		slog.Info("found namespaces",
+			slog.String("src", "fd"), slog.Int("count", total))
+	}
+	slog.Info("found sockets", slog.Int("count", len(result.SocketProcessMap)))
+}
+
+// fdScan is the outcome of scanning the open file descriptors of a single
+// process, to be merged later into the discovery result.
+type fdScan struct {
+	sockets    []uint64              // inode numbers of sockets.
+	tuns       []species.NamespaceID // network namespaces of TUN/TAP netdevs.
+	namespaces []fdNamespace         // namespaces referenced, but not yet known.
+	issues     []fdIssue             // problems encountered.
+}
+
+// fdNamespace is a namespace referenced by an open file descriptor.
+type fdNamespace struct {
+	nsid      species.NamespaceID
+	nstype    species.NamespaceType
+	ownernsid species.NamespaceID // owning user namespace, if detected.
+	ref       string              // /proc/[PID]/fd/[FD] path.
+}
+
+// fdIssue is a problem encountered while scanning the open file descriptors of
+// a process.
+type fdIssue struct {
+	path string
+	err  error
+}
+
+// scanProcessFds scans the open file descriptors of the process with the
+// specified PID for sockets, TUN/TAP netdevs, and namespaces. It only reads the discovery
+// result in order to skip namespaces already known, so it is safe to run
+// scanProcessFds concurrently for different processes.
+func scanProcessFds(pid model.PIDType, procfs string, scanFds bool, fakeprocfs bool, result *Result) (scan fdScan) {
+	// concatenating strings in combination with strconv.Itoa is roughly
+	// 2.3× faster than to fmt.Sprintf, so its worth any minor inconvenience
+	// anyway. (Intel Core i5 with amd64 architecture)
+	basepath := procfs + "/" + strconv.Itoa(int(pid)) + "/fd"
+	// avoid os.ReadDir as we don't want to waste CPU time on sorting the
+	// directory entries.
+	dirf, err := os.Open(basepath)
+	if err != nil {
+		scan.issues = append(scan.issues, fdIssue{path: basepath, err: err})
+		return
+	}
+	fdEntries, err := dirf.ReadDir(-1)
+	_ = dirf.Close()
+	if err != nil {
+		scan.issues = append(scan.issues, fdIssue{path: basepath, err: err})
+		return
+	}
 	pidfd := 0 // grudingly accepting zero albeit being a valid fd, sigh.
 	defer func() {
 		// ensure to not leak a process fd in any case.
@@ -86,125 +200,126 @@ func scanFd(_ species.NamespaceType, procfs string, fakeprocfs bool, result *Res
 			_ = unix.Close(pidfd)
 		}
 	}()
-	for pid := range result.Processes {
-		// concatenating strings in combination with strconv.Itoa is roughly
-		// 2.3× faster than to fmt.Sprintf, so its worth any minor inconvenience
-		// anyway. (Intel Core i5 with amd64 architecture)
-		basepath := procfs + "/" + strconv.Itoa(int(pid)) + "/fd"
-		// avoid os.ReadDir as we don't want to waste CPU time on sorting the
-		// directory entries.
-		dirf, err := os.Open(basepath)
-		if err != nil {
+	var pidfdErr error
+	// openPidfd gets a PID fd for the process if we haven't done so yet, so we
+	// can later duplicate the processes's fds into our process for further
+	// inspection. It returns false if there is no PID fd.
+	openPidfd := func(procFdPath string) bool {
+		if pidfd > 0 {
+			return true
+		}
+		if pidfdErr != nil {
+			return false
+		}
+		pidfd, pidfdErr = unix.PidfdOpen(int(pid), 0)
+		if pidfdErr != nil {
+			// Report only once per process, not for each and every fd of it.
+			scan.issues = append(scan.issues, fdIssue{path: procFdPath, err: pidfdErr})
+			return false
+		}
+		return true
+	}
+	for _, fdEntry := range fdEntries {
+		// Filter out all open file descriptors which are not symbolic
+		// links; please note that there should only be symbolic links,
+		// but better be careful here.
+		if fdEntry.Type()&os.ModeSymlink == 0 {
 			continue
 		}
-		fdEntries, err := dirf.ReadDir(-1)
-		_ = dirf.Close()
+		// Let's read the link destination ("target") in order to get an
+		// idea where it points to. We are interested in two variants out of
+		// many more: first, those targets that name a type of namespace,
+		// such as "net:[...]", and second, "socket:[...]" targets. The
+		// socket targets can be queried for the network namespace the
+		// socket is connected to.
+		//
+		// Please note that we don't report fds that got closed in the
+		// meantime, as this is perfectly normal process behavior.
+		procFdPath := basepath + "/" + fdEntry.Name()
+		fdDestination, err := os.Readlink(procFdPath)
 		if err != nil {
 			continue
 		}
-		for _, fdEntry := range fdEntries {
-			// Filter out all open file descriptors which are not symbolic
-			// links; please note that there should only be symbolic links,
-			// but better be careful here.
-			if fdEntry.Type()&os.ModeSymlink == 0 {
+		var nsid species.NamespaceID
+		var nstype species.NamespaceType
+		var nsr relations.Relation
+		if strings.HasPrefix(fdDestination, socketPrefix) {
+			// It's a socket so we note down the relationship between the
+			// socket's inode number and this process in any case, as this
+			// is a byproduct of trying to find the socket's network
+			// namespace.
+			l := len(fdDestination)
+			if l <= socketPrefixLen {
 				continue
 			}
-			// Let's read the link destination ("target") in order to get an
-			// idea where it points to. We are interested in two variants out of
-			// many more: first, those targets that name a type of namespace,
-			// such as "net:[...]", and second, "socket:[...]" targets. The
-			// socket targets can be queried for the network namespace the
-			// socket is connected to.
-			procFdPath := basepath + "/" + fdEntry.Name()
-			fdDestination, err := os.Readlink(procFdPath)
+			ino, err := strconv.ParseUint(fdDestination[8:l-1], 10, 64)
 			if err != nil {
 				continue
 			}
-			var nsid species.NamespaceID
-			var nstype species.NamespaceType
-			var nsr relations.Relation
-			if strings.HasPrefix(fdDestination, socketPrefix) {
-				// It's a socket so we note down the relationship between the
-				// socket's inode number and this process in any case, as this
-				// is a byproduct of trying to find the socket's network
-				// namespace.
-				l := len(fdDestination)
-				if l <= socketPrefixLen {
-					continue
-				}
-				ino, err := strconv.ParseUint(fdDestination[8:l-1], 10, 64)
-				if err != nil {
-					continue
-				}
-				result.SocketProcessMap[ino] = append(result.SocketProcessMap[ino], pid)
-				if !scanFds {
-					continue
-				}
-				// So the calling explorer really wants to discover network
-				// namespaces from sockets. If we haven't done yet for this
-				// process, get a PID fd so we can later duplicate the
-				// processes's fd into our process for further inspection.
-				if pidfd <= 0 {
-					pidfd, err = unix.PidfdOpen(int(pid), 0)
-					if err != nil {
-						continue
-					}
-				}
-				nsid, nstype = namespaceOfSocket(pidfd, fdEntry.Name())
-				if nstype == species.NaNS {
-					continue
-				}
-			} else if !scanFds {
-				// while scanning for sockets was requested, scanning fds
-				// wasn't, so we then don't dig deeper into fds that might
-				// reference namespaces directly.
+			scan.sockets = append(scan.sockets, ino)
+			if !scanFds {
 				continue
-			} else {
-				nsid, nstype = namespaceFromLink(procFdPath, fdDestination, fakeprocfs)
-				if nstype == species.NaNS {
-					continue
-				}
-				nsr = ops.NamespacePath(procFdPath)
 			}
-			// Check if we already know this namespace, otherwise it's a new
-			// discovery. Add such new discoveries and use the /proc fd path as
-			// a path reference in case we want later to make use of this
-			// namespace. Consumers of these /proc-based fd paths need to have a
-			// clue about how to correctly deal with them in order to reference
-			// the targeted namespace.
-			nstypeidx := model.TypeIndex(nstype)
-			if _, ok := result.Namespaces[nstypeidx][nsid]; ok {
+			// So the calling explorer really wants to discover network
+			// namespaces from sockets.
+			if !openPidfd(procFdPath) {
 				continue
 			}
-			foundns := namespaces.NewWithSimpleRef(nstype, nsid, procFdPath)
-			if nsr != nil {
-				foundns.(namespaces.NamespaceConfigurer).DetectOwner(nsr)
+			nsid, nstype = netnsOfFd(pidfd, fdEntry.Name(), unix.SIOCGSKNS)
+			if nstype == species.NaNS {
+				continue
 			}
-			if debugEnabled {
-				slog.Debug("found namespace",
-					slog.String("namespace", foundns.(model.NamespaceStringer).TypeIDString()),
-					slog.String("ref", procFdPath))
+		} else if !scanFds {
+			// while scanning for sockets was requested, scanning fds
+			// wasn't, so we then don't dig deeper into fds that might
+			// reference namespaces directly.
+			continue
+		} else if fdDestination == tunDevicePath {
+			// A TUN/TAP netdev keeps the network namespace it is attached to
+			// alive, such as in case of VPN daemons and VM managers. Please
+			// note that a TUN/TAP fd not (yet) attached to a netdev doesn't
+			// have a network namespace.
+			if !openPidfd(procFdPath) {
+				continue
 			}
-			result.Namespaces[nstypeidx][nsid] = foundns
-			total++
+			nsid, nstype = netnsOfFd(pidfd, fdEntry.Name(), nsioctl.TUNGETDEVNETNS)
+			if nstype == species.NaNS {
+				continue
+			}
+			scan.tuns = append(scan.tuns, nsid)
+		} else {
+			nsid, nstype = namespaceFromLink(procFdPath, fdDestination, fakeprocfs)
+			if nstype == species.NaNS {
+				continue
+			}
+			nsr = ops.NamespacePath(procFdPath)
 		}
-		// Release the process fd as we don't need it anymore because we're
-		// progressing to the next process in our list.
-		if pidfd > 0 {
-			_ = unix.Close(pidfd)
-			pidfd = 0
+		// Skip namespaces we already know, so we don't waste time on
+		// detecting their owners.
+		if _, ok := result.Namespaces[model.TypeIndex(nstype)][nsid]; ok {
+			continue
 		}
+		fdns := fdNamespace{
+			nsid:   nsid,
+			nstype: nstype,
+			ref:    procFdPath,
+		}
+		if nsr != nil {
+			if usernsf, err := nsr.User(); err == nil {
+				fdns.ownernsid, _ = usernsf.ID()
+				_ = usernsf.(io.Closer).Close()
+			}
+		}
+		scan.namespaces = append(scan.namespaces, fdns)
 	}
-	if scanFds {
-		slog.Info("found namespaces",
-			slog.String("src", "fd"), slog.Int("count", total))
-	}
-	slog.Info("found sockets", slog.Int("count", len(result.SocketProcessMap)))
+	return
 }
 
-// namespaceOfSocket returns the network namespace a particular socket fd (of
-// the specified process) is connected to.
-func namespaceOfSocket(pidfd int, fdname string) (species.NamespaceID, species.NamespaceType) {
+// netnsOfFd returns the network namespace a particular socket or TUN/TAP fd (of
+// the specified process) is connected to, using the specified ioctl request to
+// query the network namespace: either SIOCGSKNS for sockets or TUNGETDEVNETNS
+// for TUN/TAP netdevs.
+func netnsOfFd(pidfd int, fdname string, request uint) (species.NamespaceID, species.NamespaceType) {
 	// PIDs are unsigned, but passed as int32...
 	fdno, err := strconv.ParseUint(fdname, 10, 31)
 	if err != nil {
@@ -213,14 +328,14 @@ func namespaceOfSocket(pidfd int, fdname string) (species.NamespaceID, species.N
 
 	// Duplicate the process' fd into our own process, then issue a query ioctl
 	// on it to get the network namespace reference as another fd. This doesn't
-	// mess with the other process' socket otherwise so that is safe to do:
-	// look, but don't touch.
-	sockfd, err := unix.PidfdGetfd(pidfd, int(fdno), 0)
+	// mess with the other process' socket or netdev otherwise so that is safe
+	// to do: look, but don't touch.
+	fd, err := unix.PidfdGetfd(pidfd, int(fdno), 0)
 	if err != nil {
 		return species.NoneID, species.NaNS
 	}
-	defer func() { _ = unix.Close(sockfd) }()
-	netnsfd, err := ioctl.RetFd(sockfd, unix.SIOCGSKNS)
+	defer func() { _ = unix.Close(fd) }()
+	netnsfd, err := ioctl.RetFd(fd, request)
 	if err != nil {
 		return species.NoneID, species.NaNS
 	}
@@ -15,6 +15,7 @@
 package discover
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"sync"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/nstest"
+	"github.com/thediveo/lxkns/ops"
 	"github.com/thediveo/lxkns/species"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -99,12 +101,12 @@ read # wait for test to proceed()
 			},
 		}
 		r.Namespaces[model.NetNS] = model.NamespaceMap{}
-		scanFd(0, "./test/fdscan/proc", true, &r)
+		scanFd(context.Background(), 0, "./test/fdscan/proc", true, &r)
 		Expect(r.Namespaces[model.NetNS]).To(HaveLen(1))
 		Expect(r.Namespaces[model.NetNS]).To(HaveKey(species.NamespaceID{Dev: stat.Dev, Ino: 12345678}))
 
 		origns := r.Namespaces[model.NetNS][species.NamespaceID{Dev: stat.Dev, Ino: 12345678}]
-		scanFd(0, "./test/fdscan/proc", true, &r)
+		scanFd(context.Background(), 0, "./test/fdscan/proc", true, &r)
 		Expect(r.Namespaces[model.NetNS]).To(HaveLen(1))
 		Expect(r.Namespaces[model.NetNS][species.NamespaceID{Dev: stat.Dev, Ino: 12345678}]).To(BeIdenticalTo(origns))
 	})
@@ -133,6 +135,50 @@ read # wait for test to proceed()
 			HaveKey(species.NamespaceIDfromInode(netnsino)))
 	})
 
+	It("finds a network namespace a TUN/TAP netdev is attached to", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+
+		By("creating a transient new network namespace with a TUN netdev")
+		netnsFd := spacetest.NewUnmanagedTransient(unix.CLONE_NEWNET)
+		closeNetnsFd := sync.OnceFunc(func() { _ = unix.Close(netnsFd) })
+		defer closeNetnsFd()
+
+		netnsino := netns.Ino(netnsFd)
+
+		tunfd, err := ops.Execute(func() int {
+			fd, err := unix.Open("/dev/net/tun", unix.O_RDWR|unix.O_CLOEXEC, 0)
+			if err != nil {
+				return -1
+			}
+			ifr := Successful(unix.NewIfreq("lxknstun0"))
+			ifr.SetUint16(unix.IFF_TUN | unix.IFF_NO_PI)
+			if err := unix.IoctlIfreq(fd, unix.TUNSETIFF, ifr); err != nil {
+				_ = unix.Close(fd)
+				return -1
+			}
+			return fd
+		}, ops.NamespaceFd(netnsFd))
+		Expect(err).NotTo(HaveOccurred())
+		if tunfd < 0 {
+			Skip("TUN/TAP netdevs not available")
+		}
+		defer func() { _ = unix.Close(tunfd) }()
+
+		By("keeping only the TUN fd as the last reference to the transient network namespace")
+		closeNetnsFd()
+
+		By("discovering the transient network namespace from the TUN fd")
+		allns := Namespaces(FromFds())
+		netnsid := species.NamespaceIDfromInode(netnsino)
+		Expect(allns.Namespaces[model.NetNS]).To(HaveKey(netnsid))
+		Expect(allns.Namespaces[model.NetNS][netnsid].Ref()).To(ConsistOf(
+			MatchRegexp(`^/proc/%d/fd/\d+$`, os.Getpid())))
+		Expect(allns.TunProcessMap).To(HaveKeyWithValue(
+			netnsid, ConsistOf(model.PIDType(os.Getpid()))))
+	})
+
 	It("discovers the socket-to-process mapping", func() {
 		if os.Getuid() != 0 {
 			Skip("needs root")
@@ -34,7 +34,6 @@ import (
 	"context"
 	"io"
 	"log/slog"
-	"os"
 
 	"github.com/thediveo/lxkns/internal/namespaces"
 	"github.com/thediveo/lxkns/model"
@@ -55,7 +54,7 @@ import (
 // hidden namespaces don't have file paths as references but instead can only
 // be referenced by fd's returned by the kernel namespace ioctl()s. This would
 // then force us to keep potentially a larger number of fd's open.
-func discoverHierarchy(nstype species.NamespaceType, _ string, result *Result) {
+func discoverHierarchy(ctx context.Context, nstype species.NamespaceType, _ string, result *Result) {
 	if !result.Options.DiscoverHierarchy {
 		slog.Info("skipping discovery of namespace hierarchy", slog.String("type", nstype.Name()))
 		return
@@ -63,83 +62,148 @@ func discoverHierarchy(nstype species.NamespaceType, _ string, result *Result) {
 	slog.Debug("discovering namespace hierarchy", slog.String("type", nstype.Name()))
 	hidden := 0
 
-	debugEnabled := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
 	nstypeidx := model.TypeIndex(nstype)
 	nsmap := result.Namespaces[nstypeidx]
+	lookup := func(nsid species.NamespaceID) model.Namespace { return nsmap[nsid] }
 	for _, startns := range nsmap {
-		ns := startns // ...so we can later climb rung by rung.
-		if ns.(model.Hierarchy).Parent() != nil {
-			// Early exit: skip this user/PID namespace, if it has already
-			// been brought into the hierarchy as part of the
-			// line-of-hierarchy for another user/PID namespace.
+		if ctx.Err() != nil {
+			return
+		}
+		// Early exit: skip this user/PID namespace, if it has already been
+		// brought into the hierarchy as part of the line-of-hierarchy for
+		// another user/PID namespace.
+		if startns.(model.Hierarchy).Parent() != nil {
 			continue
 		}
 		// For climbing up the hierarchy, Linux wants us to give it file
 		// descriptors referencing the namespaces to be queried for their
 		// parents.
-		if len(ns.Ref()) != 1 {
+		if len(startns.Ref()) != 1 {
 			slog.Info("skipping bind-mounted namespace",
-				slog.String("namespace", ns.(model.NamespaceStringer).TypeIDString()))
+				slog.String("namespace", startns.(model.NamespaceStringer).TypeIDString()))
 			continue
 		}
-		f, err := os.Open(ns.Ref()[0])
+		rungs := climbHierarchy(nstype, startns.Ref()[0], lookup, result.Options.ScanNsfs)
+		hidden += joinHierarchy(nstype, startns, rungs, nsmap, debugEnabled)
+	}
+	slog.Info("found hidden namespaces in hierarchy", slog.Int("count", hidden))
+}
+
+// hierarchyRung is a user or PID namespace passed when climbing up the
+// hierarchy of user or PID namespaces.
+type hierarchyRung struct {
+	nsid     species.NamespaceID
+	ref      model.NamespaceRef // reference in case of a hidden namespace.
+	owneruid int                // owner's UID in case of a user namespace.
+}
+
+// climbHierarchy climbs up the hierarchy of user or PID namespaces, starting
+// from the namespace referenced by the specified path, until it reaches either
+// the top of the hierarchy or a namespace that has already been brought into
+// the hierarchy. The lookup function returns the already known namespace for
+// a namespace ID, or nil. climbHierarchy returns the rungs climbed, starting
+// with the namespace referenced by the specified path. If withHandles is true,
+// rungs of namespaces not known yet get nsfs file handle references, where
+// supported by the kernel.
+//
+// As climbHierarchy doesn't change any namespaces, it can be run without
+// locking a discovery result that is shared with readers. Use [joinHierarchy]
+// to then bring the namespace into its hierarchy.
+func climbHierarchy(
+	nstype species.NamespaceType,
+	ref string,
+	lookup func(species.NamespaceID) model.Namespace,
+	withHandles bool,
+) (rungs []hierarchyRung) {
+	nsf, _, err := ops.NewTypedNamespacePath(ref, nstype).OpenTypedReference()
+	if err != nil {
+		return nil
+	}
+	nsid, err := nsf.ID()
+	if err != nil {
+		_ = nsf.(io.Closer).Close()
+		return nil
+	}
+	rung := hierarchyRung{nsid: nsid}
+	// Now, go climbing up the hierarchy...
+	for {
+		// By the way ... if it's a user namespace, then get its owner's
+		// UID, as we just happen to have a useful fd referencing the
+		// namespace open anyway.
+		if nstype == species.CLONE_NEWUSER {
+			rung.owneruid, _ = nsf.OwnerUID()
+		}
+		rungs = append(rungs, rung)
+		// See if there is a parent of this namespace at all, or whether
+		// we've reached the end of the road. Normally, this should be the
+		// initial user or PID namespace. But if we have insufficient
+		// capabilities, then we'll hit a brickwall earlier.
+		parentnsf, err := nsf.Parent()
 		if err != nil {
+			// There is no parent user/PID namespace, so we're done in
+			// this line. The reasons for not having a parent are: (1)
+			// initial namespace, so no parent; (2) no capabilities in
+			// parent namespace, so no parent either.
+			break
+		}
+		_ = nsf.(io.Closer).Close()
+		nsf = parentnsf
+		parentnsid, err := nsf.ID()
+		if err != nil {
+			// There is something severely rotten here, because the kernel
+			// just gave us a parent namespace reference which we cannot
+			// stat. Either we get a parent namespace reference which then
+			// has to work, or we won't get a reference from the parent
+			// namespace ioctl() syscall.
+			panic("cannot stat parent namespace fd reference")
+		}
+		rung = hierarchyRung{nsid: parentnsid}
+		parentns := lookup(parentnsid)
+		if parentns == nil {
+			// So we've found a "hidden" namespace. For user namespaces
+			// this happens when there are no processes joined to a
+			// particular user namespace, but this user namespace has
+			// still child user namespaces. For PID namespaces this can
+			// only happen when bind-mounting a PID namespace or keeping
+			// it opened by an file descriptor ("fd-tied"), and there are
+			// no processes either in it or any of its child processes
+			// (which are also bind-mounted or fd-tied).
+			rung.ref = hiddenRef(nsf, withHandles)
 			continue
 		}
-		var nsf relations.Relation
-		nsf, _ = ops.NewTypedNamespaceFile(f, nstype)
-		// Now, go climbing up the hierarchy...
-		for {
-			// We already worked on this user/pid namespace, so we don't need
-			// to climb up further. This won't catch the initial user/pid
-			// namespaces, but then these will break out of the loop anyway,
-			// as they don't have any parents.
-			if ns.(model.Hierarchy).Parent() != nil { //nolint:staticcheck // one of many breaks
-				break
-			}
-			// By the way ... if it's a user namespace, then get its owner's
-			// UID, as we just happen to have a useful fd referencing the
-			// namespace open anyway.
-			if nstype == species.CLONE_NEWUSER {
-				ns.(*namespaces.UserNamespace).DetectUID(nsf)
-			}
-			// See if there is a parent of this namespace at all, or whether
-			// we've reached the end of the road. Normally, this should be the
-			// initial user or PID namespace. But if we have insufficient
-			// capabilities, then we'll hit a brickwall earlier.
-			parentnsf, err := nsf.Parent()
-			if err != nil {
-				// There is no parent user/PID namespace, so we're done in
-				// this line. Let's move on to the next namespace. The reasons
-				// for not having a parent are: (1) initial namespace, so no
-				// parent; (2) no capabilities in parent namespace, so no
-				// parent either.
-				break
-			}
-			parentnsid, err := parentnsf.ID()
-			if err != nil {
-				// There is something severely rotten here, because the kernel
-				// just gave us a parent namespace reference which we cannot
-				// stat. Either we get a parent namespace reference which then
-				// has to work, or we won't get a reference from the parent
-				// namespace ioctl() syscall.
-				panic("cannot stat parent namespace fd reference")
-			}
-			parentns, ok := nsmap[parentnsid]
+		if parentns.(model.Hierarchy).Parent() != nil {
+			// We already worked on this user/pid namespace, so we don't
+			// need to climb up further. This won't catch the initial
+			// user/pid namespaces, but then these will break out of the
+			// loop anyway, as they don't have any parents.
+			rungs = append(rungs, rung)
+			break
+		}
+	}
+	// Don't leak...
+	_ = nsf.(io.Closer).Close()
+	return
+}
+
+// joinHierarchy brings the specified user or PID namespace into its hierarchy,
+// using the rungs returned by [climbHierarchy] when climbing up from this
+// namespace. Namespaces not yet known are added to the specified namespace
+// map as “hidden” namespaces; joinHierarchy returns the number of such hidden
+// namespaces added.
+func joinHierarchy(
+	nstype species.NamespaceType,
+	ns model.Namespace,
+	rungs []hierarchyRung,
+	nsmap model.NamespaceMap,
+	debugEnabled bool,
+) (hidden int) {
+	for idx, rung := range rungs {
+		if idx > 0 {
+			parentns, ok := nsmap[rung.nsid]
 			if !ok {
-				// So we've found a "hidden" namespace. For user namespaces
-				// this happens when there are no processes joined to a
-				// particular user namespace, but this user namespace has
-				// still child user namespaces. For PID namespaces this can
-				// only happen when bind-mounting a PID namespace or keeping
-				// it opened by an file descriptor ("fd-tied"), and there are
-				// no processes either in it or any of its child processes
-				// (which are also bind-mounted or fd-tied).
-				//
-				// Anyway, we need to create a new namespace node for what we
-				// found.
-				parentns = namespaces.New(nstype, parentnsid, nil)
-				nsmap[parentnsid] = parentns
+				parentns = namespaces.New(nstype, rung.nsid, rung.ref)
+				nsmap[rung.nsid] = parentns
 				if debugEnabled {
 					slog.Debug("found hidden intermediate namespace",
 						slog.String("namespace", parentns.(model.NamespaceStringer).TypeIDString()))
@@ -150,11 +214,31 @@ func discoverHierarchy(nstype species.NamespaceType, _ string, result *Result) {
 			// the hierarchy, and then prepare for the next rung...
 			parentns.(namespaces.HierarchyConfigurer).AddChild(ns.(model.Hierarchy))
 			ns = parentns
-			_ = nsf.(io.Closer).Close()
-			nsf = parentnsf
 		}
-		// Don't leak...
-		_ = nsf.(io.Closer).Close()
+		if ns.(model.Hierarchy).Parent() != nil {
+			break
+		}
+		if nstype == species.CLONE_NEWUSER {
+			ns.(namespaces.UserConfigurer).SetOwnerUID(rung.owneruid)
+		}
 	}
-	slog.Info("found hidden namespaces in hierarchy", slog.Int("count", hidden))
+	return
+}
+
+// hiddenRef returns an nsfs file handle reference to the specified hidden
+// namespace, if asked for and supported by the kernel. Otherwise, it returns a
+// zero reference.
+func hiddenRef(nsf relations.Relation, withHandles bool) model.NamespaceRef {
+	if !withHandles {
+		return nil
+	}
+	f, ok := nsf.(interface{ Fd() uintptr })
+	if !ok {
+		return nil
+	}
+	nsh, err := ops.NewNamespaceHandle(int(f.Fd())) // #nosec G115
+	if err != nil {
+		return nil
+	}
+	return model.NamespaceRef{nsh.Path()}
 }
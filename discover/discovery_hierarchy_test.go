@@ -16,6 +16,7 @@ package discover
 
 import (
 	"log/slog"
+	"os"
 	"time"
 
 	"github.com/thediveo/testbasher"
@@ -64,6 +65,72 @@ read # wait for test to proceed()
 		Expect(userns.Parent().Parent().(model.Namespace).ID()).To(Equal(ppusernsid))
 	})
 
+	It("references hidden namespaces by nsfs file handles", func() {
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -Ur unshare -U $stage2 # create a user ns with another user ns inside.
+`)
+		scripts.Script("stage2", `
+process_namespaceid user # prints the user namespace ID of "the" process.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		usernsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(WithStandardDiscovery())
+		hiddenns := allns.Namespaces[model.UserNS][usernsid].(model.Hierarchy).Parent().(model.Namespace)
+		Expect(hiddenns.Ref()).To(BeEmpty())
+
+		allns = Namespaces(WithStandardDiscovery(), FromNsfs())
+		hiddenns = allns.Namespaces[model.UserNS][usernsid].(model.Hierarchy).Parent().(model.Namespace)
+		if len(hiddenns.Ref()) == 0 {
+			Skip("kernel doesn't support nsfs file handles")
+		}
+		Expect(hiddenns.Ref()).To(HaveExactElements(HavePrefix(ops.NamespaceHandlePrefix)))
+		Expect(ops.NamespacePath(hiddenns.Ref()[0]).ID()).To(Equal(hiddenns.ID()))
+	})
+
+	It("discovers user namespace ID mappings", func() {
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -Ur $stage2 # create a user ns mapping only root.
+`)
+		scripts.Script("stage2", `
+process_namespaceid user # prints the user namespace ID of "the" process.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		usernsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(WithStandardDiscovery())
+		userns := allns.Namespaces[model.UserNS][usernsid].(model.IDMapping)
+		Expect(userns.UIDMap()).To(Equal(model.IDMap{
+			{First: 0, LowerFirst: uint32(os.Geteuid()), Count: 1}})) // #nosec G115
+		Expect(userns.GIDMap()).To(Equal(model.IDMap{
+			{First: 0, LowerFirst: uint32(os.Getegid()), Count: 1}})) // #nosec G115
+		Expect(userns.Setgroups()).To(Equal(model.SetgroupsDeny))
+
+		initialuserns := userns.(model.Hierarchy).Parent().(model.IDMapping)
+		Expect(initialuserns.UIDMap().IsIdentity()).To(BeTrue())
+		Expect(initialuserns.Setgroups()).To(Equal(model.SetgroupsAllow))
+	})
+
+	It("discovers user namespace ID mappings without the hierarchy", func() {
+		allns := Namespaces(WithStandardDiscovery(), WithoutHierarchy())
+		myself := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(myself).NotTo(BeNil())
+		userns := myself.Namespaces[model.UserNS].(model.IDMapping)
+		Expect(userns.UIDMap()).NotTo(BeEmpty())
+		Expect(userns.GIDMap()).NotTo(BeEmpty())
+		Expect(userns.Setgroups()).NotTo(Equal(model.SetgroupsUnknown))
+	})
+
 	It("adds child namespaces only once", func() {
 		scripts := testbasher.Basher{}
 		defer scripts.Done()
@@ -0,0 +1,48 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+)
+
+// readIDMaps reads the user and group ID mappings, as well as the
+// setgroups(2) permission state of a user namespace from the proc filesystem
+// entries below the specified base path of a process joined to the user
+// namespace. It returns a function for later setting the ID mappings on the
+// user namespace, or nil if the ID mappings couldn't be read.
+func readIDMaps(base string) func(model.Namespace) {
+	uidmap, err := model.ReadIDMap(base + "uid_map")
+	if err != nil {
+		return nil
+	}
+	gidmap, err := model.ReadIDMap(base + "gid_map")
+	if err != nil {
+		return nil
+	}
+	setgroups := model.SetgroupsUnknown
+	if sg, err := os.ReadFile(base + "setgroups"); err == nil { // #nosec G304
+		setgroups = model.Setgroups(bytes.TrimSpace(sg))
+	}
+	return func(userns model.Namespace) {
+		userns.(namespaces.UserConfigurer).SetIDMaps(uidmap, gidmap, setgroups)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/thediveo/go-mntinfo"
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+)
+
+// discoverIPCObjects discovers the System V IPC objects as well as the POSIX
+// message queues of the discovered IPC namespaces. API users must have opted
+// in to this discovery step, as well as to the discovery of IPC namespaces.
+//
+// As the System V IPC objects listed in “/proc/sysvipc/” always are those of
+// the IPC namespace of the reading task, we need to briefly switch into each
+// IPC namespace. In contrast, POSIX message queues are listed from an
+// “mqueue” filesystem mounted in the mount namespace of the IPC namespace's
+// ealdorman, where present.
+func discoverIPCObjects(ctx context.Context, _ species.NamespaceType, procfs string, result *Result) {
+	if !result.Options.DiscoverIPCObjects {
+		slog.Info("skipping discovery of IPC objects", slog.String("src", "sysvipc,mqueue"))
+		return
+	}
+	if result.Options.NamespaceTypes&species.CLONE_NEWIPC == 0 {
+		slog.Warn("IPC namespace discovery skipped, so skipping IPC objects discovery")
+		return
+	}
+	slog.Debug("discovering IPC objects", slog.String("src", "sysvipc,mqueue"))
+	// An mqueue filesystem is bound to the IPC namespace it was mounted from.
+	// Unfortunately, there's no way to find out from which IPC namespace an
+	// mqueue filesystem was mounted, yet processes in child IPC namespaces
+	// often still see the mqueue filesystem of the initial IPC namespace. We
+	// thus attribute mqueue filesystems visible to the initial IPC namespace
+	// only to the initial IPC namespace.
+	initialipcnsid, _ := ops.NamespacePath(procfs + "/1/ns/ipc").ID()
+	initialmqueues := map[uint64]struct{}{}
+	if initialipcns, ok := result.Namespaces[model.IPCNS][initialipcnsid]; ok {
+		for _, mqueue := range mqueueMounts(initialipcns, procfs) {
+			initialmqueues[mqueueDev(mqueue)] = struct{}{}
+		}
+	}
+	objectstotal := 0
+	for ipcnsid, ipcns := range result.Namespaces[model.IPCNS] {
+		if ctx.Err() != nil {
+			return
+		}
+		// IPC namespaces bind-mounted into other mount namespaces need to be
+		// reached through these mount namespaces.
+		ref, closer, err := resolveNamespaceRef(ipcns.Ref(), result)
+		if err != nil {
+			slog.Warn("cannot open bind-mounted IPC namespace",
+				slog.String("namespace", ipcns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("ref", ipcns.Ref().String()),
+				slog.String("err", err.Error()))
+			result.report(SourceIPC, 0, ipcnsid, ipcns.Ref().String(), err)
+			continue
+		}
+		objects, err := sysvIPCObjects(ref, procfs)
+		closer()
+		if err != nil {
+			slog.Warn("cannot read IPC objects",
+				slog.String("namespace", ipcns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("err", err.Error()))
+			result.report(SourceIPC, 0, ipcnsid, ref, err)
+			continue
+		}
+		for _, mqueue := range mqueueMounts(ipcns, procfs) {
+			if _, ok := initialmqueues[mqueueDev(mqueue)]; ok && ipcnsid != initialipcnsid {
+				continue
+			}
+			ealdorman := ipcns.Ealdorman()
+			path := procfs + "/" + strconv.Itoa(int(ealdorman.PID)) + "/root" + mqueue.MountPoint
+			mqs, err := readPOSIXMessageQueues(path)
+			if err != nil {
+				result.report(SourceIPC, ealdorman.PID, ipcnsid, path, err)
+				continue
+			}
+			objects.POSIXMessageQueues = mqs
+			objects.MqueuePath = mqueue.MountPoint
+			break
+		}
+		objectstotal += len(objects.SharedMemory) + len(objects.Semaphores) +
+			len(objects.MessageQueues) + len(objects.POSIXMessageQueues)
+		ipcns.(namespaces.IPCConfigurer).SetIPCObjects(objects)
+	}
+	slog.Info("found IPC objects",
+		slog.Int("namespace_count", len(result.Namespaces[model.IPCNS])),
+		slog.Int("count", objectstotal))
+}
+
+// sysvIPCObjects returns the System V IPC objects of the IPC namespace
+// referenced by the specified path, switching into the IPC namespace in order
+// to read the files in “/proc/sysvipc/”.
+func sysvIPCObjects(ref string, procfs string) (*model.IPCObjects, error) {
+	type objectsOrError struct {
+		objects *model.IPCObjects
+		err     error
+	}
+	res, err := ops.Execute(func() objectsOrError {
+		objects, err := readSysVIPCObjects(procfs + "/sysvipc/")
+		return objectsOrError{objects: objects, err: err}
+	}, ops.NewTypedNamespacePath(ref, species.CLONE_NEWIPC))
+	if err != nil {
+		return nil, err
+	}
+	return res.objects, res.err
+}
+
+// mqueueMounts returns the mqueue filesystem mounts in the mount namespace of
+// the ealdorman of the specified IPC namespace, using the proc filesystem
+// mounted at procfs.
+func mqueueMounts(ipcns model.Namespace, procfs string) []mntinfo.Mountinfo {
+	ealdorman := ipcns.Ealdorman()
+	if ealdorman == nil {
+		return nil
+	}
+	return mounts.MountsOfType(procfs, int(ealdorman.PID), "mqueue")
+}
+
+// mqueueDev returns the device number of the specified mqueue filesystem
+// mount; as each IPC namespace has its own mqueue filesystem instance, the
+// device number identifies the mqueue filesystem instance.
+func mqueueDev(mqueue mntinfo.Mountinfo) uint64 {
+	return unix.Mkdev(uint32(mqueue.Major), uint32(mqueue.Minor)) // #nosec G115
+}
+
+// readSysVIPCObjects reads the System V shared memory segments, semaphore
+// sets, and message queues from the “shm”, “sem”, and “msg” files in the
+// specified directory, which usually is “/proc/sysvipc/”.
+func readSysVIPCObjects(dir string) (*model.IPCObjects, error) {
+	objects := &model.IPCObjects{}
+	err := readSysVIPCTable(dir+"shm", "shmid", func(obj model.SysVIPCObject, row sysvIPCRow) {
+		objects.SharedMemory = append(objects.SharedMemory, model.SysVSharedMemory{
+			SysVIPCObject: obj,
+			Size:          row.uint("size"),
+			Attached:      row.uint("nattch"),
+			CreatorPID:    model.PIDType(row.uint("cpid")), // #nosec G115
+			LastPID:       model.PIDType(row.uint("lpid")), // #nosec G115
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = readSysVIPCTable(dir+"sem", "semid", func(obj model.SysVIPCObject, row sysvIPCRow) {
+		objects.Semaphores = append(objects.Semaphores, model.SysVSemaphoreSet{
+			SysVIPCObject: obj,
+			Semaphores:    row.uint("nsems"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = readSysVIPCTable(dir+"msg", "msqid", func(obj model.SysVIPCObject, row sysvIPCRow) {
+		objects.MessageQueues = append(objects.MessageQueues, model.SysVMessageQueue{
+			SysVIPCObject: obj,
+			Bytes:         row.uint("cbytes"),
+			Messages:      row.uint("qnum"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// sysvIPCRow maps the column names of a “/proc/sysvipc/” table to the fields
+// of a single row.
+type sysvIPCRow map[string]string
+
+// uint returns the unsigned decimal value of the named column, or zero if
+// missing or invalid.
+func (r sysvIPCRow) uint(column string) uint64 {
+	value, _ := strconv.ParseUint(r[column], 10, 64)
+	return value
+}
+
+// readSysVIPCTable reads the “/proc/sysvipc/” table from the specified file,
+// calling fn for each row with the common IPC object properties already
+// parsed. The name of the column containing the IPC object identifier differs
+// between the tables, so it needs to be specified.
+func readSysVIPCTable(path string, idcolumn string, fn func(model.SysVIPCObject, sysvIPCRow)) error {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	return parseSysVIPCTable(f, idcolumn, fn)
+}
+
+// parseSysVIPCTable parses a “/proc/sysvipc/” table consisting of a header
+// line with the column names, followed by a line per IPC object.
+func parseSysVIPCTable(r io.Reader, idcolumn string, fn func(model.SysVIPCObject, sysvIPCRow)) error {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return scanner.Err()
+	}
+	columns := strings.Fields(scanner.Text())
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != len(columns) {
+			return fmt.Errorf("invalid sysvipc line %q", scanner.Text())
+		}
+		row := sysvIPCRow{}
+		for idx, column := range columns {
+			row[column] = fields[idx]
+		}
+		key, err := strconv.ParseInt(row["key"], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid sysvipc line %q, %w", scanner.Text(), err)
+		}
+		id, err := strconv.Atoi(row[idcolumn])
+		if err != nil {
+			return fmt.Errorf("invalid sysvipc line %q, %w", scanner.Text(), err)
+		}
+		perms, err := strconv.ParseUint(row["perms"], 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid sysvipc line %q, %w", scanner.Text(), err)
+		}
+		fn(model.SysVIPCObject{
+			Key:   int32(key),
+			ID:    id,
+			Perms: uint32(perms),
+			UID:   uint32(row.uint("uid")),  // #nosec G115
+			GID:   uint32(row.uint("gid")),  // #nosec G115
+			CUID:  uint32(row.uint("cuid")), // #nosec G115
+			CGID:  uint32(row.uint("cgid")), // #nosec G115
+		}, row)
+	}
+	return scanner.Err()
+}
+
+// readPOSIXMessageQueues returns the POSIX message queues found in the mqueue
+// filesystem mounted at the specified path.
+func readPOSIXMessageQueues(path string) ([]model.POSIXMessageQueue, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	mqs := []model.POSIXMessageQueue{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		mq := model.POSIXMessageQueue{
+			Name: entry.Name(),
+			Mode: uint32(info.Mode().Perm()),
+		}
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+			mq.UID = stat.Uid
+			mq.GID = stat.Gid
+		}
+		// Reading a message queue file returns a single line of the form
+		// "QSIZE:0 NOTIFY:0 SIGNO:0 NOTIFY_PID:0".
+		if status, err := os.ReadFile(path + "/" + entry.Name()); err == nil { // #nosec G304
+			for _, field := range strings.Fields(string(status)) {
+				if qsize, ok := strings.CutPrefix(field, "QSIZE:"); ok {
+					mq.Size, _ = strconv.ParseUint(qsize, 10, 64)
+				}
+			}
+		}
+		mqs = append(mqs, mq)
+	}
+	return mqs, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/thediveo/testbasher"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/nstest"
+	"github.com/thediveo/lxkns/ops"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("Discover IPC objects", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("parses sysvipc tables", func() {
+		var objs []model.SysVIPCObject
+		var sizes []uint64
+		Expect(parseSysVIPCTable(strings.NewReader(
+			"       key      shmid perms                  size  cpid  lpid nattch   uid   gid  cuid  cgid      atime      dtime      ctime                   rss                  swap\n"+
+				"1456502697          0   644                  4096 30562     0      0     0     0     0     0          0          0 1792194297                     0                     0\n"+
+				"        -1         42  1600                 65536    42    43      2  1000  1001  1002  1003          0          0 1792194297                     0                     0\n"),
+			"shmid", func(obj model.SysVIPCObject, row sysvIPCRow) {
+				objs = append(objs, obj)
+				sizes = append(sizes, row.uint("size"))
+			})).To(Succeed())
+		Expect(objs).To(HaveExactElements(
+			model.SysVIPCObject{Key: 1456502697, ID: 0, Perms: 0o644},
+			model.SysVIPCObject{Key: -1, ID: 42, Perms: 0o1600, UID: 1000, GID: 1001, CUID: 1002, CGID: 1003},
+		))
+		Expect(sizes).To(HaveExactElements(uint64(4096), uint64(65536)))
+
+		Expect(parseSysVIPCTable(strings.NewReader(""), "shmid", nil)).To(Succeed())
+		for _, text := range []string{
+			"key shmid perms\n1 2\n",
+			"key shmid perms\nx 2 644\n",
+			"key shmid perms\n1 x 644\n",
+			"key shmid perms\n1 2 999\n",
+		} {
+			Expect(parseSysVIPCTable(strings.NewReader(text), "shmid",
+				func(model.SysVIPCObject, sysvIPCRow) {})).NotTo(Succeed(), "text %q", text)
+		}
+	})
+
+	It("doesn't discover IPC objects unless asked to", func() {
+		allns := Namespaces(FromProcs())
+		for _, ipcns := range allns.Namespaces[model.IPCNS] {
+			Expect(ipcns.(model.IPCInventory).IPCObjects()).To(BeNil())
+		}
+	})
+
+	It("finds mqueue mounts using the proc filesystem in use", func() {
+		f, err := os.Open("/proc/self/ns/mnt")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = f.Close() }()
+		if _, err := mounts.MountsOfNamespace(int(f.Fd())); errors.Is(err, errors.ErrUnsupported) {
+			Skip("kernel doesn't support listmount/statmount with mount namespace IDs")
+		}
+
+		allns := Namespaces(FromProcs())
+		myipcnsid, err := ops.NamespacePath("/proc/self/ns/ipc").ID()
+		Expect(err).NotTo(HaveOccurred())
+		ipcns := allns.Namespaces[model.IPCNS][myipcnsid]
+		Expect(ipcns).NotTo(BeNil())
+		Expect(mqueueMounts(ipcns, "/proc/self/root/proc")).To(
+			Equal(mqueueMounts(ipcns, "/proc")))
+		Expect(mqueueMounts(ipcns, "/nonexisting")).To(BeEmpty())
+	})
+
+	It("discovers IPC objects", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		if _, err := os.Stat("/proc/sysvipc/shm"); err != nil {
+			Skip("kernel without System V IPC support")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -im --propagation private $stage2
+`)
+		// The script only reports the (short) IPC namespace ID, as the test
+		// decides on the mount point of the mqueue filesystem.
+		mqdir := GinkgoT().TempDir()
+		scripts.Script("stage2", fmt.Sprintf(`
+mount -t mqueue none %[1]s
+touch %[1]s/lxkns-test-queue
+ipcmk -M 4096 >/dev/null
+ipcmk -S 3 >/dev/null
+ipcmk -Q >/dev/null
+process_namespaceid ipc # prints the IPC namespace ID of "the" process.
+read # wait for test to proceed()
+umount %[1]s
+`, mqdir))
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		ipcnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(FromProcs(), WithIPCObjects())
+		ipcns := allns.Namespaces[model.IPCNS][ipcnsid]
+		Expect(ipcns).NotTo(BeNil())
+		objects := ipcns.(model.IPCInventory).IPCObjects()
+		Expect(objects).NotTo(BeNil())
+		Expect(objects.SharedMemory).To(ConsistOf(And(
+			HaveField("Size", uint64(4096)),
+			HaveField("SysVIPCObject.Perms", uint32(0o644)),
+		)))
+		Expect(objects.Semaphores).To(ConsistOf(HaveField("Semaphores", uint64(3))))
+		Expect(objects.MessageQueues).To(ConsistOf(HaveField("Messages", uint64(0))))
+		Expect(objects.POSIXMessageQueues).To(ConsistOf(
+			HaveField("Name", "lxkns-test-queue")))
+		Expect(objects.MqueuePath).To(Equal(mqdir))
+	})
+
+	It("discovers IPC objects of bind-mounted IPC namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		if _, err := os.Stat("/proc/sysvipc/shm"); err != nil {
+			Skip("kernel without System V IPC support")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -m --propagation private $stage2
+`)
+		// The bind-mounted IPC namespace is only reachable through the
+		// transient mount namespace, as no process is attached to it.
+		bmpath := filepath.Join(GinkgoT().TempDir(), "ipcns")
+		scripts.Script("stage2", fmt.Sprintf(`
+touch %[1]s
+unshare --ipc=%[1]s true
+nsenter --ipc=%[1]s ipcmk -M 4096 >/dev/null
+namespaceid %[1]s # prints the ID of the bind-mounted IPC namespace.
+read # wait for test to proceed()
+`, bmpath))
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		ipcnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(FromProcs(), FromBindmounts(), WithIPCObjects())
+		ipcns := allns.Namespaces[model.IPCNS][ipcnsid]
+		Expect(ipcns).NotTo(BeNil())
+		Expect(ipcns.Ref()).To(HaveLen(2))
+		objects := ipcns.(model.IPCInventory).IPCObjects()
+		Expect(objects).NotTo(BeNil())
+		Expect(objects.SharedMemory).To(ConsistOf(HaveField("Size", uint64(4096))))
+	})
+
+})
@@ -23,12 +23,14 @@ package discover
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/thediveo/go-mntinfo"
 
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/ops"
 	"github.com/thediveo/lxkns/ops/mountineer"
 	"github.com/thediveo/lxkns/species"
 )
@@ -41,7 +43,7 @@ type NamespacedMountPathMap map[species.NamespaceID]mounts.MountPathMap
 // mount namespaces. API users must have opted in not only to this discovery
 // step but must have also enabled discovery of mount namespaces. Otherwise,
 // this step will be skipped.
-func discoverFromMountinfo(_ species.NamespaceType, _ string, result *Result) {
+func discoverFromMountinfo(ctx context.Context, _ species.NamespaceType, procfs string, result *Result) {
 	if !result.Options.DiscoverMounts {
 		slog.Info("skipping discovery of namespaces", slog.String("src", "mountpaths,mountpoints"))
 		return
@@ -54,9 +56,36 @@ func discoverFromMountinfo(_ species.NamespaceType, _ string, result *Result) {
 	// determine the mount paths and the mount point visibility from this
 	// information.
 	result.Mounts = NamespacedMountPathMap{}
-	debugEnabled := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
 	mountpointtotal := 0
+	withStatmount := true
 	for mntid, mountns := range result.Namespaces[model.MountNS] {
+		if ctx.Err() != nil {
+			return
+		}
+		// Where supported, we directly list and stat the mounts of a mount
+		// namespace, without the need for any sandbox.
+		if withStatmount {
+			mountpoints, err := statMounts(mountns)
+			if err == nil {
+				if debugEnabled {
+					slog.Debug("found mounts inside mount namespace using statmount",
+						slog.String("namespace", mountns.(model.NamespaceStringer).TypeIDString()),
+						slog.Int("count", len(mountpoints)))
+				}
+				mountpointtotal += len(mountpoints)
+				result.Mounts[mntid] = mounts.NewMountPathMapFromMountPoints(mountpoints)
+				continue
+			}
+			if errors.Is(err, errors.ErrUnsupported) {
+				slog.Info("kernel does not support statmount, falling back to mountinfo")
+				withStatmount = false
+			} else if debugEnabled {
+				slog.Debug("cannot statmount, falling back to mountinfo",
+					slog.String("namespace", mountns.(model.NamespaceStringer).TypeIDString()),
+					slog.String("err", err.Error()))
+			}
+		}
 		mnteer, err := mountineer.NewWithMountNamespace(
 			mountns,
 			result.Namespaces[model.UserNS])
@@ -64,12 +93,13 @@ func discoverFromMountinfo(_ species.NamespaceType, _ string, result *Result) {
 			slog.Error("could not discover mount points",
 				slog.String("namespace", mountns.(model.NamespaceStringer).TypeIDString()),
 				slog.String("err", err.Error()))
+			result.report(SourceMountinfo, 0, mntid, mountns.Ref().String(), err)
 			continue
 		}
 		if debugEnabled {
 			slog.Debug("reading mount point information from bind-mounted namespace",
 				slog.String("namespace", mountns.(model.NamespaceStringer).TypeIDString()),
-				slog.String("ref", refString(mountns, result)))
+				slog.String("ref", refString(mountns, procfs, result)))
 		}
 		// Warp speed Mr Sulu, through the proc root wormhole!
 		mountpoints := mntinfo.MountsOfPid(int(mnteer.PID()))
@@ -86,3 +116,20 @@ func discoverFromMountinfo(_ species.NamespaceType, _ string, result *Result) {
 		slog.Int("count", len(result.Mounts)),
 		slog.Int("mountpoint_count", mountpointtotal))
 }
+
+// statMounts returns the mount points of the specified mount namespace using
+// statmount(2), as long as the mount namespace can be referenced by a single
+// path. Bind-mounted mount namespaces in other mount namespaces need the
+// mountineer instead.
+func statMounts(mountns model.Namespace) ([]mounts.MountPoint, error) {
+	ref := mountns.Ref()
+	if len(ref) != 1 {
+		return nil, errors.New("multi-path mount namespace reference")
+	}
+	fd, closer, err := ops.NamespacePath(ref[0]).NsFd()
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+	return mounts.MountsOfNamespace(fd)
+}
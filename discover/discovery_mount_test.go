@@ -15,6 +15,7 @@
 package discover
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/thediveo/testbasher"
 
+	"github.com/thediveo/lxkns/mounts"
 	"github.com/thediveo/lxkns/nstest"
 	"github.com/thediveo/lxkns/ops"
 	"github.com/thediveo/lxkns/species"
@@ -91,4 +93,23 @@ rmdir $bm-dir || /bin/true
 		Expect(namespacedmmap[initialmntnsid]).NotTo(HaveKey(bm))
 	})
 
+	It("discovers unique mount IDs where supported", func() {
+		f, err := os.Open("/proc/self/ns/mnt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = mounts.MountsOfNamespace(int(f.Fd()))
+		_ = f.Close()
+		if errors.Is(err, errors.ErrUnsupported) {
+			Skip("kernel doesn't support listmount/statmount with mount namespace IDs")
+		}
+
+		allns := Namespaces(WithNamespaceTypes(species.CLONE_NEWNS), FromProcs(), WithMounts())
+		mntnsid, err := ops.NamespacePath("/proc/self/ns/mnt").ID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allns.Mounts).To(HaveKey(mntnsid))
+		Expect(allns.Mounts[mntnsid]).To(HaveKey("/"))
+		Expect(allns.Mounts[mntnsid]["/"].Mounts).To(ContainElement(And(
+			HaveField("UniqueID", Not(BeZero())),
+			HaveField("MountNamespaceID", Not(BeZero())))))
+	})
+
 })
@@ -0,0 +1,202 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/internal/rtnetlink"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+)
+
+// discoverNetworkInterfaces discovers the network interfaces together with
+// their IP addresses of the discovered network namespaces. API users must have
+// opted in to this discovery step, as well as to the discovery of network
+// namespaces.
+//
+// As routing netlink sockets are bound to the network namespace they were
+// created in, we need to briefly switch into each network namespace. While
+// being there, we also resolve the identifiers (“nsids”) this network
+// namespace assigned to other network namespaces, so that we can relate veth
+// peers and lower interfaces across network namespaces. Finally, we build the
+// network topology from the network interfaces of all network namespaces.
+func discoverNetworkInterfaces(ctx context.Context, _ species.NamespaceType, _ string, result *Result) {
+	if !result.Options.DiscoverNetworkInterfaces {
+		slog.Info("skipping discovery of network interfaces", slog.String("src", "rtnetlink"))
+		return
+	}
+	if result.Options.NamespaceTypes&species.CLONE_NEWNET == 0 {
+		slog.Warn("network namespace discovery skipped, so skipping network interfaces discovery")
+		return
+	}
+	slog.Debug("discovering network interfaces", slog.String("src", "rtnetlink"))
+	// Only network namespaces we can switch into can be resolved from nsids.
+	// As network namespaces bind-mounted into other mount namespaces need to
+	// be reached through these mount namespaces, we keep the latter open until
+	// we're done with all network namespaces.
+	netnsrefs := map[species.NamespaceID]string{}
+	for netnsid, netns := range result.Namespaces[model.NetNS] {
+		ref, closer, err := resolveNamespaceRef(netns.Ref(), result)
+		if err != nil {
+			slog.Warn("cannot open bind-mounted network namespace",
+				slog.String("namespace", netns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("ref", netns.Ref().String()),
+				slog.String("err", err.Error()))
+			result.report(SourceNetif, 0, netnsid, netns.Ref().String(), err)
+			continue
+		}
+		defer closer()
+		netnsrefs[netnsid] = ref
+	}
+	netiftotal := 0
+	for netnsid, netns := range result.Namespaces[model.NetNS] {
+		if ctx.Err() != nil {
+			return
+		}
+		ref, ok := netnsrefs[netnsid]
+		if !ok {
+			continue
+		}
+		netifs, nsids, err := networkInterfaces(ref, netnsrefs)
+		if err != nil {
+			slog.Warn("cannot read network interfaces",
+				slog.String("namespace", netns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("err", err.Error()))
+			result.report(SourceNetif, 0, netnsid, ref, err)
+			continue
+		}
+		netiftotal += len(netifs)
+		netns.(namespaces.NetConfigurer).SetNetworkInterfaces(netifs)
+		netns.(namespaces.NetConfigurer).SetNetNSIDs(nsids)
+	}
+	slog.Info("found network interfaces",
+		slog.Int("namespace_count", len(result.Namespaces[model.NetNS])),
+		slog.Int("count", netiftotal))
+	result.NetworkTopology = model.NewNetworkTopology(result.Namespaces[model.NetNS])
+}
+
+// networkInterfaces returns the network interfaces of the network namespace
+// referenced by the specified path, sorted by their interface indices, as well
+// as the nsids this network namespace assigned to the network namespaces
+// referenced by netnsrefs.
+func networkInterfaces(ref string, netnsrefs map[species.NamespaceID]string) ([]model.NetworkInterface, map[int32]species.NamespaceID, error) {
+	type linksOrError struct {
+		links []rtnetlink.Link
+		addrs []rtnetlink.Address
+		nsids map[int32]species.NamespaceID
+		err   error
+	}
+	res, err := ops.Execute(func() (res linksOrError) {
+		conn, err := rtnetlink.Dial()
+		if err != nil {
+			res.err = err
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		if res.links, res.err = conn.Links(); res.err != nil {
+			return
+		}
+		if res.addrs, res.err = conn.Addresses(); res.err != nil {
+			return
+		}
+		res.nsids = netNSIDs(conn, netnsrefs)
+		return
+	}, ops.NewTypedNamespacePath(ref, species.CLONE_NEWNET))
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.err != nil {
+		return nil, nil, res.err
+	}
+	return newNetworkInterfaces(res.links, res.addrs, res.nsids), res.nsids, nil
+}
+
+// netNSIDs returns the nsids assigned by the network namespace the specified
+// routing netlink connection is bound to, mapped to the IDs of the network
+// namespaces referenced by netnsrefs. As the kernel doesn't tell us which
+// network namespace an nsid belongs to, we have to ask for the nsid of each
+// network namespace in turn, until we've resolved all assigned nsids. The
+// references can be either paths or nsfs file handles.
+func netNSIDs(conn *rtnetlink.Conn, netnsrefs map[species.NamespaceID]string) map[int32]species.NamespaceID {
+	assigned, err := conn.NetNSIDs()
+	if err != nil {
+		slog.Debug("cannot read nsids", slog.String("err", err.Error()))
+		return nil
+	}
+	nsids := make(map[int32]species.NamespaceID, len(assigned))
+	for netnsid, ref := range netnsrefs {
+		if len(nsids) == len(assigned) {
+			break
+		}
+		fd, closer, err := ops.NamespacePath(ref).NsFd()
+		if err != nil {
+			continue
+		}
+		nsid, err := conn.NetNSIDOf(fd)
+		closer()
+		if err != nil || nsid == unix.NETNSA_NSID_NOT_ASSIGNED {
+			continue
+		}
+		nsids[nsid] = netnsid
+	}
+	return nsids
+}
+
+// newNetworkInterfaces returns the network interfaces for the specified links
+// and addresses, sorted by their interface indices. The specified nsids are
+// used to resolve the network namespaces of veth peers and lower interfaces
+// located in other network namespaces.
+func newNetworkInterfaces(links []rtnetlink.Link, addrs []rtnetlink.Address, nsids map[int32]species.NamespaceID) []model.NetworkInterface {
+	netifs := make([]model.NetworkInterface, 0, len(links))
+	for _, link := range links {
+		netif := model.NetworkInterface{
+			Index:     link.Index,
+			Name:      link.Name,
+			Kind:      link.Kind,
+			MTU:       link.MTU,
+			OperState: model.OperStateFromKernel(link.OperState),
+			Master:    link.MasterIndex,
+		}
+		if link.LinkNetNSID == unix.NETNSA_NSID_NOT_ASSIGNED {
+			// many interfaces are their own "parent", so skip them.
+			if link.ParentIndex != link.Index {
+				netif.Link = link.ParentIndex
+			}
+		} else if netnsid, ok := nsids[link.LinkNetNSID]; ok {
+			netif.Link = link.ParentIndex
+			netif.LinkNetNS = netnsid
+		}
+		if len(link.HardwareAddr) != 0 {
+			netif.MAC = link.HardwareAddr.String()
+		}
+		for _, addr := range addrs {
+			if addr.Index == link.Index {
+				netif.Addresses = append(netif.Addresses, addr.Prefix)
+			}
+		}
+		netifs = append(netifs, netif)
+	}
+	slices.SortFunc(netifs, func(a, b model.NetworkInterface) int { return a.Index - b.Index })
+	return netifs
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/thediveo/testbasher"
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nstest"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("Discover network interfaces", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("doesn't discover network interfaces unless asked to", func() {
+		allns := Namespaces(FromProcs())
+		for _, netns := range allns.Namespaces[model.NetNS] {
+			Expect(netns.(model.NetworkInventory).NetworkInterfaces()).To(BeNil())
+		}
+	})
+
+	It("discovers network interfaces and addresses", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -n $stage2
+`)
+		scripts.Script("stage2", `
+ip link set lo up
+ip link add lxkns-veth0 type veth peer name lxkns-veth1
+ip link add lxkns-br0 mtu 1400 type bridge
+ip addr add 10.11.12.13/24 dev lxkns-br0
+ip addr add fd00::1/64 dev lxkns-br0
+process_namespaceid net # prints the network namespace ID of "the" process.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		netnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(FromProcs(), WithNetworkInterfaces())
+		netns := allns.Namespaces[model.NetNS][netnsid]
+		Expect(netns).NotTo(BeNil())
+		netifs := netns.(model.NetworkInventory).NetworkInterfaces()
+		Expect(netifs).To(HaveLen(4))
+		Expect(netifs[0]).To(And(
+			HaveField("Index", 1),
+			HaveField("Name", "lo"),
+			HaveField("Kind", ""),
+			HaveField("OperState", model.OperStateUnknown),
+			HaveField("Addresses", ContainElement(netip.MustParsePrefix("127.0.0.1/8"))),
+		))
+		Expect(netifs).To(ContainElement(And(
+			HaveField("Name", "lxkns-veth0"),
+			HaveField("Kind", "veth"),
+			HaveField("MAC", MatchRegexp(`^([0-9a-f]{2}:){5}[0-9a-f]{2}$`)),
+			HaveField("OperState", model.OperStateDown),
+		)))
+		Expect(netifs).To(ContainElement(And(
+			HaveField("Name", "lxkns-br0"),
+			HaveField("Kind", "bridge"),
+			HaveField("MTU", uint32(1400)),
+			HaveField("Addresses", ConsistOf(
+				netip.MustParsePrefix("10.11.12.13/24"),
+				netip.MustParsePrefix("fd00::1/64"))),
+		)))
+	})
+
+	It("discovers network interfaces of bind-mounted network namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -nm --propagation private $stage2
+`)
+		// The bind-mounted network namespace is only reachable through the
+		// transient mount namespace, as no process is attached to it.
+		bmpath := filepath.Join(GinkgoT().TempDir(), "netns")
+		scripts.Script("stage2", fmt.Sprintf(`
+touch %[1]s
+unshare --net=%[1]s true
+nsenter --net=%[1]s ip link add lxkns-veth0 type veth peer name lxkns-veth1 netns $$
+process_namespaceid net # prints the network namespace ID of "the" process.
+namespaceid %[1]s # prints the ID of the bind-mounted network namespace.
+read # wait for test to proceed()
+`, bmpath))
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		netnsid := nstest.CmdDecodeNSId(cmd)
+		bmnetnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(FromProcs(), FromBindmounts(), WithNetworkInterfaces())
+		bmnetns := allns.Namespaces[model.NetNS][bmnetnsid]
+		Expect(bmnetns).NotTo(BeNil())
+		Expect(bmnetns.Ref()).To(HaveLen(2))
+		Expect(bmnetns.(model.NetworkInventory).NetworkInterfaces()).To(ContainElement(And(
+			HaveField("Name", "lxkns-veth0"),
+			HaveField("Kind", "veth"),
+		)))
+		Expect(bmnetns.(model.NetworkInventory).NetNSIDs()).To(ConsistOf(netnsid))
+	})
+
+	It("relates network interfaces across network namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -n $stage2
+`)
+		scripts.Script("stage2", `
+ip link add lxkns-br0 type bridge
+ip link add lxkns-veth0 master lxkns-br0 type veth peer name lxkns-veth1
+ip link add lxkns-mv0 link lxkns-br0 type macvlan mode bridge
+unshare -n sleep infinity &
+PEER=$!
+trap "kill $PEER" EXIT
+while [ "$(namespaceid /proc/$PEER/ns/net)" = "$(process_namespaceid net)" ]; do sleep 0.1; done
+ip link set lxkns-veth1 netns $PEER
+process_namespaceid net # prints the network namespace ID of "the" process.
+namespaceid /proc/$PEER/ns/net # prints the network namespace ID of the peer.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		netnsid := nstest.CmdDecodeNSId(cmd)
+		peernetnsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(FromProcs(), WithNetworkInterfaces())
+		netns := allns.Namespaces[model.NetNS][netnsid]
+		Expect(netns).NotTo(BeNil())
+		peernetns := allns.Namespaces[model.NetNS][peernetnsid]
+		Expect(peernetns).NotTo(BeNil())
+
+		Expect(netns.(model.NetworkInventory).NetNSIDs()).To(ConsistOf(peernetnsid))
+		Expect(peernetns.(model.NetworkInventory).NetNSIDs()).To(ConsistOf(netnsid))
+
+		netif := func(netns model.Namespace, name string) model.NetworkInterface {
+			GinkgoHelper()
+			netifs := netns.(model.NetworkInventory).NetworkInterfaces()
+			idx := slices.IndexFunc(netifs, func(netif model.NetworkInterface) bool {
+				return netif.Name == name
+			})
+			Expect(idx).NotTo(BeNumerically("<", 0), "missing network interface %q", name)
+			return netifs[idx]
+		}
+		br0 := netif(netns, "lxkns-br0")
+		veth0 := netif(netns, "lxkns-veth0")
+		mv0 := netif(netns, "lxkns-mv0")
+		veth1 := netif(peernetns, "lxkns-veth1")
+		Expect(veth0.Master).To(Equal(br0.Index))
+		Expect(veth0.Link).To(Equal(veth1.Index))
+		Expect(veth0.LinkNetNS).To(Equal(peernetnsid))
+		Expect(veth1.Link).To(Equal(veth0.Index))
+		Expect(veth1.LinkNetNS).To(Equal(netnsid))
+		Expect(mv0.Link).To(Equal(br0.Index))
+		Expect(mv0.LinkNetNS).To(BeZero())
+		Expect(netif(netns, "lo").Link).To(BeZero())
+
+		topo := allns.NetworkTopology
+		bridge, ok := topo.Bridge(model.NetworkInterfaceRef{NetNS: peernetnsid, Index: veth1.Index})
+		Expect(ok).To(BeTrue())
+		Expect(bridge).To(Equal(model.NetworkInterfaceRef{NetNS: netnsid, Index: br0.Index}))
+		Expect(topo.Edges).To(ContainElement(model.NetworkTopologyEdge{
+			From:     model.NetworkInterfaceRef{NetNS: netnsid, Index: mv0.Index},
+			To:       model.NetworkInterfaceRef{NetNS: netnsid, Index: br0.Index},
+			Relation: model.NetworkRelationLower,
+		}))
+
+		By("resolving nsids of network namespaces referenced by nsfs file handles")
+		fd, err := unix.Open(peernetns.Ref()[0], unix.O_RDONLY|unix.O_CLOEXEC, 0)
+		Expect(err).NotTo(HaveOccurred())
+		nsh, err := ops.NewNamespaceHandle(fd)
+		_ = unix.Close(fd)
+		if err != nil {
+			Skip("kernel doesn't support nsfs file handles")
+		}
+		_, nsids, err := networkInterfaces(netns.Ref()[0],
+			map[species.NamespaceID]string{peernetnsid: nsh.Path()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nsids).To(ConsistOf(peernetnsid))
+	})
+
+})
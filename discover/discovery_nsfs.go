@@ -0,0 +1,181 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"log/slog"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+)
+
+// sysListns is the syscall number of listns(2), available since Linux kernel
+// 6.19. Syscalls added after 5.1 share the same number on all architectures.
+const sysListns = 470
+
+// nsIDReq is the request passed to listns(2), see also [include/uapi/linux/nsfs.h].
+//
+// [include/uapi/linux/nsfs.h]: https://elixir.bootlin.com/linux/v6.19/source/include/uapi/linux/nsfs.h
+type nsIDReq struct {
+	size     uint32 // size of this request, for versioning.
+	_        uint32
+	nsID     uint64 // list namespaces with identifiers after this one.
+	nsType   uint32 // CLONE_NEW* type(s) of namespaces to list.
+	_        uint32
+	userNsID uint64 // list only namespaces owned by this user namespace, if non-zero.
+}
+
+// listnsBatchSize is the maximum number of namespace identifiers to ask
+// listns(2) for in a single call.
+const listnsBatchSize = 256
+
+// discoverFromNsfs discovers namespaces of the specified type directly from
+// the kernel, using the listns(2) syscall to enumerate the identifiers of all
+// active namespaces and then opening them using nsfs file handles. This finds
+// even those namespaces that are not referenced by any process, open file
+// descriptor, or bind-mount, such as namespaces kept alive only by their child
+// namespaces. These namespaces are referenced by nsfs file handles in the
+// textual form of [ops.NamespaceHandle.Path].
+//
+// On kernels without listns(2) support, discoverFromNsfs silently does
+// nothing.
+func discoverFromNsfs(ctx context.Context, nstype species.NamespaceType, _ string, result *Result) {
+	if !result.Options.ScanNsfs {
+		slog.Info("skipping discovery of namespaces from nsfs", slog.String("type", nstype.Name()))
+		return
+	}
+	slog.Debug("discovering namespaces from nsfs", slog.String("type", nstype.Name()))
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
+	nsmap := result.Namespaces[model.TypeIndex(nstype)]
+	total := 0
+	for nsh, err := range listNamespaces(nstype) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if errors.Is(err, unix.ENOSYS) {
+				slog.Info("kernel does not support listing namespaces")
+				return
+			}
+			result.report(SourceNsfs, 0, species.NoneID, nsh.Path(), err)
+			continue
+		}
+		nsid, ownernsid, err := lookupHandleNamespace(&nsh, result.Options.DiscoverOwnership)
+		if err != nil {
+			result.report(SourceNsfs, 0, species.NoneID, nsh.Path(), err)
+			continue
+		}
+		ns, ok := nsmap[nsid]
+		if ok {
+			// Namespaces found from other sources might lack references, such
+			// as namespaces found by climbing up the hierarchy of PID and user
+			// namespaces, so let's fill in the gap where possible.
+			if len(ns.Ref()) == 0 {
+				ns.(namespaces.NamespaceConfigurer).SetRef(model.NamespaceRef{nsh.Path()})
+			}
+			continue
+		}
+		ns = namespaces.New(nstype, nsid, model.NamespaceRef{nsh.Path()})
+		if ownernsid != species.NoneID {
+			ns.(namespaces.NamespaceConfigurer).SetOwner(ownernsid)
+		}
+		nsmap[nsid] = ns
+		total++
+		if debugEnabled {
+			slog.Debug("found namespace from nsfs",
+				slog.String("namespace", ns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("ref", nsh.Path()))
+		}
+	}
+	slog.Info("found namespaces from nsfs", slog.String("type", nstype.Name()), slog.Int("count", total))
+}
+
+// listNamespaces iterates over the nsfs file handles of all active namespaces
+// of the specified type, as listed by the kernel. In case of an error, the
+// iteration yields the error and stops. The file handles lack the inode
+// numbers of the namespaces, see also [lookupHandleNamespace].
+func listNamespaces(nstype species.NamespaceType) iter.Seq2[ops.NamespaceHandle, error] {
+	return func(yield func(ops.NamespaceHandle, error) bool) {
+		req := nsIDReq{
+			size:   uint32(unsafe.Sizeof(nsIDReq{})),
+			nsType: uint32(nstype),
+		}
+		ids := make([]uint64, listnsBatchSize)
+		for {
+			n, _, errno := unix.Syscall6(sysListns,
+				uintptr(unsafe.Pointer(&req)),
+				uintptr(unsafe.Pointer(&ids[0])), uintptr(len(ids)),
+				0, 0, 0)
+			if errno != 0 {
+				yield(ops.NamespaceHandle{Type: nstype}, errno)
+				return
+			}
+			for _, id := range ids[:n] {
+				if !yield(ops.NamespaceHandle{ID: id, Type: nstype}, nil) {
+					return
+				}
+			}
+			if n < uintptr(len(ids)) {
+				return
+			}
+			req.nsID = ids[n-1]
+		}
+	}
+}
+
+// lookupHandleNamespace opens the namespace referenced by the specified nsfs
+// file handle and returns its namespace ID, as well as the ID of its owning
+// user namespace, if any. Additionally, it completes the handle with the inode
+// number of the namespace.
+func lookupHandleNamespace(nsh *ops.NamespaceHandle, discoverOwnership bool) (nsid species.NamespaceID, ownernsid species.NamespaceID, err error) {
+	fd, err := nsh.Open()
+	if err != nil {
+		if errors.Is(err, unix.ESTALE) {
+			// The namespace has gone since listing it.
+			err = fmt.Errorf("stale namespace handle, %w", fs.ErrNotExist)
+		}
+		return species.NoneID, species.NoneID, err
+	}
+	defer func() { _ = unix.Close(fd) }()
+	nsf, err := ops.NewTypedNamespaceFd(fd, nsh.Type)
+	if err != nil {
+		return species.NoneID, species.NoneID, err
+	}
+	nsid, err = nsf.ID()
+	if err != nil {
+		return species.NoneID, species.NoneID, err
+	}
+	nsh.Ino = uint32(nsid.Ino) // #nosec G115
+	if discoverOwnership && nsh.Type != species.CLONE_NEWUSER {
+		if usernsf, err := nsf.User(); err == nil {
+			ownernsid, _ = usernsf.ID()
+			_ = usernsf.(io.Closer).Close()
+		}
+	}
+	return nsid, ownernsid, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("nsfs discovery", func() {
+
+	It("lists namespaces directly from the kernel", func() {
+		netnsid, err := ops.NamespacePath("/proc/self/ns/net").ID()
+		Expect(err).NotTo(HaveOccurred())
+
+		allns := Namespaces(FromNsfs(), WithNamespaceTypes(species.CLONE_NEWNET))
+		Expect(allns.Errors).To(BeEmpty())
+		for _, err := range listNamespaces(species.CLONE_NEWNET) {
+			if errors.Is(err, unix.ENOSYS) {
+				Expect(allns.Namespaces[model.NetNS]).To(BeEmpty())
+				Skip("kernel doesn't support listing namespaces")
+			}
+			break
+		}
+		Expect(allns.Namespaces[model.NetNS]).To(HaveKeyWithValue(netnsid,
+			HaveField("Ref()", HaveExactElements(HavePrefix(ops.NamespaceHandlePrefix+"net:")))))
+	})
+
+	It("is opt-in only", func() {
+		opts := DiscoverOpts{}
+		WithStandardDiscovery()(&opts)
+		Expect(opts.ScanNsfs).To(BeFalse())
+		FromNsfs()(&opts)
+		Expect(opts.ScanNsfs).To(BeTrue())
+	})
+
+})
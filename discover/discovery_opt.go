@@ -18,6 +18,7 @@ package discover
 
 import (
 	"maps"
+	"strings"
 
 	"github.com/thediveo/lxkns/containerizer"
 	"github.com/thediveo/lxkns/species"
@@ -36,16 +37,30 @@ type DiscoverOpts struct {
 
 	ScanProcs                      bool              `json:"from-procs"`                    // Scan processes for attached namespaces.
 	ScanTasks                      bool              `json:"from-tasks"`                    // Scan all tasks for attached namespaces.
+	ScanPidfds                     bool              `json:"via-pidfds"`                    // Look up the namespaces of processes via pidfds, if supported.
 	ScanFds                        bool              `json:"from-fds"`                      // Scan open file descriptors for namespaces.
 	ScanBindmounts                 bool              `json:"from-bindmounts"`               // Scan bind-mounts for namespaces.
+	ScanNsfs                       bool              `json:"from-nsfs"`                     // List namespaces directly from the kernel and reference them via nsfs file handles.
 	DiscoverHierarchy              bool              `json:"with-hierarchy"`                // Discover the hierarchy of PID and user namespaces.
 	DiscoverOwnership              bool              `json:"with-ownership"`                // Discover the ownership of non-user namespaces.
 	DiscoverFreezerState           bool              `json:"with-freezer"`                  // Discover the cgroup freezer state of processes.
 	DiscoverMounts                 bool              `json:"with-mounts"`                   // Discover mount point hierarchy with mount paths and visibility.
 	DiscoverSocketProcesses        bool              `json:"with-socket-processes"`         // Discover the processes related to specific socket inode numbers.
+	DiscoverUTSNames               bool              `json:"with-uts-names"`                // Discover the host and domain names of UTS namespaces.
+	DiscoverCgroupRoots            bool              `json:"with-cgroup-roots"`             // Discover the roots of cgroup namespaces in the cgroup hierarchy.
+	DiscoverIPCObjects             bool              `json:"with-ipc-objects"`              // Discover the System V IPC objects and POSIX message queues of IPC namespaces.
+	DiscoverNetworkInterfaces      bool              `json:"with-network-interfaces"`       // Discover the network interfaces and addresses of network namespaces.
+	DiscoverSockets                bool              `json:"with-sockets"`                  // Discover the sockets of network namespaces.
 	DiscoverAffinityScheduling     bool              `json:"with-affinity-scheduling"`      // Disover CPU affinity and scheduling of leader processes.
 	DiscoverTaskAffinityScheduling bool              `json:"with-task-affinity-scheduling"` // Discovery CPU affinity and scheduling of all tasks.
+	DiscoverCapabilities           bool              `json:"with-capabilities"`             // Discover the capability sets of processes.
+	DiscoverTaskCapabilities       bool              `json:"with-task-capabilities"`        // Discover the capability sets of all tasks.
+	DiscoverCredentials            bool              `json:"with-credentials"`              // Discover the credentials of processes.
+	DiscoverTaskCredentials        bool              `json:"with-task-credentials"`         // Discover the credentials of all tasks.
+	DiscoverLSMContexts            bool              `json:"with-lsm-contexts"`             // Discover the LSM security contexts of processes.
 	Labels                         map[string]string `json:"labels"`                        // Pass options (in form of labels) to decorators
+	Concurrency                    int               `json:"concurrency"`                   // Maximum number of workers scanning processes in parallel; less than two scans sequentially.
+	ProcfsRoot                     string            `json:"procfs-root"`                   // Where the proc filesystem to discover from is mounted; defaults to "/proc".
 
 	Containerizer containerizer.Containerizer `json:"-"` // Discover containers using containerizer.
 
@@ -135,6 +150,21 @@ func NotFromTasks() DiscoveryOption {
 	return func(o *DiscoverOpts) { o.ScanTasks = false }
 }
 
+// WithPidfds opts to look up the namespaces of processes via pidfds instead of
+// via their paths in the proc filesystem, avoiding to attribute namespaces to
+// the wrong processes in case their PIDs get reused while discovering. On
+// kernels without support for getting namespaces from pidfds, or when
+// discovering from a proc filesystem of a different PID namespace, discovery
+// falls back to using proc filesystem paths.
+func WithPidfds() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.ScanPidfds = true }
+}
+
+// WithoutPidfds opts out of looking up the namespaces of processes via pidfds.
+func WithoutPidfds() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.ScanPidfds = false }
+}
+
 func WithAffinityAndScheduling() DiscoveryOption {
 	return func(o *DiscoverOpts) { o.DiscoverAffinityScheduling = true }
 }
@@ -172,6 +202,22 @@ func NotFromBindmounts() DiscoveryOption {
 	return func(o *DiscoverOpts) { o.ScanBindmounts = false }
 }
 
+// FromNsfs opts to list namespaces directly from the kernel, finding also
+// namespaces not referenced by any process, open file descriptor, or
+// bind-mount. Namespaces found this way are referenced using nsfs file handles
+// (see [github.com/thediveo/lxkns/ops.NamespaceHandle]); this includes “hidden” PID and user namespaces
+// found when discovering the namespace hierarchy. Listing namespaces requires
+// a Linux kernel 6.19 or later; on older kernels, this option only adds nsfs
+// file handle references to hidden namespaces, where supported.
+func FromNsfs() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.ScanNsfs = true }
+}
+
+// NotFromNsfs opts out of listing namespaces directly from the kernel.
+func NotFromNsfs() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.ScanNsfs = false }
+}
+
 // WithHierarchy opts to query the namespace hierarchy of PID and user
 // namespaces.
 func WithHierarchy() DiscoveryOption {
@@ -219,6 +265,163 @@ func WithoutSocketProcesses() DiscoveryOption {
 	return func(o *DiscoverOpts) { o.DiscoverSocketProcesses = false }
 }
 
+// WithUTSNames opts to find the host (node) and NIS domain names of UTS
+// namespaces. As this requires switching into each UTS namespace, it usually
+// needs root privileges.
+func WithUTSNames() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverUTSNames = true }
+}
+
+// WithoutUTSNames opts out of finding the host and domain names of UTS
+// namespaces.
+func WithoutUTSNames() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverUTSNames = false }
+}
+
+// WithCgroupRoots opts to find the roots of cgroup namespaces in the cgroup
+// hierarchy. As this requires switching into each cgroup namespace, it
+// usually needs root privileges.
+func WithCgroupRoots() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverCgroupRoots = true }
+}
+
+// WithoutCgroupRoots opts out of finding the roots of cgroup namespaces.
+func WithoutCgroupRoots() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverCgroupRoots = false }
+}
+
+// WithIPCObjects opts to find the System V IPC objects (shared memory,
+// semaphore sets, and message queues), as well as the POSIX message queues of
+// IPC namespaces. As this requires switching into each IPC namespace, it
+// usually needs root privileges.
+func WithIPCObjects() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverIPCObjects = true }
+}
+
+// WithoutIPCObjects opts out of finding the IPC objects of IPC namespaces.
+func WithoutIPCObjects() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverIPCObjects = false }
+}
+
+// WithNetworkInterfaces opts to find the network interfaces of network
+// namespaces, together with their IPv4 and IPv6 addresses. As this requires
+// switching into each network namespace, it usually needs root privileges.
+func WithNetworkInterfaces() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverNetworkInterfaces = true }
+}
+
+// WithoutNetworkInterfaces opts out of finding the network interfaces of
+// network namespaces.
+func WithoutNetworkInterfaces() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverNetworkInterfaces = false }
+}
+
+// WithSockets opts to find the TCP, UDP, UNIX domain, and packet sockets of
+// network namespaces. As this requires switching into each network namespace,
+// it usually needs root privileges. WithSockets implies [WithSocketProcesses],
+// so that the sockets can be related to the processes having them open.
+func WithSockets() DiscoveryOption {
+	return func(o *DiscoverOpts) {
+		o.DiscoverSockets = true
+		o.DiscoverSocketProcesses = true
+	}
+}
+
+// WithoutSockets opts out of finding the sockets of network namespaces.
+func WithoutSockets() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverSockets = false }
+}
+
+// WithCapabilities opts to find the effective, permitted, inheritable,
+// bounding, and ambient capability sets of all processes.
+func WithCapabilities() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverCapabilities = true }
+}
+
+// WithoutCapabilities opts out of finding the capability sets of processes.
+func WithoutCapabilities() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverCapabilities = false }
+}
+
+// WithTaskCapabilities opts to find the capability sets of all tasks, in
+// addition to the capability sets of all processes. Please note that this
+// requires scanning tasks, see [FromTasks].
+func WithTaskCapabilities() DiscoveryOption {
+	return func(o *DiscoverOpts) {
+		o.DiscoverCapabilities = true
+		o.DiscoverTaskCapabilities = true
+	}
+}
+
+// WithoutTaskCapabilities opts out of finding the capability sets of tasks.
+func WithoutTaskCapabilities() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverTaskCapabilities = false }
+}
+
+// WithCredentials opts to find the user and group credentials, as well as the
+// seccomp mode and no_new_privs flag, of all processes.
+func WithCredentials() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverCredentials = true }
+}
+
+// WithoutCredentials opts out of finding the credentials of processes.
+func WithoutCredentials() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverCredentials = false }
+}
+
+// WithTaskCredentials opts to find the credentials of all tasks, in addition
+// to the credentials of all processes. Please note that this requires scanning
+// tasks, see [FromTasks].
+func WithTaskCredentials() DiscoveryOption {
+	return func(o *DiscoverOpts) {
+		o.DiscoverCredentials = true
+		o.DiscoverTaskCredentials = true
+	}
+}
+
+// WithoutTaskCredentials opts out of finding the credentials of tasks.
+func WithoutTaskCredentials() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverTaskCredentials = false }
+}
+
+// WithLSMContexts opts to find the security contexts (labels) of all
+// processes, as assigned by Linux security modules such as AppArmor, SELinux,
+// and Smack.
+func WithLSMContexts() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverLSMContexts = true }
+}
+
+// WithoutLSMContexts opts out of finding the security contexts of processes.
+func WithoutLSMContexts() DiscoveryOption {
+	return func(o *DiscoverOpts) { o.DiscoverLSMContexts = false }
+}
+
+// WithConcurrency opts to scan the processes, their tasks, and open file
+// descriptors using up to n workers in parallel. The results are merged in a
+// deterministic order, so a concurrent discovery returns the same namespaces
+// with the same references as a sequential discovery. Setting n to less than
+// two scans sequentially, which is the default.
+func WithConcurrency(n int) DiscoveryOption {
+	return func(o *DiscoverOpts) { o.Concurrency = n }
+}
+
+// WithProcfsRoot opts to discover from the proc filesystem mounted at the
+// specified path instead of "/proc", such as a host's proc filesystem mounted
+// at "/host/proc" into a container. The namespace references in the discovery
+// result then are based on this path too. An empty path discovers from
+// "/proc", which is the default.
+func WithProcfsRoot(path string) DiscoveryOption {
+	return func(o *DiscoverOpts) { o.ProcfsRoot = strings.TrimSuffix(path, "/") }
+}
+
+// procfsRoot returns where the proc filesystem to discover from is mounted.
+func (o *DiscoverOpts) procfsRoot() string {
+	if o.ProcfsRoot == "" {
+		return "/proc"
+	}
+	return o.ProcfsRoot
+}
+
 // WithLabel adds a key-value pair to the discovery options.
 func WithLabel(key, value string) DiscoveryOption {
 	return func(o *DiscoverOpts) {
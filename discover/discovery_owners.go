@@ -32,7 +32,7 @@ import (
 // namespaces. We only run the resolution phase after we've discovered a
 // complete map of all user namespaces: only now we can resolve the owner
 // userspace ids to their corresponding user namespace objects.
-func resolveOwnership(nstype species.NamespaceType, _ string, result *Result) {
+func resolveOwnership(ctx context.Context, nstype species.NamespaceType, _ string, result *Result) {
 	if !result.Options.DiscoverOwnership || nstype == species.CLONE_NEWUSER {
 		if !result.Options.DiscoverOwnership {
 			slog.Info("skipping discovery of namespace ownerships",
@@ -42,7 +42,7 @@ func resolveOwnership(nstype species.NamespaceType, _ string, result *Result) {
 	}
 	slog.Debug("discovering namespace ownerships", slog.String("type", nstype.Name()))
 
-	debugEnabled := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
 	// The namespace type discovery sequence guarantees us that by the
 	// time we got here, the user namespaces already have been fully
 	// discovered, so we have a complete map of them.
@@ -0,0 +1,134 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nsioctl"
+	"github.com/thediveo/lxkns/species"
+)
+
+// pidfdNamespaceRequests maps namespace type indices to the pidfd ioctl
+// requests returning the namespace of a process, as well as the namespace for
+// its future children, where applicable.
+var pidfdNamespaceRequests = [model.NamespaceTypesCount][2]uint{
+	model.MountNS:  {nsioctl.PIDFD_GET_MNT_NAMESPACE},
+	model.CgroupNS: {nsioctl.PIDFD_GET_CGROUP_NAMESPACE},
+	model.UTSNS:    {nsioctl.PIDFD_GET_UTS_NAMESPACE},
+	model.IPCNS:    {nsioctl.PIDFD_GET_IPC_NAMESPACE},
+	model.UserNS:   {nsioctl.PIDFD_GET_USER_NAMESPACE},
+	model.PIDNS:    {nsioctl.PIDFD_GET_PID_NAMESPACE, nsioctl.PIDFD_GET_PID_FOR_CHILDREN_NAMESPACE},
+	model.NetNS:    {nsioctl.PIDFD_GET_NET_NAMESPACE},
+	model.TimeNS:   {nsioctl.PIDFD_GET_TIME_NAMESPACE, nsioctl.PIDFD_GET_TIME_FOR_CHILDREN_NAMESPACE},
+}
+
+// procfsInOwnPIDNamespace returns true if the proc filesystem mounted at procfs
+// belongs to the PID namespace of this process. Only then do the PIDs found in
+// procfs match the PIDs pidfds can be opened for.
+func procfsInOwnPIDNamespace(procfs string) bool {
+	self, err := os.Readlink(procfs + "/self")
+	return err == nil && self == strconv.Itoa(os.Getpid())
+}
+
+// lookupPidfdNamespaces looks up the namespace of the specified type of a
+// process via a pidfd, and optionally also the namespace for its future
+// children. The namespace lookup is only successful if the process still is
+// the same one as originally discovered, based on its PID and starttime. The
+// additional boolean return value is false if the kernel doesn't support
+// getting the namespaces of a process via pidfds, so the caller needs to fall
+// back onto looking up the namespaces via the nsref path instead.
+//
+// lookupPidfdNamespaces is safe to be called concurrently, as it doesn't touch
+// any discovery results.
+func lookupPidfdNamespaces(
+	flags determineNamespaceFlags,
+	proc *model.Process,
+	procfs string,
+	nsref string,
+	nstype species.NamespaceType,
+	forChildren bool,
+) (lookup procNamespaceLookup, ok bool) {
+	lookup.nsref = nsref
+	pidfd, err := unix.PidfdOpen(int(proc.PID), 0)
+	if err != nil {
+		if errors.Is(err, unix.ESRCH) {
+			lookup.ns.err = err
+			return lookup, true
+		}
+		return lookup, false
+	}
+	defer func() { _ = unix.Close(pidfd) }()
+
+	requests := pidfdNamespaceRequests[model.TypeIndex(nstype)]
+	lookup.ns, ok = lookupPidfdNamespace(flags, pidfd, requests[0], nstype)
+	if !ok {
+		return lookup, false
+	}
+	if forChildren && lookup.ns.err == nil && requests[1] != 0 {
+		lookup.forChildren, ok = lookupPidfdNamespace(flags|detForChildren, pidfd, requests[1], nstype)
+		if !ok {
+			return lookup, false
+		}
+	}
+	// We now need to make sure that the process referenced by our pidfd
+	// still is the one we've originally discovered: the pidfd might have been
+	// opened for a different process that reused the PID in the meantime. As
+	// pidfds can't tell us their starttime, we get it from procfs instead and
+	// then check that the pidfd's process is still alive. If it is, the PID
+	// in procfs must have referenced this same process.
+	if lookup.ns.err == nil {
+		starttime, err := model.StarttimeInProcfs(proc.PID, procfs)
+		if err != nil {
+			lookup.ns.err = err
+		} else if starttime != proc.Starttime ||
+			unix.PidfdSendSignal(pidfd, 0, nil, 0) != nil {
+			lookup.ns.err = fmt.Errorf("process identity changed, %w", unix.ESRCH)
+		}
+	}
+	return lookup, true
+}
+
+// lookupPidfdNamespace looks up a namespace of a process using the specified
+// pidfd ioctl request. The additional boolean return value is false if the
+// kernel doesn't support this ioctl request.
+func lookupPidfdNamespace(
+	flags determineNamespaceFlags,
+	pidfd int,
+	request uint,
+	nstype species.NamespaceType,
+) (lookup namespaceLookup, ok bool) {
+	// Please note that ioctl.RetFd would lose the errno, so we need to issue
+	// the ioctl syscall ourselves.
+	nsfd, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(pidfd), uintptr(request), 0)
+	if errno != 0 {
+		if errno == unix.ESRCH {
+			lookup.err = errno
+			return lookup, true
+		}
+		// ENOTTY on kernels without pidfd namespace ioctls, EOPNOTSUPP on
+		// kernels without support for this particular type of namespace.
+		return lookup, false
+	}
+	return lookupNamespaceFile(flags, os.NewFile(nsfd, "pidfd-namespace"), nstype), true
+}
@@ -32,10 +32,16 @@ package discover
 
 import (
 	"context"
+	"errors"
+	"io"
+	"io/fs"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 
+	"github.com/thediveo/lxkns/internal/fanout"
 	"github.com/thediveo/lxkns/internal/namespaces"
 	"github.com/thediveo/lxkns/model"
 	"github.com/thediveo/lxkns/ops"
@@ -46,7 +52,7 @@ import (
 // (including tasks when requested), using the namespace links inside the proc
 // filesystem: "/proc/[PID]/ns/...". It does not check any other places, as
 // these are covered by separate discovery functions.
-func discoverFromProc(nstype species.NamespaceType, _ string, result *Result) {
+func discoverFromProc(ctx context.Context, nstype species.NamespaceType, procfs string, result *Result) {
 	src := "processes"
 	if result.Options.ScanTasks {
 		src = "processes,tasks"
@@ -71,109 +77,151 @@ func discoverFromProc(nstype species.NamespaceType, _ string, result *Result) {
 	}
 	nsmap := result.Namespaces[nstypeidx]
 
-	debugEnabled := slog.Default().Enabled(context.Background(), slog.LevelDebug)
+	debugEnabled := slog.Default().Enabled(ctx, slog.LevelDebug)
+	workers := result.Options.Concurrency
 	total := 0
 	// For all processes (but not yet any tasks/threads) listed in /proc try to
 	// gather the namespaces of a given type they use.
 	//
 	// Kindly reminder: result.Processes is a map, so Go will happily iterate it
-	// in whatever random sequence it just takes a fancy of.
-	for pid, proc := range result.Processes {
-		// Discover the namespace instance of the specified type which this
-		// particular process has joined. Please note that namespace
-		// references for processes appear as symbolic(!) links in the /proc
-		// filesystem, but in fact are behaving like hard links. Nevertheless,
-		// we have to follow them like symbolic links in order to find the
-		// identifier in form of the inode # of the referenced namespace.
-		nsref := "/proc/" + strconv.Itoa(int(pid)) + "/ns/" + nstypename
-		foundns, isnew := determineNamespace(discoverOwnership,
+	// in whatever random sequence it just takes a fancy of. As we might look up
+	// the namespaces of processes in parallel, we first sort the PIDs and then
+	// later merge the lookup results in this order, so that the outcome of the
+	// discovery doesn't depend on the number of workers.
+	pids := slices.Sorted(maps.Keys(result.Processes))
+	// Discover the namespace instance of the specified type which a particular
+	// process has joined. Please note that namespace references for processes
+	// appear as symbolic(!) links in the /proc filesystem, but in fact are
+	// behaving like hard links. Nevertheless, we have to follow them like
+	// symbolic links in order to find the identifier in form of the inode # of
+	// the referenced namespace.
+	//
+	// Optionally, we look up the namespaces via pidfds, making sure that we
+	// don't attribute namespaces to the wrong process in case a PID gets
+	// reused while we're scanning.
+	usePidfds := result.Options.ScanPidfds && procfsInOwnPIDNamespace(procfs)
+	lookups := fanout.Map(ctx, workers, pids, func(pid model.PIDType) procNamespaceLookup {
+		nsref := procfs + "/" + strconv.Itoa(int(pid)) + "/ns/" + nstypename
+		if usePidfds {
+			if lookup, ok := lookupPidfdNamespaces(discoverOwnership,
+				result.Processes[pid], procfs, nsref, nstype, hasForChildrenRef); ok {
+				return lookup
+			}
+		}
+		return lookupProcNamespaces(discoverOwnership, nsref, nstype, hasForChildrenRef)
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	for idx, pid := range pids {
+		proc := result.Processes[pid]
+		lookup := &lookups[idx]
+		foundns, isnew := mergeNamespace(discoverOwnership,
 			&proc.ProTaskCommon,
-			nsref, nstype, nstypeidx, nsmap)
+			lookup.nsref, nstype, nstypeidx, nsmap, &lookup.ns)
 		if foundns == nil { // ...that didn't went well.
+			reportProcNamespaceIssue(result, nstype, pid, lookup.nsref, lookup.ns.err)
 			continue
 		}
 		if isnew {
 			if debugEnabled {
 				slog.Debug("found namespace from process",
 					slog.String("namespace", foundns.(model.NamespaceStringer).TypeIDString()),
-					slog.String("ref", nsref), slog.String("process", proc.Name))
+					slog.String("ref", lookup.nsref), slog.String("process", proc.Name))
 			}
 			total++
 		}
 		if !hasForChildrenRef {
 			continue
 		}
-		foundns, isnew = determineNamespace(detForChildren|discoverOwnership,
+		foundns, isnew = mergeNamespace(detForChildren|discoverOwnership,
 			&proc.ProTaskCommon,
-			nsref, nstype, nstypeidx,
-			nsmap)
+			lookup.nsref, nstype, nstypeidx,
+			nsmap, &lookup.forChildren)
 		if foundns == nil || !isnew {
 			continue
 		}
 		if debugEnabled {
 			slog.Debug("found namespace from process",
 				slog.String("namespace", foundns.(model.NamespaceStringer).TypeIDString()),
-				slog.String("ref", nsref), slog.String("process", proc.Name))
+				slog.String("ref", lookup.nsref), slog.String("process", proc.Name))
 		}
 		total++
 	}
 	determineLeaders(nstype, result)
 	// Try to set namespace references which we hope to be as long-lived as
 	// possible; so we prefer the most senior leader process: the ealdorman.
+	// As we're at it, we also pick up type-specific namespace details from
+	// the ealdorman.
 	for _, ns := range nsmap {
 		if ealdorman := ns.Ealdorman(); ealdorman != nil {
-			ref := "/proc/" + strconv.Itoa(int(ealdorman.PID)) + "/ns/" + nstypename
+			ref := procfs + "/" + strconv.Itoa(int(ealdorman.PID)) + "/ns/" + nstypename
 			ns.(namespaces.NamespaceConfigurer).SetRef(model.NamespaceRef{ref})
+			discoverNamespaceDetails(ns, procfs, &result.Options)
 		}
 	}
 	// Now scan tasks for yet unknown namespaces separately; this ensures that
 	// for the already known namespaces we have also already established
 	// process-based references that hopefully will be more stable than
-	// task-based references.
-	for _, proc := range result.Processes {
+	// task-based references. Please note that determineLeaders might have
+	// pruned some processes in the meantime.
+	var tasks []*model.Task
+	for _, pid := range pids {
+		if proc, ok := result.Processes[pid]; ok {
+			tasks = append(tasks, proc.Tasks...)
+		}
+	}
+	// tasks are actually also directly addressable in procfs using a TID
+	// instead of a PID. They just don't show up when reading the process
+	// directory.
+	tasklookups := fanout.Map(ctx, workers, tasks, func(task *model.Task) procNamespaceLookup {
+		nsref := procfs + "/" + strconv.Itoa(int(task.TID)) + "/ns/" + nstypename
+		return lookupProcNamespaces(discoverOwnership, nsref, nstype, hasForChildrenRef)
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	for idx, task := range tasks {
+		proc := task.Process
 		procns := proc.Namespaces[nstypeidx]
-		for _, task := range proc.Tasks {
-			// tasks are actually also directly addressable in procfs using a
-			// TID instead of a PID. They just don't show up when reading the
-			// process directory.
-			nsref := "/proc/" + strconv.Itoa(int(task.TID)) + "/ns/" + nstypename
-			newns, isnew := determineNamespace(detSetReference|discoverOwnership,
-				&task.ProTaskCommon,
-				nsref, nstype, nstypeidx, nsmap)
-			if newns == nil {
-				continue
-			}
-			if newns != procns {
-				// tsk, tsk ... we've got a stray task here...
-				newns.(namespaces.NamespaceConfigurer).AddLooseThread(task)
-			}
-			if isnew {
-				if debugEnabled {
-					slog.Debug("found namespace from task",
-						slog.String("namespace", newns.(model.NamespaceStringer).TypeIDString()),
-						slog.String("ref", nsref), slog.String("proc", proc.Name))
-				}
-				total++
-			}
-			if !hasForChildrenRef {
-				continue
-			}
-			newns, isnew = determineNamespace(detSetReference|detForChildren|discoverOwnership,
-				&task.ProTaskCommon,
-				nsref, nstype, nstypeidx, nsmap)
-			if newns == nil || !isnew {
-				continue
-			}
-			if newns != procns {
-				newns.(namespaces.NamespaceConfigurer).AddLooseThread(task)
-			}
+		lookup := &tasklookups[idx]
+		newns, isnew := mergeNamespace(detSetReference|discoverOwnership,
+			&task.ProTaskCommon,
+			lookup.nsref, nstype, nstypeidx, nsmap, &lookup.ns)
+		if newns == nil {
+			reportProcNamespaceIssue(result, nstype, task.TID, lookup.nsref, lookup.ns.err)
+			continue
+		}
+		if newns != procns {
+			// tsk, tsk ... we've got a stray task here...
+			newns.(namespaces.NamespaceConfigurer).AddLooseThread(task)
+		}
+		if isnew {
 			if debugEnabled {
 				slog.Debug("found namespace from task",
 					slog.String("namespace", newns.(model.NamespaceStringer).TypeIDString()),
-					slog.String("ref", nsref), slog.String("proc", proc.Name))
+					slog.String("ref", lookup.nsref), slog.String("proc", proc.Name))
 			}
 			total++
 		}
+		if !hasForChildrenRef {
+			continue
+		}
+		newns, isnew = mergeNamespace(detSetReference|detForChildren|discoverOwnership,
+			&task.ProTaskCommon,
+			lookup.nsref, nstype, nstypeidx, nsmap, &lookup.forChildren)
+		if newns == nil || !isnew {
+			continue
+		}
+		if newns != procns {
+			newns.(namespaces.NamespaceConfigurer).AddLooseThread(task)
+		}
+		if debugEnabled {
+			slog.Debug("found namespace from task",
+				slog.String("namespace", newns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("ref", lookup.nsref), slog.String("proc", proc.Name))
+		}
+		total++
 	}
 
 	slog.Info("found namespaces",
@@ -181,6 +229,18 @@ func discoverFromProc(nstype species.NamespaceType, _ string, result *Result) {
 		slog.Int("count", total))
 }
 
+// reportProcNamespaceIssue reports a failure to determine the namespace of the
+// specified type for the process or task with the specified PID/TID. As time
+// namespaces aren't supported by older kernels, it doesn't report missing time
+// namespace references; in all other cases, a missing namespace reference
+// indicates a process or task that has vanished in the meantime.
+func reportProcNamespaceIssue(result *Result, nstype species.NamespaceType, pid model.PIDType, nsref string, err error) {
+	if err == nil || (nstype == species.CLONE_NEWTIME && errors.Is(err, fs.ErrNotExist)) {
+		return
+	}
+	result.report(SourceProc, pid, species.NoneID, nsref, err)
+}
+
 type determineNamespaceFlags uint8
 
 const (
@@ -189,20 +249,50 @@ const (
 	detForChildren
 )
 
-// determineNamespace reads the details of the specified nsref namespace
-// reference that must be of the specified type. It then updates the
-// additionally specified namespace map. determineNamespace returns the
-// discovered namespace, or nil if it could not be determined. The additional
-// boolean is true if this is the first time this namespace is seen, otherwise
-// false.
-func determineNamespace(
+// namespaceLookup is the outcome of looking up the namespace of a process or
+// task, using its namespace reference in the /proc filesystem.
+type namespaceLookup struct {
+	nsid      species.NamespaceID // ID of the namespace referenced.
+	ownernsid species.NamespaceID // ID of the owning user namespace, if detected.
+	err       error               // reason in case the namespace couldn't be determined.
+}
+
+// procNamespaceLookup is the outcome of looking up the namespace of a process
+// or task, as well as optionally the namespace for its future children.
+type procNamespaceLookup struct {
+	nsref       string          // namespace reference (without "_for_children").
+	ns          namespaceLookup // namespace of process or task.
+	forChildren namespaceLookup // namespace for children, if applicable.
+}
+
+// lookupProcNamespaces looks up the namespace referenced by nsref, and
+// optionally also the namespace referenced by nsref's “_for_children” sibling.
+// lookupProcNamespaces is safe to be called concurrently, as it doesn't touch
+// any discovery results.
+func lookupProcNamespaces(
 	flags determineNamespaceFlags,
-	procOrTask *model.ProTaskCommon,
 	nsref string,
 	nstype species.NamespaceType,
-	nstypeidx model.NamespaceTypeIndex,
-	nsmap model.NamespaceMap,
-) (ns model.Namespace, firsttime bool) {
+	forChildren bool,
+) procNamespaceLookup {
+	lookup := procNamespaceLookup{
+		nsref: nsref,
+		ns:    lookupNamespace(flags, nsref, nstype),
+	}
+	if forChildren && lookup.ns.err == nil {
+		lookup.forChildren = lookupNamespace(flags|detForChildren, nsref, nstype)
+	}
+	return lookup
+}
+
+// lookupNamespace reads the details of the specified nsref namespace reference
+// that must be of the specified type. In case the namespace could not be
+// determined, the error returned gives the reason.
+func lookupNamespace(
+	flags determineNamespaceFlags,
+	nsref string,
+	nstype species.NamespaceType,
+) (lookup namespaceLookup) {
 	if flags&detForChildren != 0 {
 		nsref += "_for_children"
 	}
@@ -210,18 +300,64 @@ func determineNamespace(
 	// to discover ownership.
 	f, err := os.Open(nsref) // #nosec G304
 	if err != nil {
-		return nil, false
+		lookup.err = err
+		return
 	}
+	return lookupNamespaceFile(flags, f, nstype)
+}
+
+// lookupNamespaceFile reads the details of the namespace referenced by the
+// specified open file that must be of the specified type. lookupNamespaceFile
+// takes ownership of the file and closes it when done.
+func lookupNamespaceFile(
+	flags determineNamespaceFlags,
+	f *os.File,
+	nstype species.NamespaceType,
+) (lookup namespaceLookup) {
 	// Why not using a simple (typed) NamespacePath here? Because we want to
 	// carry out multiple query operations and avoid repeated opening and
 	// closing for each individual query on the same namespace.
 	nsf, _ := ops.NewTypedNamespaceFile(f, nstype)
 	defer func() { _ = nsf.Close() }() // ...we've taken over ownership of the *os.File as well!
-	nsid, err := nsf.ID()
-	if err != nil {
+	lookup.nsid, lookup.err = nsf.ID()
+	if lookup.err != nil {
+		return
+	}
+	// Let's also get the owning user namespace id, while we still have a
+	// suitable fd open. For user namespaces, we skip this step, as this
+	// is the same as the parent relationship. Additionally, it makes
+	// things too awkward in the model, because then we would need to
+	// treat ownership differently for non-user namespaces versus user
+	// namespaces all the time. Thus, sorry, no user namespaces here.
+	if flags&detDiscoverOwnership != 0 && nstype != species.CLONE_NEWUSER {
+		if usernsf, err := nsf.User(); err == nil {
+			lookup.ownernsid, _ = usernsf.ID()
+			_ = usernsf.(io.Closer).Close()
+		}
+	}
+	return
+}
+
+// mergeNamespace merges the result of a namespace lookup for a process or task
+// into the specified namespace map. mergeNamespace returns the namespace
+// looked up, or nil if it could not be determined. The additional boolean is
+// true if this is the first time this namespace is seen, otherwise false.
+func mergeNamespace(
+	flags determineNamespaceFlags,
+	procOrTask *model.ProTaskCommon,
+	nsref string,
+	nstype species.NamespaceType,
+	nstypeidx model.NamespaceTypeIndex,
+	nsmap model.NamespaceMap,
+	lookup *namespaceLookup,
+) (ns model.Namespace, firsttime bool) {
+	if lookup.err != nil || lookup.nsid == species.NoneID {
 		return nil, false
 	}
-	ns, existingNs := nsmap[nsid]
+	if flags&detForChildren != 0 {
+		nsref += "_for_children"
+	}
+	ns, existingNs := nsmap[lookup.nsid]
 	if !existingNs {
 		// Only add a namespace we haven't yet seen. And yes, we don't give
 		// a (file system-based) reference here, as we want to use a
@@ -232,8 +368,8 @@ func determineNamespace(
 		if flags&detSetReference != 0 {
 			ref = model.NamespaceRef{nsref}
 		}
-		ns = namespaces.New(nstype, nsid, ref)
-		nsmap[nsid] = ns
+		ns = namespaces.New(nstype, lookup.nsid, ref)
+		nsmap[lookup.nsid] = ns
 	}
 	// To speed up finding the process leaders in a specific namespace, we
 	// remember this namespace as joined by the process we're just looking at.
@@ -248,14 +384,8 @@ func determineNamespace(
 	if flags&detForChildren == 0 {
 		procOrTask.Namespaces[nstypeidx] = ns
 	}
-	// Let's also get the owning user namespace id, while we still have a
-	// suitable fd open. For user namespaces, we skip this step, as this
-	// is the same as the parent relationship. Additionally, it makes
-	// things too awkward in the model, because then we would need to
-	// treat ownership differently for non-user namespaces versus user
-	// namespaces all the time. Thus, sorry, no user namespaces here.
-	if flags&detDiscoverOwnership != 0 && nstype != species.CLONE_NEWUSER {
-		ns.(namespaces.NamespaceConfigurer).DetectOwner(nsf)
+	if lookup.ownernsid != species.NoneID {
+		ns.(namespaces.NamespaceConfigurer).SetOwner(lookup.ownernsid)
 	}
 	return ns, !existingNs
 }
@@ -0,0 +1,73 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/thediveo/lxkns/internal/fanout"
+	"github.com/thediveo/lxkns/model"
+)
+
+// discoverProcessDetails discovers the capability sets, the user and group
+// credentials (including seccomp modes and no_new_privs flags), and the
+// security contexts of all processes, as requested. Capability sets and
+// credentials optionally get discovered also for all tasks. As capability sets
+// and credentials both come from the same status file, it gets read only once
+// per process and task. Processes and tasks that vanished in the meantime
+// simply lack these details.
+func discoverProcessDetails(ctx context.Context, procfs string, result *Result) {
+	opts := &result.Options
+	var procdetails, taskdetails model.StatusDetails
+	if opts.DiscoverCapabilities {
+		procdetails |= model.StatusCapabilities
+		if opts.DiscoverTaskCapabilities {
+			taskdetails |= model.StatusCapabilities
+		}
+	}
+	if opts.DiscoverCredentials {
+		procdetails |= model.StatusCredentials
+		if opts.DiscoverTaskCredentials {
+			taskdetails |= model.StatusCredentials
+		}
+	}
+	if procdetails == 0 && !opts.DiscoverLSMContexts {
+		return
+	}
+	slog.Debug("discovering process details",
+		slog.Bool("capabilities", opts.DiscoverCapabilities),
+		slog.Bool("credentials", opts.DiscoverCredentials),
+		slog.Bool("lsm-contexts", opts.DiscoverLSMContexts),
+		slog.Bool("task-capabilities", taskdetails&model.StatusCapabilities != 0),
+		slog.Bool("task-credentials", taskdetails&model.StatusCredentials != 0))
+	fanout.Each(ctx, opts.Concurrency, slices.Collect(maps.Values(result.Processes)),
+		func(proc *model.Process) {
+			_ = proc.RetrieveStatus(procfs, procdetails)
+			if opts.DiscoverLSMContexts {
+				_ = proc.RetrieveLSMContexts(procfs)
+			}
+			if taskdetails == 0 {
+				return
+			}
+			for _, task := range proc.Tasks {
+				_ = task.RetrieveStatus(procfs, taskdetails)
+			}
+		})
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"os"
+	"time"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Discover process details", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("doesn't discover capabilities unless asked to", func() {
+		allns := Namespaces(FromProcs(), FromTasks())
+		proc := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Capabilities).To(BeNil())
+	})
+
+	It("discovers capabilities of processes", func() {
+		allns := Namespaces(FromProcs(), FromTasks(), WithCapabilities(), WithConcurrency(4))
+		proc := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Capabilities).NotTo(BeNil())
+		Expect(proc.Capabilities.Bounding).NotTo(BeEmpty())
+		if os.Geteuid() == 0 {
+			Expect(proc.Capabilities.HasEffective("CAP_SYS_ADMIN")).To(BeTrue())
+		}
+		Expect(proc.Tasks).To(HaveEach(HaveField("Capabilities", BeNil())))
+	})
+
+	It("discovers capabilities of tasks", func() {
+		allns := Namespaces(FromProcs(), FromTasks(), WithTaskCapabilities())
+		proc := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Capabilities).NotTo(BeNil())
+		Expect(proc.Tasks).NotTo(BeEmpty())
+		// Threads locked to namespace switches might have already vanished in
+		// the meantime, so we only check the main thread.
+		Expect(proc.Tasks).To(ContainElement(And(
+			HaveField("TID", model.PIDType(os.Getpid())),
+			HaveField("Capabilities", Not(BeNil())))))
+	})
+
+	It("doesn't discover credentials unless asked to", func() {
+		allns := Namespaces(FromProcs(), FromTasks())
+		proc := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Credentials).To(BeNil())
+	})
+
+	It("discovers credentials of processes", func() {
+		allns := Namespaces(FromProcs(), FromTasks(), WithCredentials(), WithConcurrency(4))
+		proc := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Credentials).NotTo(BeNil())
+		Expect(proc.Credentials.UIDs.Effective).To(Equal(uint32(os.Geteuid())))
+		Expect(proc.Tasks).To(HaveEach(HaveField("Credentials", BeNil())))
+	})
+
+	It("discovers credentials of tasks", func() {
+		allns := Namespaces(FromProcs(), FromTasks(), WithTaskCredentials())
+		proc := allns.Processes[model.PIDType(os.Getpid())]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Credentials).NotTo(BeNil())
+		// Threads locked to namespace switches might have already vanished in
+		// the meantime, so we only check the main thread.
+		Expect(proc.Tasks).To(ContainElement(And(
+			HaveField("TID", model.PIDType(os.Getpid())),
+			HaveField("Credentials.UIDs", Equal(proc.Credentials.UIDs)))))
+	})
+
+	It("discovers LSM contexts of processes only when asked to", func() {
+		mypid := model.PIDType(os.Getpid())
+		expected := Successful(model.ReadLSMContexts("/proc", mypid))
+
+		allns := Namespaces(FromProcs())
+		Expect(allns.Processes[mypid].LSMContexts).To(BeNil())
+
+		allns = Namespaces(FromProcs(), WithLSMContexts(), WithConcurrency(4))
+		Expect(allns.Processes[mypid].LSMContexts).To(Equal(expected))
+	})
+
+	It("discovers capabilities, credentials, and LSM contexts together", func() {
+		mypid := model.PIDType(os.Getpid())
+		allns := Namespaces(FromProcs(), FromTasks(),
+			WithTaskCapabilities(), WithCredentials(), WithLSMContexts())
+		proc := allns.Processes[mypid]
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Capabilities).NotTo(BeNil())
+		Expect(proc.Credentials).NotTo(BeNil())
+		Expect(proc.LSMContexts).To(Equal(Successful(model.ReadLSMContexts("/proc", mypid))))
+		Expect(proc.Tasks).To(ContainElement(And(
+			HaveField("TID", mypid),
+			HaveField("Capabilities", Not(BeNil())),
+			HaveField("Credentials", BeNil()))))
+	})
+
+})
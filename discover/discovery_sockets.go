@@ -0,0 +1,177 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/internal/sockdiag"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+)
+
+// discoverSockets discovers the TCP, UDP, UNIX domain, and packet sockets of
+// the discovered network namespaces, relating them to the processes having
+// them open. API users must have opted in to this discovery step, as well as
+// to the discovery of network namespaces. Finally, discoverSockets relates the
+// connected UNIX domain sockets across network namespaces in a graph.
+//
+// As socket diagnostics netlink sockets only report the sockets of the
+// network namespace they were created in, we need to briefly switch into each
+// network namespace.
+func discoverSockets(ctx context.Context, _ species.NamespaceType, _ string, result *Result) {
+	if !result.Options.DiscoverSockets {
+		slog.Info("skipping discovery of sockets", slog.String("src", "sock_diag"))
+		return
+	}
+	if result.Options.NamespaceTypes&species.CLONE_NEWNET == 0 {
+		slog.Warn("network namespace discovery skipped, so skipping sockets discovery")
+		return
+	}
+	slog.Debug("discovering sockets", slog.String("src", "sock_diag"))
+	socktotal := 0
+	for netnsid, netns := range result.Namespaces[model.NetNS] {
+		if ctx.Err() != nil {
+			return
+		}
+		// Network namespaces bind-mounted into other mount namespaces need
+		// to be reached through these mount namespaces.
+		ref, closer, err := resolveNamespaceRef(netns.Ref(), result)
+		if err != nil {
+			slog.Warn("cannot open bind-mounted network namespace",
+				slog.String("namespace", netns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("ref", netns.Ref().String()),
+				slog.String("err", err.Error()))
+			result.report(SourceSockets, 0, netnsid, netns.Ref().String(), err)
+			continue
+		}
+		socks, err := sockets(ref)
+		closer()
+		if err != nil {
+			slog.Warn("cannot read sockets",
+				slog.String("namespace", netns.(model.NamespaceStringer).TypeIDString()),
+				slog.String("err", err.Error()))
+			result.report(SourceSockets, 0, netnsid, ref, err)
+			continue
+		}
+		socktotal += len(socks)
+		netns.(namespaces.NetConfigurer).SetSockets(newSockets(socks, result.SocketProcessMap))
+	}
+	result.UnixSocketGraph = model.NewUnixSocketGraph(result.Namespaces[model.NetNS])
+	slog.Info("found sockets",
+		slog.Int("namespace_count", len(result.Namespaces[model.NetNS])),
+		slog.Int("count", socktotal))
+}
+
+// sockets returns the sockets of the network namespace referenced by the
+// specified path. Socket families and protocols not supported by the kernel
+// are silently skipped.
+func sockets(ref string) ([]sockdiag.Socket, error) {
+	type socketsOrError struct {
+		socks []sockdiag.Socket
+		err   error
+	}
+	res, err := ops.Execute(func() (res socketsOrError) {
+		conn, err := sockdiag.Dial()
+		if err != nil {
+			res.err = err
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		dumps := []func() ([]sockdiag.Socket, error){conn.UnixSockets, conn.PacketSockets}
+		for _, family := range []uint8{unix.AF_INET, unix.AF_INET6} {
+			for _, protocol := range []uint8{unix.IPPROTO_TCP, unix.IPPROTO_UDP} {
+				dumps = append(dumps, func() ([]sockdiag.Socket, error) {
+					return conn.InetSockets(family, protocol)
+				})
+			}
+		}
+		for _, dump := range dumps {
+			socks, err := dump()
+			if err != nil {
+				// the kernel reports ENOENT in case the diagnostics module
+				// for a particular socket family or protocol isn't
+				// available.
+				if errors.Is(err, unix.ENOENT) {
+					continue
+				}
+				res.err = err
+				return
+			}
+			res.socks = append(res.socks, socks...)
+		}
+		return
+	}, ops.NewTypedNamespacePath(ref, species.CLONE_NEWNET))
+	if err != nil {
+		return nil, err
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+	return res.socks, nil
+}
+
+// newSockets returns the sockets for the specified socket diagnostics,
+// relating them to the processes having them open, and sorted by protocol and
+// inode number.
+func newSockets(socks []sockdiag.Socket, sockprocs SocketProcesses) []model.Socket {
+	sockets := make([]model.Socket, 0, len(socks))
+	for _, sock := range socks {
+		socket := model.Socket{
+			Inode: uint64(sock.Inode),
+			PIDs:  sockprocs[uint64(sock.Inode)],
+		}
+		switch sock.Family {
+		case unix.AF_INET, unix.AF_INET6:
+			socket.Protocol = model.SocketProtocolTCP
+			if sock.Protocol == unix.IPPROTO_UDP {
+				socket.Protocol = model.SocketProtocolUDP
+			}
+			socket.State = model.SocketStateFromKernel(sock.State)
+			socket.Local = sock.Local
+			socket.Remote = sock.Remote
+		case unix.AF_UNIX:
+			socket.Protocol = model.SocketProtocolUNIX
+			socket.Type = model.SocketTypeFromKernel(sock.Type)
+			socket.State = model.SocketStateFromKernel(sock.State)
+			socket.Path = sock.Path
+			socket.Peer = uint64(sock.Peer)
+			socket.VFSInode = sock.VFSInode
+			socket.VFSDev = sock.VFSDev
+		case unix.AF_PACKET:
+			socket.Protocol = model.SocketProtocolPacket
+			socket.Type = model.SocketTypeFromKernel(sock.Type)
+			socket.Interface = sock.Ifindex
+			socket.EtherType = sock.EtherType
+		default:
+			continue
+		}
+		sockets = append(sockets, socket)
+	}
+	slices.SortFunc(sockets, func(a, b model.Socket) int {
+		return cmp.Or(cmp.Compare(a.Protocol, b.Protocol), cmp.Compare(a.Inode, b.Inode))
+	})
+	return sockets
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thediveo/testbasher"
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nstest"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Discover sockets", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("doesn't discover sockets unless asked to", func() {
+		allns := Namespaces(FromProcs())
+		for _, netns := range allns.Namespaces[model.NetNS] {
+			Expect(netns.(model.SocketInventory).Sockets()).To(BeNil())
+		}
+	})
+
+	It("discovers sockets and the processes having them open", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		tcp := Successful(net.Listen("tcp4", "127.0.0.1:0"))
+		defer func() { _ = tcp.Close() }()
+		sockpath := filepath.Join(GinkgoT().TempDir(), "lxkns.sock")
+		unixl := Successful(net.Listen("unix", sockpath))
+		defer func() { _ = unixl.Close() }()
+
+		allns := Namespaces(FromProcs(), WithSockets())
+		Expect(allns.Options.DiscoverSocketProcesses).To(BeTrue())
+		netnsid := Successful(ops.NamespacePath("/proc/self/ns/net").ID())
+		netns := allns.Namespaces[model.NetNS][netnsid]
+		Expect(netns).NotTo(BeNil())
+		socks := netns.(model.SocketInventory).Sockets()
+		Expect(socks).To(ContainElement(And(
+			HaveField("Protocol", model.SocketProtocolTCP),
+			HaveField("State", model.SocketStateListen),
+			HaveField("Local", netip.MustParseAddrPort(tcp.Addr().String())),
+			HaveField("PIDs", ContainElement(model.PIDType(os.Getpid()))),
+		)))
+		Expect(socks).To(ContainElement(And(
+			HaveField("Protocol", model.SocketProtocolUNIX),
+			HaveField("Type", model.SocketTypeStream),
+			HaveField("State", model.SocketStateListen),
+			HaveField("Path", sockpath),
+			HaveField("PIDs", ContainElement(model.PIDType(os.Getpid()))),
+		)))
+	})
+
+	It("discovers sockets of bind-mounted network namespaces", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -m --propagation private $stage2
+`)
+		// The bind-mounted network namespace is only reachable through the
+		// transient mount namespace, as no process is attached to it.
+		bmpath := filepath.Join(GinkgoT().TempDir(), "netns")
+		scripts.Script("stage2", fmt.Sprintf(`
+touch %[1]s
+unshare --net=%[1]s true
+echo $$
+namespaceid %[1]s # prints the ID of the bind-mounted network namespace.
+read # wait for test to proceed()
+`, bmpath))
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		var pid int
+		cmd.Decode(&pid)
+		netnsid := nstest.CmdDecodeNSId(cmd)
+
+		// Our listening socket will belong to the bind-mounted network
+		// namespace, but we don't.
+		sockpath := filepath.Join(GinkgoT().TempDir(), "lxkns.sock")
+		unixl := Successful(ops.Execute(func() net.Listener {
+			return Successful(net.Listen("unix", sockpath))
+		}, ops.NewTypedNamespacePath(fmt.Sprintf("/proc/%d/root%s", pid, bmpath), species.CLONE_NEWNET)))
+		defer func() { _ = unixl.Close() }()
+
+		allns := Namespaces(FromProcs(), FromBindmounts(), WithSockets())
+		netns := allns.Namespaces[model.NetNS][netnsid]
+		Expect(netns).NotTo(BeNil())
+		Expect(netns.Ref()).To(HaveLen(2))
+		Expect(netns.(model.SocketInventory).Sockets()).To(ContainElement(And(
+			HaveField("Protocol", model.SocketProtocolUNIX),
+			HaveField("Path", sockpath),
+			HaveField("PIDs", ContainElement(model.PIDType(os.Getpid()))),
+		)))
+	})
+
+	It("relates connected UNIX domain sockets", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		sockpath := filepath.Join(GinkgoT().TempDir(), "lxkns.sock")
+		unixl := Successful(net.Listen("unix", sockpath))
+		defer func() { _ = unixl.Close() }()
+		unixc := Successful(net.Dial("unix", sockpath))
+		defer func() { _ = unixc.Close() }()
+		unixs := Successful(unixl.Accept())
+		defer func() { _ = unixs.Close() }()
+		var st unix.Stat_t
+		Expect(unix.Stat(sockpath, &st)).To(Succeed())
+
+		allns := Namespaces(FromProcs(), WithSockets())
+		netnsid := Successful(ops.NamespacePath("/proc/self/ns/net").ID())
+		clients := allns.UnixSocketGraph.Clients(st.Dev, st.Ino)
+		Expect(clients).To(ConsistOf(And(
+			HaveField("NetNS", netnsid),
+			HaveField("Socket.PIDs", ContainElement(model.PIDType(os.Getpid()))),
+		)))
+		peer, ok := allns.UnixSocketGraph.Peer(clients[0].Inode)
+		Expect(ok).To(BeTrue())
+		Expect(peer.Path).To(Equal(sockpath))
+	})
+
+})
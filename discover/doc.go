@@ -38,6 +38,41 @@ It accepts option setters, such as [discover.StandardDiscovery],
 	    ...
 	}
 
+In order to limit how long a discovery might take on hosts with lots of
+processes, use [discover.NamespacesContext] instead and pass it a context with
+a deadline. Either way, problems encountered during a discovery, such as
+missing privileges or processes that vanished while being scanned, are reported
+in the Errors and Warnings fields of the discovery [discover.Result].
+
+In order to find out what has changed between two discoveries, such as when
+periodically discovering namespaces, pass both discovery results to
+[discover.Diff]. It returns the namespaces, processes, containers, container
+engines, and mount points that have been added, removed, or changed.
+
+Instead of repeatedly discovering from scratch, a [discover.Watcher] starts
+from a full discovery and then keeps its discovery result current based on
+process fork, exec, and exit events from the kernel, emitting the changes as
+they happen. As a safety net, a Watcher additionally runs a full discovery
+periodically.
+
+Namespaces are discovered from multiple sources, such as the processes, their
+open file descriptors, and bind-mounts. Additional namespace sources can be
+plugged in by registering a [discover.Discoverer] with the
+plugger.Group[discover.Discoverer] plugin group.
+
+With [discover.FromNsfs], namespaces are additionally listed directly from the
+kernel, finding even those namespaces that are neither referenced by any
+process, nor open file descriptor, nor bind-mount, such as user namespaces only
+kept alive by their child namespaces. Such namespaces are then referenced using
+nsfs file handles, which the ops package knows how to open.
+
+As processes come and go while being scanned, a PID might get reused in the
+middle of a discovery, so that namespaces could get attributed to the wrong
+process. On kernels 6.11 and later, [discover.WithPidfds] avoids this by
+looking up the namespaces of processes via pidfds and checking that each process
+still is the one originally seen. On older kernels, discovery falls back to the
+paths in the proc filesystem.
+
 Please also have a look at our manual's [Discovering Namespaces UML diagrams].
 
 # Basics of the lxkns Information Model
@@ -0,0 +1,37 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	iidmap "github.com/thediveo/lxkns/internal/idmap"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+)
+
+// NewIDMap returns a new ID map ([model.IDMapper]) for translating user and
+// group IDs between the user namespaces of the specified discovery results.
+// Only user namespaces with ID mappings, that is, user namespaces with leader
+// processes, can be translated from and to.
+func NewIDMap(result *Result) model.IDMapper {
+	// The ID mappings we've read are relative to our own user namespace, so
+	// we need to know it.
+	var readerns model.Namespace
+	if usernsid, err := ops.NamespacePath("/proc/self/ns/user").ID(); err == nil {
+		readerns = result.Namespaces[model.UserNS][usernsid]
+	}
+	return iidmap.NewIDMapInProcfs(readerns, result.Options.procfsRoot())
+}
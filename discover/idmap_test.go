@@ -0,0 +1,80 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/thediveo/testbasher"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/nstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("ID maps", func() {
+
+	BeforeEach(func() {
+		DeferCleanup(slog.SetDefault, slog.Default())
+		slog.SetDefault(slog.New(slog.NewTextHandler(GinkgoWriter, &slog.HandlerOptions{})))
+
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("translates UIDs and GIDs", func() {
+		scripts := testbasher.Basher{}
+		defer scripts.Done()
+		scripts.Common(nstest.NamespaceUtilsScript)
+		scripts.Script("main", `
+unshare -Ur $stage2 # create a user ns mapping only root.
+`)
+		scripts.Script("stage2", `
+process_namespaceid user # prints the user namespace ID of "the" process.
+read # wait for test to proceed()
+`)
+		cmd := scripts.Start("main")
+		defer cmd.Close()
+		usernsid := nstest.CmdDecodeNSId(cmd)
+
+		allns := Namespaces(WithStandardDiscovery())
+		Expect(allns.IDMap).NotTo(BeNil())
+		userns := allns.Namespaces[model.UserNS][usernsid]
+		Expect(userns).NotTo(BeNil())
+		parentns := userns.(model.Hierarchy).Parent().(model.Namespace)
+
+		uid, ok := allns.IDMap.Translate(0, model.UserIDs, userns, parentns)
+		Expect(ok).To(BeTrue())
+		Expect(uid).To(Equal(uint32(os.Geteuid()))) // #nosec G115
+
+		gid, ok := allns.IDMap.Translate(uint32(os.Getegid()), model.GroupIDs, parentns, userns) // #nosec G115
+		Expect(ok).To(BeTrue())
+		Expect(gid).To(BeZero())
+
+		_, ok = allns.IDMap.Translate(1, model.UserIDs, userns, parentns)
+		Expect(ok).To(BeFalse())
+		Expect(allns.IDMap.TranslateMunged(1, model.UserIDs, userns, parentns)).NotTo(BeZero())
+	})
+
+})
@@ -0,0 +1,109 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"errors"
+	"io/fs"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+)
+
+// IssueSource identifies the discovery step that ran into an [Issue].
+type IssueSource string
+
+// The discovery steps that might report issues.
+const (
+	SourceProc       IssueSource = "proc"        // scanning processes and tasks for their namespaces.
+	SourceFd         IssueSource = "fd"          // scanning open file descriptors of processes.
+	SourceBindmounts IssueSource = "bind-mounts" // scanning mount namespaces for bind-mounted namespaces.
+	SourceMountinfo  IssueSource = "mountinfo"   // reading mount points of mount namespaces.
+	SourceNsfs       IssueSource = "nsfs"        // listing namespaces and opening them via nsfs file handles.
+	SourceIPC        IssueSource = "ipc"         // listing the IPC objects of IPC namespaces.
+	SourceNetif      IssueSource = "netif"       // listing the network interfaces of network namespaces.
+	SourceSockets    IssueSource = "sockets"     // listing the sockets of network namespaces.
+	SourceContainers IssueSource = "containers"  // discovering containers and their engines.
+)
+
+// Issue describes a problem encountered during a discovery, such as a process
+// that vanished while being scanned or a mount namespace that could not be
+// entered due to missing privileges. Depending on the information available,
+// an Issue names the process (PID), namespace and path involved.
+type Issue struct {
+	Source    IssueSource         // discovery step that ran into this issue.
+	PID       model.PIDType       // PID of the process involved, or zero.
+	Namespace species.NamespaceID // ID of the namespace involved, or species.NoneID.
+	Path      string              // path involved, if any.
+	Errno     syscall.Errno       // OS-level error number, or zero if not available.
+	Err       error               // the underlying error.
+}
+
+// Error returns a textual description of this issue.
+func (i Issue) Error() string {
+	var s strings.Builder
+	s.WriteString(string(i.Source))
+	if i.PID != 0 {
+		s.WriteString(", PID ")
+		s.WriteString(strconv.FormatInt(int64(i.PID), 10))
+	}
+	if i.Namespace != species.NoneID {
+		s.WriteString(", namespace ")
+		s.WriteString(strconv.FormatUint(i.Namespace.Ino, 10))
+	}
+	if i.Path != "" {
+		s.WriteString(", ")
+		s.WriteString(i.Path)
+	}
+	if i.Err != nil {
+		s.WriteString(": ")
+		s.WriteString(i.Err.Error())
+	}
+	return s.String()
+}
+
+// Unwrap returns the underlying error.
+func (i Issue) Unwrap() error { return i.Err }
+
+// IsVanished returns true if the issue was caused by something not being there
+// (anymore), such as a process that terminated during discovery. Such issues
+// are reported as warnings, while all other issues are reported as errors.
+func (i Issue) IsVanished() bool {
+	return errors.Is(i.Err, fs.ErrNotExist) || i.Errno == syscall.ESRCH
+}
+
+// report records an issue with the discovery result, sorting it either into the
+// warnings in case of something that isn't there (anymore), or into the errors
+// otherwise.
+func (r *Result) report(src IssueSource, pid model.PIDType, nsid species.NamespaceID, path string, err error) {
+	issue := Issue{
+		Source:    src,
+		PID:       pid,
+		Namespace: nsid,
+		Path:      path,
+		Err:       err,
+	}
+	_ = errors.As(err, &issue.Errno)
+	if issue.IsVanished() {
+		r.Warnings = append(r.Warnings, issue)
+		return
+	}
+	r.Errors = append(r.Errors, issue)
+}
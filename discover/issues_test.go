@@ -0,0 +1,84 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("discovery issues", func() {
+
+	It("sorts issues into errors and warnings", func() {
+		r := Result{}
+		r.report(SourceProc, 42, species.NoneID, "/proc/42/ns/net",
+			&os.PathError{Op: "open", Path: "/proc/42/ns/net", Err: syscall.ENOENT})
+		r.report(SourceFd, 666, species.NoneID, "/proc/666/fd",
+			&os.PathError{Op: "open", Path: "/proc/666/fd", Err: syscall.EACCES})
+		r.report(SourceFd, 123, species.NoneID, "/proc/123/fd/1", syscall.ESRCH)
+
+		Expect(r.Warnings).To(ConsistOf(
+			And(
+				HaveField("Source", SourceProc),
+				HaveField("PID", model.PIDType(42)),
+				HaveField("Errno", syscall.ENOENT)),
+			And(
+				HaveField("Source", SourceFd),
+				HaveField("PID", model.PIDType(123)),
+				HaveField("Errno", syscall.ESRCH)),
+		))
+		Expect(r.Errors).To(ConsistOf(
+			And(
+				HaveField("Source", SourceFd),
+				HaveField("PID", model.PIDType(666)),
+				HaveField("Errno", syscall.EACCES)),
+		))
+		Expect(r.Errors[0]).To(MatchError(syscall.EACCES))
+	})
+
+	It("describes issues", func() {
+		issue := Issue{
+			Source:    SourceMountinfo,
+			PID:       42,
+			Namespace: species.NamespaceID{Dev: 1, Ino: 4026531841},
+			Path:      "/proc/42/ns/mnt",
+			Err:       syscall.EPERM,
+		}
+		Expect(issue.Error()).To(Equal(fmt.Sprintf(
+			"mountinfo, PID 42, namespace 4026531841, /proc/42/ns/mnt: %s", syscall.EPERM.Error())))
+		Expect(Issue{Source: SourceContainers}.Error()).To(Equal("containers"))
+	})
+
+	It("aborts a cancelled discovery", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result, err := NamespacesContext(ctx, WithStandardDiscovery())
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(result).NotTo(BeNil())
+		Expect(result.Processes).To(BeEmpty())
+		for _, nsmap := range result.Namespaces {
+			Expect(nsmap).To(BeEmpty())
+		}
+	})
+
+})
@@ -22,7 +22,8 @@ import (
 )
 
 // NewPIDMap returns a new PID map ([model.PIDMapper]) based on the specified
-// discovery results and further information gathered from the /proc filesystem.
+// discovery results and further information gathered from the proc filesystem
+// the discovery used.
 func NewPIDMap(result *Result) model.PIDMapper {
-	return ipidmap.NewPIDMap(result.Processes)
+	return ipidmap.NewPIDMapInProcfs(result.Processes, result.Options.procfsRoot())
 }
@@ -24,11 +24,17 @@ import (
 	"github.com/thediveo/lxkns/model"
 )
 
-const procPrefix = "/proc/"
-
 // PIDfromPath returns the PID embedded in a /proc/$PID/... path, or 0 if the
 // path doesn't contain a /proc/$PID.
 func PIDfromPath(path string) model.PIDType {
+	return pidFromProcfsPath("/proc", path)
+}
+
+// pidFromProcfsPath returns the PID embedded in a $PROCFS/$PID/... path, where
+// $PROCFS is the specified path of a proc filesystem, or 0 if the path doesn't
+// contain a $PROCFS/$PID.
+func pidFromProcfsPath(procfs, path string) model.PIDType {
+	procPrefix := procfs + "/"
 	if !strings.HasPrefix(path, procPrefix) {
 		return 0
 	}
@@ -54,6 +60,12 @@ func PIDfromPath(path string) model.PIDType {
 // NewlyProcfsPathIsBetter returns false; in particular, when one of the
 // namespace references isn't from a process.
 func NewlyProcfsPathIsBetter(newly, known model.NamespaceRef, processes model.ProcessTable) bool {
+	return newlyProcfsPathIsBetter("/proc", newly, known, processes)
+}
+
+// newlyProcfsPathIsBetter implements [NewlyProcfsPathIsBetter] for references
+// into the proc filesystem mounted at the specified procfs path.
+func newlyProcfsPathIsBetter(procfs string, newly, known model.NamespaceRef, processes model.ProcessTable) bool {
 	// We never consider replacing an already known reference when the newly one
 	// doesn't even is of the same length.
 	if l := len(newly); l < 2 || l != len(known) {
@@ -61,8 +73,8 @@ func NewlyProcfsPathIsBetter(newly, known model.NamespaceRef, processes model.Pr
 	}
 	// And we'll only consider references any further if both have their first
 	// reference elements to be for processes.
-	newpid := PIDfromPath(newly[0])
-	knownpid := PIDfromPath(known[0])
+	newpid := pidFromProcfsPath(procfs, newly[0])
+	knownpid := pidFromProcfsPath(procfs, known[0])
 	if newpid == 0 || knownpid == 0 {
 		return false
 	}
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/thediveo/lxkns/internal/namespaces"
 	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -82,6 +84,21 @@ var _ = Describe("procfs references", func() {
 		Entry(nil, r("/proc/777", "/abc"), r("/proc/42", "/abc"), false),
 	)
 
+	DescribeTable("resolving process names in references",
+		func(ref, procfs, expected string) {
+			mntns := namespaces.New(species.CLONE_NEWNS, species.NamespaceIDfromInode(1), r(ref))
+			result := &Result{Processes: model.ProcessTable{
+				42: {ProTaskCommon: model.ProTaskCommon{Name: "foo"}},
+			}}
+			Expect(refString(mntns, procfs, result)).To(Equal(expected))
+		},
+		EntryDescription("%[1]s in %[2]s -> %[3]s"),
+		Entry(nil, "/proc/42/ns/mnt", "/proc", "/proc/42/ns/mnt[=foo]»/proc/42/ns/mnt"),
+		Entry(nil, "/host/proc/42/ns/mnt", "/host/proc", "/host/proc/42/ns/mnt[=foo]»/host/proc/42/ns/mnt"),
+		Entry(nil, "/host/proc/42/ns/mnt", "/proc", "/host/proc/42/ns/mnt"),
+		Entry(nil, "/proc/666/ns/mnt", "/proc", "/proc/666/ns/mnt"),
+	)
+
 })
 
 func r(elements ...string) (ref model.NamespaceRef) {
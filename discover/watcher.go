@@ -0,0 +1,928 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package discover
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thediveo/lxkns/internal/cnproc"
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/mounts"
+	"github.com/thediveo/lxkns/ops"
+	"github.com/thediveo/lxkns/species"
+)
+
+// DefaultResyncInterval is the interval between full rediscoveries of a
+// [Watcher] when not specifying an interval explicitly.
+const DefaultResyncInterval = 5 * time.Minute
+
+// WatchEvent tells about the changes a [Watcher] has applied to its discovery
+// result. For changes stemming from process events, the Watcher updates
+// namespaces in place, so the old namespaces of changed namespaces are
+// snapshots taken before applying the process events.
+type WatchEvent struct {
+	Diff   *ResultDiff // the changes applied.
+	Resync bool        // true if the changes stem from a full rediscovery.
+}
+
+// Watcher keeps a discovery result current by watching for process fork, exec,
+// and exit events, and then re-checking only the namespaces of the affected
+// processes (and tasks, if enabled). A Watcher updates the namespace leaders,
+// loose threads, and the user and PID namespace hierarchies in place, and
+// emits the changes as [WatchEvent] values.
+//
+// Process events only tell about processes, so a Watcher periodically runs a
+// full rediscovery as a safety net, picking up changes that process events
+// cannot tell about, such as bind-mounted namespaces, namespaces kept alive by
+// open file descriptors, mount points, control groups, containers, and the
+// PID map. Similarly, a Watcher immediately runs a full rediscovery when the
+// kernel had to drop process events.
+//
+// A Watcher requires the CAP_NET_ADMIN capability in order to subscribe to
+// process events. As the kernel tells only subscribers in the initial PID
+// namespace about process events, and then in terms of PIDs in the initial PID
+// namespace, a Watcher outside the initial PID namespace or discovering from
+// the proc filesystem of a different PID namespace falls back to only running
+// full rediscoveries at its resync interval.
+type Watcher struct {
+	resync time.Duration
+	events chan WatchEvent
+	conn   *cnproc.Conn
+
+	mu     sync.RWMutex
+	result *Result
+	err    error
+}
+
+// NewWatcher returns a new [Watcher] that starts with a full discovery using
+// the specified discovery options and then keeps the discovery result current
+// until the specified context gets cancelled. The Watcher does a full
+// rediscovery at the specified resync interval; if zero, it uses
+// [DefaultResyncInterval].
+//
+// The caller must receive the [WatchEvent] values from [Watcher.Events] until
+// the channel gets closed, as otherwise the Watcher will block.
+func NewWatcher(ctx context.Context, resync time.Duration, options ...DiscoveryOption) (*Watcher, error) {
+	if resync <= 0 {
+		resync = DefaultResyncInterval
+	}
+	// Subscribe before the initial discovery, so we don't miss any process
+	// events in between; events for processes already discovered are harmless.
+	var conn *cnproc.Conn
+	if opts := newDiscoverOpts(options...); inInitialPIDNamespace(opts.procfsRoot()) {
+		var err error
+		conn, err = cnproc.Dial()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		slog.Warn("not in initial PID namespace, watching only by rediscovering",
+			slog.Duration("interval", resync))
+	}
+	result, err := NamespacesContext(ctx, options...)
+	if err != nil {
+		if conn != nil {
+			_ = conn.Close()
+		}
+		return nil, err
+	}
+	w := &Watcher{
+		resync: resync,
+		events: make(chan WatchEvent),
+		conn:   conn,
+		result: result,
+	}
+	go w.watch(ctx)
+	return w, nil
+}
+
+// initialPIDNamespaceIno is the inode number the kernel assigns to the initial
+// PID namespace (PROC_PID_INIT_INO).
+const initialPIDNamespaceIno = 0xeffffffc
+
+// inInitialPIDNamespace returns true if the calling process is in the initial
+// PID namespace, and if the specified proc filesystem belongs to the initial
+// PID namespace too. A proc filesystem of a child PID namespace doesn't show
+// processes of the initial PID namespace, so its “self” doesn't resolve.
+func inInitialPIDNamespace(procfs string) bool {
+	nsid, err := ops.NamespacePath(procfs + "/self/ns/pid").ID()
+	return err == nil && nsid.Ino == initialPIDNamespaceIno
+}
+
+// Events returns the channel of change events. The channel gets closed when
+// the Watcher terminates.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// View calls the specified function with the current discovery result. The
+// discovery result must not be modified and must not be used outside fn, as
+// the Watcher updates it in place after fn returns.
+func (w *Watcher) View(fn func(result *Result)) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	fn(w.result)
+}
+
+// Err returns the reason why the Watcher terminated, or nil while the Watcher
+// is still running.
+func (w *Watcher) Err() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.err
+}
+
+// watch receives process events until the context gets cancelled, applying
+// them in batches to the discovery result.
+func (w *Watcher) watch(ctx context.Context) {
+	defer close(w.events)
+
+	procevents := make(chan []cnproc.Event, 16)
+	overrun := make(chan struct{}, 1)
+	recverr := make(chan error, 1)
+	var wg sync.WaitGroup
+	if w.conn != nil {
+		wg.Add(1)
+		go w.receive(ctx, &wg, procevents, overrun, recverr)
+	}
+
+	ticker := time.NewTicker(w.resync)
+	var err error
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case err = <-recverr:
+		case <-overrun:
+			slog.Warn("process events overrun, rediscovering")
+			w.rediscover(ctx)
+		case <-ticker.C:
+			w.rediscover(ctx)
+		case events := <-procevents:
+			// Batch all process events received in the meantime, so we
+			// emit fewer but larger change events.
+		batching:
+			for {
+				select {
+				case more := <-procevents:
+					events = append(events, more...)
+				default:
+					break batching
+				}
+			}
+			w.update(ctx, events)
+		}
+	}
+	ticker.Stop()
+	if w.conn != nil {
+		_ = w.conn.Close()
+	}
+	wg.Wait()
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+// receive process events until either the context gets cancelled or
+// receiving fails, passing them on to the watch goroutine.
+func (w *Watcher) receive(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	procevents chan<- []cnproc.Event,
+	overrun chan<- struct{},
+	recverr chan<- error,
+) {
+	defer wg.Done()
+	for {
+		events, err := w.conn.Receive()
+		if errors.Is(err, cnproc.ErrOverrun) {
+			select {
+			case overrun <- struct{}{}:
+			default:
+			}
+			continue
+		}
+		if err != nil {
+			recverr <- err
+			return
+		}
+		select {
+		case procevents <- events:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// emit the specified changes, unless there are none.
+func (w *Watcher) emit(ctx context.Context, diff *ResultDiff, resync bool) {
+	if diff.Empty() {
+		return
+	}
+	select {
+	case w.events <- WatchEvent{Diff: diff, Resync: resync}:
+	case <-ctx.Done():
+	}
+}
+
+// rediscover runs a full discovery with the same options as before and then
+// replaces the current discovery result.
+func (w *Watcher) rediscover(ctx context.Context) {
+	// Only our watch goroutine ever replaces the result, so there's no need to
+	// lock while only reading it here.
+	old := w.result
+	result, err := NamespacesContext(ctx, SameAs(old))
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	w.result = result
+	w.mu.Unlock()
+	w.emit(ctx, Diff(old, result), true)
+}
+
+// update applies the specified process events to the current discovery
+// result. Only our watch goroutine ever changes the result, so it first
+// gathers the information needed from procfs without locking the result, so
+// that readers don't get blocked by procfs reads. Only applying the process
+// events then needs locking.
+func (w *Watcher) update(ctx context.Context, events []cnproc.Event) {
+	u := newWatchUpdate(ctx, w.result)
+	u.gather(events)
+	w.mu.Lock()
+	for _, event := range events {
+		u.apply(event)
+	}
+	diff := u.finish()
+	w.mu.Unlock()
+	w.emit(ctx, diff, false)
+}
+
+// namespaceLookups are the namespaces of a process or task, by namespace type
+// index. Namespace types not discovered have zero lookups.
+type namespaceLookups [model.NamespaceTypesCount]procNamespaceLookup
+
+// watchUpdate applies a batch of process events to a discovery result,
+// tracking the changes made.
+type watchUpdate struct {
+	result       *Result
+	procfs       string
+	flags        determineNamespaceFlags
+	debugEnabled bool
+
+	procs       map[model.PIDType]*model.Process              // fresh processes; nil if gone.
+	proclookups map[model.PIDType]*namespaceLookups           // namespaces of processes.
+	tasklookups map[model.PIDType]*namespaceLookups           // namespaces of tasks.
+	details     map[species.NamespaceID]func(model.Namespace) // namespace details setters.
+	rungs       map[species.NamespaceID][]hierarchyRung       // hierarchies of new namespaces.
+
+	added   map[model.PIDType]*model.Process  // processes added in this batch.
+	removed []*model.Process                  // processes removed in this batch.
+	changed map[*model.Process]*model.Process // changed processes and their old copies.
+
+	touched   [model.NamespaceTypesCount]map[species.NamespaceID]model.Namespace // snapshots.
+	removedns []model.Namespace
+	newroots  bool // user or PID namespaces have been added or removed.
+}
+
+func newWatchUpdate(ctx context.Context, result *Result) *watchUpdate {
+	u := &watchUpdate{
+		result:       result,
+		procfs:       result.Options.procfsRoot(),
+		debugEnabled: slog.Default().Enabled(ctx, slog.LevelDebug),
+		procs:        map[model.PIDType]*model.Process{},
+		proclookups:  map[model.PIDType]*namespaceLookups{},
+		tasklookups:  map[model.PIDType]*namespaceLookups{},
+		details:      map[species.NamespaceID]func(model.Namespace){},
+		rungs:        map[species.NamespaceID][]hierarchyRung{},
+		added:        map[model.PIDType]*model.Process{},
+		changed:      map[*model.Process]*model.Process{},
+	}
+	if result.Options.DiscoverOwnership {
+		u.flags = detDiscoverOwnership
+	}
+	return u
+}
+
+// gather reads the information from procfs needed to later apply the
+// specified process events, without changing the discovery result: the
+// affected processes and their tasks, the namespaces they are joined to, as
+// well as the details and hierarchies of namespaces not known so far.
+func (u *watchUpdate) gather(events []cnproc.Event) {
+	if !u.result.Options.ScanProcs {
+		return
+	}
+	withtasks := u.result.Options.ScanTasks
+	for _, event := range events {
+		if !event.IsProcess() {
+			if withtasks {
+				u.gatherTasks(u.fetchProcess(event.TGID, true))
+			}
+			continue
+		}
+		known := u.result.Processes[event.PID]
+		switch event.Type {
+		case cnproc.EventFork, cnproc.EventExec:
+			proc := u.fetchProcess(event.PID, withtasks)
+			if proc == nil {
+				continue
+			}
+			u.gatherNamespaces(proc.PID, true)
+			u.gatherTasks(proc)
+			if known == nil || known.Starttime == proc.Starttime {
+				continue
+			}
+		case cnproc.EventExit:
+			if known == nil {
+				continue
+			}
+		default:
+			continue
+		}
+		// The known process has terminated, so its children might get
+		// reparented.
+		for _, child := range known.Children {
+			u.fetchProcess(child.PID, false)
+		}
+	}
+}
+
+// gatherTasks gathers the namespaces of the tasks of the specified process
+// that aren't known yet.
+func (u *watchUpdate) gatherTasks(proc *model.Process) {
+	if proc == nil {
+		return
+	}
+	known := map[model.PIDType]struct{}{}
+	if knownproc, ok := u.result.Processes[proc.PID]; ok && knownproc.Starttime == proc.Starttime {
+		for _, task := range knownproc.Tasks {
+			known[task.TID] = struct{}{}
+		}
+	}
+	for _, task := range proc.Tasks {
+		if _, ok := known[task.TID]; !ok {
+			u.gatherNamespaces(task.TID, false)
+		}
+	}
+}
+
+// gatherNamespaces looks up the namespaces of the process or task with the
+// specified PID/TID, and then gathers what is needed about those namespaces
+// not known so far.
+func (u *watchUpdate) gatherNamespaces(pid model.PIDType, isProcess bool) {
+	lookups := u.lookupNamespaces(pid, isProcess)
+	for nstypeidx := range lookups {
+		lookup := &lookups[nstypeidx]
+		if lookup.nsref == "" {
+			continue
+		}
+		u.gatherNamespace(model.NamespaceTypeIndex(nstypeidx), pid, lookup.nsref, &lookup.ns, isProcess)
+		u.gatherNamespace(model.NamespaceTypeIndex(nstypeidx), pid, lookup.nsref+"_for_children", &lookup.forChildren, false)
+	}
+}
+
+// gatherNamespace climbs the hierarchy of the specified user or PID namespace
+// if it isn't known yet. If withDetails is true, the process with the
+// specified PID is joined to the namespace and gatherNamespace then reads the
+// details of the namespace if it isn't known yet, as well as the ID mappings
+// of a known user namespace that doesn't have any ID mappings yet.
+func (u *watchUpdate) gatherNamespace(
+	nstypeidx model.NamespaceTypeIndex,
+	pid model.PIDType,
+	nsref string,
+	lookup *namespaceLookup,
+	withDetails bool,
+) {
+	if lookup.err != nil || lookup.nsid == species.NoneID {
+		return
+	}
+	nstype := model.TypesByIndex[nstypeidx]
+	nsmap := u.result.Namespaces[nstypeidx]
+	ns := nsmap[lookup.nsid]
+	if ns == nil && u.result.Options.DiscoverHierarchy &&
+		(nstype == species.CLONE_NEWUSER || nstype == species.CLONE_NEWPID) {
+		if _, ok := u.rungs[lookup.nsid]; !ok {
+			u.rungs[lookup.nsid] = climbHierarchy(nstype, nsref,
+				func(nsid species.NamespaceID) model.Namespace { return nsmap[nsid] },
+				u.result.Options.ScanNsfs)
+		}
+	}
+	if !withDetails {
+		return
+	}
+	if ns != nil && (nstype != species.CLONE_NEWUSER || len(ns.(model.IDMapping).UIDMap()) != 0) {
+		return
+	}
+	if _, ok := u.details[lookup.nsid]; !ok {
+		u.details[lookup.nsid] = readNamespaceDetails(nstype, pid, u.procfs, &u.result.Options)
+	}
+}
+
+// fetchProcess returns the process with the specified PID as freshly read from
+// procfs, or nil if it is gone. It reads procfs only if it hasn't read the
+// process before.
+func (u *watchUpdate) fetchProcess(pid model.PIDType, withtasks bool) *model.Process {
+	if proc, ok := u.procs[pid]; ok && (proc == nil || !withtasks || proc.Tasks != nil) {
+		return proc
+	}
+	proc := model.NewProcessInProcfs(pid, withtasks, u.procfs)
+	u.procs[pid] = proc
+	return proc
+}
+
+// lookupNamespaces returns the namespaces of the process or task with the
+// specified PID/TID. It looks up the namespaces only if it hasn't looked them
+// up before.
+func (u *watchUpdate) lookupNamespaces(pid model.PIDType, isProcess bool) *namespaceLookups {
+	lookupsByPID := u.tasklookups
+	if isProcess {
+		lookupsByPID = u.proclookups
+	}
+	if lookups, ok := lookupsByPID[pid]; ok {
+		return lookups
+	}
+	lookups := &namespaceLookups{}
+	for _, nstypeidx := range discoverySequence {
+		nstype := model.TypesByIndex[nstypeidx]
+		if u.result.Options.NamespaceTypes&nstype == 0 {
+			continue
+		}
+		nsref := u.procfs + "/" + strconv.Itoa(int(pid)) + "/ns/" + nstype.Name()
+		lookups[nstypeidx] = lookupProcNamespaces(u.flags, nsref, nstype,
+			isProcess && (nstype == species.CLONE_NEWPID || nstype == species.CLONE_NEWTIME))
+	}
+	lookupsByPID[pid] = lookups
+	return lookups
+}
+
+// apply the specified process event.
+func (u *watchUpdate) apply(event cnproc.Event) {
+	if !u.result.Options.ScanProcs {
+		return
+	}
+	if !event.IsProcess() {
+		if u.result.Options.ScanTasks {
+			if proc, ok := u.result.Processes[event.TGID]; ok {
+				u.refreshTasks(proc)
+			}
+		}
+		return
+	}
+	proc := u.result.Processes[event.PID]
+	switch event.Type {
+	case cnproc.EventFork:
+		if proc != nil {
+			// We missed an exit event, or we already discovered this
+			// process in a full discovery.
+			u.exec(proc)
+			return
+		}
+		u.addProcess(event.PID)
+	case cnproc.EventExec:
+		if proc == nil {
+			u.addProcess(event.PID)
+			return
+		}
+		u.exec(proc)
+	case cnproc.EventComm:
+		if proc != nil && proc.Name != event.Comm {
+			u.changing(proc)
+			proc.Name = event.Comm
+		}
+	case cnproc.EventExit:
+		if proc != nil {
+			u.removeProcess(proc)
+		}
+	}
+}
+
+// touch snapshots the namespaces of the specified type before changing any of
+// them.
+func (u *watchUpdate) touch(nstypeidx model.NamespaceTypeIndex) {
+	if u.touched[nstypeidx] != nil {
+		return
+	}
+	nsmap := u.result.Namespaces[nstypeidx]
+	snapshots := make(map[species.NamespaceID]model.Namespace, len(nsmap))
+	for nsid, ns := range nsmap {
+		snapshots[nsid] = namespaces.Snapshot(ns)
+	}
+	u.touched[nstypeidx] = snapshots
+}
+
+// changing remembers the state of the specified process before changing it,
+// unless it has been added in this batch.
+func (u *watchUpdate) changing(proc *model.Process) {
+	if u.added[proc.PID] == proc {
+		return
+	}
+	if _, ok := u.changed[proc]; ok {
+		return
+	}
+	old := *proc
+	old.Cmdline = slices.Clone(proc.Cmdline)
+	u.changed[proc] = &old
+}
+
+// addProcess adds the newly found process with the specified PID, as well as
+// the namespaces it has joined.
+func (u *watchUpdate) addProcess(pid model.PIDType) {
+	proc := u.fetchProcess(pid, u.result.Options.ScanTasks)
+	if proc == nil {
+		return // ...it's gone already.
+	}
+	if !u.joinNamespaces(proc) {
+		return
+	}
+	processes := u.result.Processes
+	processes[pid] = proc
+	if parent, ok := processes[proc.PPID]; ok {
+		proc.Parent = parent
+		parent.Children = append(parent.Children, proc)
+	}
+	u.added[pid] = proc
+	for _, task := range proc.Tasks {
+		u.joinTaskNamespaces(task)
+	}
+}
+
+// exec re-checks the specified process after it has exec'ed, as it might have
+// changed its name, command line, and namespaces.
+func (u *watchUpdate) exec(proc *model.Process) {
+	fresh := u.fetchProcess(proc.PID, false)
+	if fresh == nil {
+		return // ...the exit event will follow.
+	}
+	if fresh.Starttime != proc.Starttime {
+		// PID got reused, so we missed the exit of the previous process.
+		u.removeProcess(proc)
+		u.addProcess(proc.PID)
+		return
+	}
+	old := *proc
+	proc.Name = fresh.Name
+	proc.Cmdline = fresh.Cmdline
+	if !u.joinNamespaces(proc) {
+		return
+	}
+	if processChanged(&old, proc) {
+		if u.added[proc.PID] != proc {
+			if _, ok := u.changed[proc]; !ok {
+				u.changed[proc] = &old
+			}
+		}
+	}
+}
+
+// removeProcess removes the specified process that has terminated.
+func (u *watchUpdate) removeProcess(proc *model.Process) {
+	processes := u.result.Processes
+	if processes[proc.PID] != proc {
+		return
+	}
+	delete(processes, proc.PID)
+	if parent := proc.Parent; parent != nil {
+		parent.Children = slices.DeleteFunc(parent.Children,
+			func(child *model.Process) bool { return child == proc })
+	}
+	// Orphans get reparented by the kernel without any process event telling
+	// us about, so we need to find out ourselves.
+	for _, child := range proc.Children {
+		child.Parent = nil
+		fresh := u.fetchProcess(child.PID, false)
+		if fresh == nil || fresh.Starttime != child.Starttime {
+			continue
+		}
+		u.changing(child)
+		child.PPID = fresh.PPID
+		if parent, ok := processes[child.PPID]; ok {
+			child.Parent = parent
+			parent.Children = append(parent.Children, child)
+		}
+	}
+	proc.Children = nil
+	for nstypeidx, ns := range proc.Namespaces {
+		if ns != nil {
+			u.touch(model.NamespaceTypeIndex(nstypeidx))
+		}
+	}
+	u.removeTasks(proc.Tasks)
+	if u.added[proc.PID] == proc {
+		delete(u.added, proc.PID)
+		return
+	}
+	if old, ok := u.changed[proc]; ok {
+		delete(u.changed, proc)
+		proc = old
+	}
+	u.removed = append(u.removed, proc)
+}
+
+// refreshTasks rediscovers the tasks of the specified process after a task
+// has been created or terminated.
+func (u *watchUpdate) refreshTasks(proc *model.Process) {
+	fresh := u.fetchProcess(proc.PID, true)
+	if fresh == nil || fresh.Starttime != proc.Starttime {
+		return
+	}
+	known := map[model.PIDType]*model.Task{}
+	for _, task := range proc.Tasks {
+		known[task.TID] = task
+	}
+	tasks := make([]*model.Task, 0, len(fresh.Tasks))
+	for _, task := range fresh.Tasks {
+		if knowntask, ok := known[task.TID]; ok {
+			delete(known, task.TID)
+			tasks = append(tasks, knowntask)
+			continue
+		}
+		task.Process = proc
+		tasks = append(tasks, task)
+		u.joinTaskNamespaces(task)
+	}
+	proc.Tasks = tasks
+	gone := make([]*model.Task, 0, len(known))
+	for _, task := range known {
+		gone = append(gone, task)
+	}
+	u.removeTasks(gone)
+}
+
+// removeTasks removes the specified tasks from the namespaces they've joined
+// as loose threads.
+func (u *watchUpdate) removeTasks(tasks []*model.Task) {
+	for _, task := range tasks {
+		for nstypeidx, ns := range task.Namespaces {
+			if ns == nil {
+				continue
+			}
+			u.touch(model.NamespaceTypeIndex(nstypeidx))
+			ns.(interface{ RemoveLooseThread(*model.Task) }).RemoveLooseThread(task)
+		}
+	}
+}
+
+// joinNamespaces looks up the namespaces the specified process has joined,
+// adding namespaces not known so far. It returns false if the namespaces
+// could not be determined, such as when the process is already gone.
+func (u *watchUpdate) joinNamespaces(proc *model.Process) bool {
+	lookups := u.lookupNamespaces(proc.PID, true)
+	for _, nstypeidx := range discoverySequence {
+		nstype := model.TypesByIndex[nstypeidx]
+		if u.result.Options.NamespaceTypes&nstype == 0 {
+			continue
+		}
+		lookup := &lookups[nstypeidx]
+		nsref := lookup.nsref
+		if lookup.ns.err != nil {
+			if nstype == species.CLONE_NEWTIME {
+				continue // ...older kernels lack time namespaces.
+			}
+			return false
+		}
+		u.touch(nstypeidx)
+		if ns, isnew := mergeNamespace(detSetReference|u.flags, &proc.ProTaskCommon,
+			nsref, nstype, nstypeidx, u.result.Namespaces[nstypeidx], &lookup.ns); isnew {
+			u.newNamespace(ns)
+		}
+		if ns, isnew := mergeNamespace(detSetReference|detForChildren|u.flags, &proc.ProTaskCommon,
+			nsref, nstype, nstypeidx, u.result.Namespaces[nstypeidx], &lookup.forChildren); isnew {
+			u.newNamespace(ns)
+		}
+	}
+	return true
+}
+
+// joinTaskNamespaces looks up the namespaces the specified task has joined,
+// adding the task as a loose thread to namespaces other than the ones of its
+// process.
+func (u *watchUpdate) joinTaskNamespaces(task *model.Task) {
+	lookups := u.lookupNamespaces(task.TID, false)
+	for _, nstypeidx := range discoverySequence {
+		nstype := model.TypesByIndex[nstypeidx]
+		if u.result.Options.NamespaceTypes&nstype == 0 {
+			continue
+		}
+		lookup := &lookups[nstypeidx]
+		u.touch(nstypeidx)
+		ns, isnew := mergeNamespace(detSetReference|u.flags, &task.ProTaskCommon,
+			lookup.nsref, nstype, nstypeidx, u.result.Namespaces[nstypeidx], &lookup.ns)
+		if ns == nil {
+			continue
+		}
+		if isnew {
+			u.newNamespace(ns)
+		}
+		if ns != task.Process.Namespaces[nstypeidx] {
+			ns.(namespaces.NamespaceConfigurer).AddLooseThread(task)
+		}
+	}
+}
+
+// newNamespace brings a newly found namespace into its hierarchy and resolves
+// its owner, as far as enabled.
+func (u *watchUpdate) newNamespace(ns model.Namespace) {
+	nstype := ns.Type()
+	if u.debugEnabled {
+		slog.Debug("found namespace from process event",
+			slog.String("namespace", ns.(model.NamespaceStringer).TypeIDString()))
+	}
+	if nstype == species.CLONE_NEWUSER || nstype == species.CLONE_NEWPID {
+		u.newroots = true
+		if u.result.Options.DiscoverHierarchy {
+			// Normally, we've already climbed the hierarchy when gathering
+			// the information about the process events.
+			nsmap := u.result.Namespaces[model.TypeIndex(nstype)]
+			rungs, ok := u.rungs[ns.ID()]
+			if !ok && len(ns.Ref()) == 1 {
+				rungs = climbHierarchy(nstype, ns.Ref()[0],
+					func(nsid species.NamespaceID) model.Namespace { return nsmap[nsid] },
+					u.result.Options.ScanNsfs)
+			}
+			joinHierarchy(nstype, ns, rungs, nsmap, u.debugEnabled)
+		}
+	}
+	if u.result.Options.DiscoverOwnership && nstype != species.CLONE_NEWUSER {
+		ns.(namespaces.NamespaceConfigurer).ResolveOwner(u.result.Namespaces[model.UserNS])
+	}
+}
+
+// finish redetermines the leaders and references of the namespaces of the
+// types touched, removes namespaces that have vanished together with their
+// last processes, and finally returns the changes made.
+func (u *watchUpdate) finish() *ResultDiff {
+	diff := &ResultDiff{
+		Mounts: map[species.NamespaceID]Changes[*mounts.MountPoint]{},
+	}
+	for nstypeidx, snapshots := range u.touched {
+		if snapshots == nil {
+			continue
+		}
+		nstypeidx := model.NamespaceTypeIndex(nstypeidx)
+		u.redetermineLeaders(nstypeidx)
+		u.pruneNamespaces(nstypeidx)
+		for nsid, ns := range u.result.Namespaces[nstypeidx] {
+			// Set the details gathered for new namespaces, as well as the ID
+			// mappings of those user namespaces that didn't have their ID
+			// mappings set yet when we last looked.
+			if setDetails := u.details[nsid]; setDetails != nil {
+				setDetails(ns)
+			}
+			old, ok := snapshots[nsid]
+			if !ok {
+				diff.Namespaces.Added = append(diff.Namespaces.Added, ns)
+				continue
+			}
+			if !slices.Equal(old.Ref(), ns.Ref()) ||
+				!slices.Equal(sortedPIDs(old.LeaderPIDs()), sortedPIDs(ns.LeaderPIDs())) ||
+				namespaceDetailsChanged(old, ns) {
+				diff.Namespaces.Changed = append(diff.Namespaces.Changed,
+					Change[model.Namespace]{Old: old, New: ns})
+			}
+		}
+	}
+	for _, ns := range u.removedns {
+		if _, ok := u.touched[model.TypeIndex(ns.Type())][ns.ID()]; ok {
+			diff.Namespaces.Removed = append(diff.Namespaces.Removed, ns)
+		}
+	}
+	if u.newroots {
+		if u.result.Options.NamespaceTypes&species.CLONE_NEWUSER != 0 {
+			u.result.UserNSRoots = rootNamespaces(u.result.Namespaces[model.UserNS])
+		}
+		if u.result.Options.NamespaceTypes&species.CLONE_NEWPID != 0 {
+			u.result.PIDNSRoots = rootNamespaces(u.result.Namespaces[model.PIDNS])
+		}
+	}
+
+	for _, proc := range u.added {
+		diff.Processes.Added = append(diff.Processes.Added, proc)
+	}
+	diff.Processes.Removed = u.removed
+	for proc, old := range u.changed {
+		if processChanged(old, proc) {
+			diff.Processes.Changed = append(diff.Processes.Changed,
+				Change[*model.Process]{Old: old, New: proc})
+		}
+	}
+
+	slices.SortFunc(diff.Namespaces.Added, compareNamespaces)
+	slices.SortFunc(diff.Namespaces.Removed, compareNamespaces)
+	slices.SortFunc(diff.Namespaces.Changed, func(a, b Change[model.Namespace]) int {
+		return compareNamespaces(a.New, b.New)
+	})
+	slices.SortFunc(diff.Processes.Added, compareProcesses)
+	slices.SortFunc(diff.Processes.Removed, compareProcesses)
+	slices.SortFunc(diff.Processes.Changed, func(a, b Change[*model.Process]) int {
+		return compareProcesses(a.New, b.New)
+	})
+	return diff
+}
+
+// redetermineLeaders redetermines the leader processes of all namespaces of
+// the specified type, as well as their references based on the most senior
+// leader processes.
+func (u *watchUpdate) redetermineLeaders(nstypeidx model.NamespaceTypeIndex) {
+	nsmap := u.result.Namespaces[nstypeidx]
+	for _, ns := range nsmap {
+		remover := ns.(interface{ RemoveLeader(*model.Process) })
+		for _, leader := range slices.Clone(ns.Leaders()) {
+			remover.RemoveLeader(leader)
+		}
+	}
+	for _, proc := range u.result.Processes {
+		if proc.Namespaces[nstypeidx] == nil {
+			continue
+		}
+		leader := proc
+		for leader.Parent != nil && leader.Parent.Namespaces[nstypeidx] == leader.Namespaces[nstypeidx] {
+			leader = leader.Parent
+		}
+		leader.Namespaces[nstypeidx].(namespaces.NamespaceConfigurer).AddLeader(leader)
+	}
+	nstypename := model.TypesByIndex[nstypeidx].Name()
+	for _, ns := range nsmap {
+		if ealdorman := ns.Ealdorman(); ealdorman != nil && u.isProcessNamespaceRef(ns.Ref()) {
+			ref := u.procfs + "/" + strconv.Itoa(int(ealdorman.PID)) + "/ns/" + nstypename
+			ns.(namespaces.NamespaceConfigurer).SetRef(model.NamespaceRef{ref})
+		}
+	}
+}
+
+// pruneNamespaces removes the namespaces of the specified type that are
+// neither joined by any process or task anymore, nor have any child
+// namespaces, and that were only referenced by a process or task now gone.
+func (u *watchUpdate) pruneNamespaces(nstypeidx model.NamespaceTypeIndex) {
+	nsmap := u.result.Namespaces[nstypeidx]
+	for nsid, ns := range nsmap {
+		if len(ns.Leaders()) != 0 || len(ns.LooseThreads()) != 0 ||
+			!u.isProcessNamespaceRef(ns.Ref()) {
+			continue
+		}
+		if pid := pidFromProcfsPath(u.procfs, ns.Ref()[0]); pid != 0 && u.isAlive(pid) {
+			continue
+		}
+		if hns, ok := ns.(model.Hierarchy); ok {
+			if len(hns.Children()) != 0 {
+				continue
+			}
+			if parent := hns.Parent(); parent != nil {
+				parent.(interface{ RemoveChild(model.Hierarchy) }).RemoveChild(hns)
+			}
+			u.newroots = true
+		}
+		if owner, ok := ns.Owner().(*namespaces.UserNamespace); ok && owner != nil {
+			owner.Disown(ns)
+		}
+		delete(nsmap, nsid)
+		u.removedns = append(u.removedns, ns)
+	}
+}
+
+// isAlive returns true if the process or task with the specified PID/TID is
+// still known.
+func (u *watchUpdate) isAlive(pid model.PIDType) bool {
+	if _, ok := u.result.Processes[pid]; ok {
+		return true
+	}
+	if !u.result.Options.ScanTasks {
+		return false
+	}
+	for _, proc := range u.result.Processes {
+		for _, task := range proc.Tasks {
+			if task.TID == pid {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isProcessNamespaceRef returns true if the specified namespace reference
+// refers to a namespace via a process or task in the /proc filesystem.
+func (u *watchUpdate) isProcessNamespaceRef(ref model.NamespaceRef) bool {
+	return len(ref) == 1 && strings.HasPrefix(ref[0], u.procfs+"/") &&
+		strings.Contains(ref[0], "/ns/")
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package discover
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("watching", func() {
+
+	BeforeEach(func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	// addedProcess returns a matcher for a watch event with the specified
+	// process having been added.
+	addedProcess := func(pid model.PIDType) OmegaMatcher {
+		return HaveField("Diff.Processes.Added", ContainElement(HaveField("PID", pid)))
+	}
+
+	removedProcess := func(pid model.PIDType) OmegaMatcher {
+		return HaveField("Diff.Processes.Removed", ContainElement(HaveField("PID", pid)))
+	}
+
+	It("keeps a discovery result current", func(ctx context.Context) {
+		wctx, cancel := context.WithCancel(ctx)
+		w := Successful(NewWatcher(wctx, time.Hour, WithStandardDiscovery()))
+		defer func() {
+			cancel()
+			Eventually(w.Events()).Should(BeClosed())
+			Expect(w.Err()).To(MatchError(context.Canceled))
+		}()
+
+		cmd := exec.Command("/bin/sleep", "120")
+		Expect(cmd.Start()).To(Succeed())
+		pid := model.PIDType(cmd.Process.Pid)
+		defer func() { _ = cmd.Process.Kill(); _ = cmd.Wait() }()
+
+		Eventually(w.Events()).Within(5 * time.Second).Should(Receive(addedProcess(pid)))
+		w.View(func(result *Result) {
+			Expect(result.Processes).To(HaveKey(pid))
+			proc := result.Processes[pid]
+			Expect(proc.Name).To(Equal("sleep"))
+			Expect(proc.Parent).NotTo(BeNil())
+			Expect(proc.Namespaces[model.NetNS]).NotTo(BeNil())
+		})
+
+		Expect(cmd.Process.Kill()).To(Succeed())
+		_ = cmd.Wait()
+		Eventually(w.Events()).Within(5 * time.Second).Should(Receive(removedProcess(pid)))
+		w.View(func(result *Result) {
+			Expect(result.Processes).NotTo(HaveKey(pid))
+		})
+	})
+
+	It("picks up new namespaces and prunes them when gone", func(ctx context.Context) {
+		wctx, cancel := context.WithCancel(ctx)
+		w := Successful(NewWatcher(wctx, time.Hour, WithStandardDiscovery()))
+		defer func() {
+			cancel()
+			Eventually(w.Events()).Should(BeClosed())
+		}()
+
+		cmd := exec.Command("unshare", "--net", "--uts", "/bin/sleep", "120")
+		Expect(cmd.Start()).To(Succeed())
+		pid := model.PIDType(cmd.Process.Pid)
+		defer func() { _ = cmd.Process.Kill(); _ = cmd.Wait() }()
+
+		var netns model.Namespace
+		Eventually(func() model.Namespace {
+			select {
+			case <-w.Events():
+			case <-time.After(100 * time.Millisecond):
+			}
+			w.View(func(result *Result) {
+				if proc, ok := result.Processes[pid]; ok && proc.Name == "sleep" {
+					netns = proc.Namespaces[model.NetNS]
+				}
+			})
+			return netns
+		}).Within(5 * time.Second).ProbeEvery(10 * time.Millisecond).ShouldNot(BeNil())
+		w.View(func(result *Result) {
+			Expect(result.Namespaces[model.NetNS]).To(HaveKeyWithValue(netns.ID(), netns))
+			Expect(netns.Leaders()).To(ConsistOf(HaveField("PID", pid)))
+		})
+
+		Expect(cmd.Process.Kill()).To(Succeed())
+		_ = cmd.Wait()
+		Eventually(w.Events()).Within(5 * time.Second).Should(Receive(
+			HaveField("Diff.Namespaces.Removed", ContainElement(netns))))
+		w.View(func(result *Result) {
+			Expect(result.Namespaces[model.NetNS]).NotTo(HaveKey(netns.ID()))
+		})
+	})
+
+	It("tells the old state of changed namespaces", func(ctx context.Context) {
+		wctx, cancel := context.WithCancel(ctx)
+		w := Successful(NewWatcher(wctx, time.Hour, WithStandardDiscovery()))
+		defer func() {
+			cancel()
+			Eventually(w.Events()).Should(BeClosed())
+		}()
+
+		cmd := exec.Command("unshare", "--net", "/bin/sh", "-c", "sleep 120 & exec sleep 120")
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		Expect(cmd.Start()).To(Succeed())
+		pid := model.PIDType(cmd.Process.Pid)
+		defer func() { _ = syscall.Kill(-int(pid), syscall.SIGKILL); _ = cmd.Wait() }()
+
+		var netns model.Namespace
+		Eventually(func() int {
+			select {
+			case <-w.Events():
+			case <-time.After(100 * time.Millisecond):
+			}
+			children := 0
+			w.View(func(result *Result) {
+				if proc, ok := result.Processes[pid]; ok && proc.Name == "sleep" {
+					netns = proc.Namespaces[model.NetNS]
+					children = len(proc.Children)
+				}
+			})
+			return children
+		}).Within(5 * time.Second).ProbeEvery(10 * time.Millisecond).Should(Equal(1))
+
+		Expect(cmd.Process.Kill()).To(Succeed())
+		_ = cmd.Wait()
+		Eventually(w.Events()).Within(5 * time.Second).Should(Receive(
+			HaveField("Diff.Namespaces.Changed", ContainElement(And(
+				HaveField("Old.ID()", netns.ID()),
+				HaveField("Old.LeaderPIDs()", ConsistOf(pid)),
+				HaveField("New.LeaderPIDs()", Not(ContainElement(pid))),
+			)))))
+	})
+
+	It("falls back to rediscovering outside the initial PID namespace", func(ctx context.Context) {
+		nsid := Successful(ops.NamespacePath("/proc/self/ns/pid").ID())
+		Expect(inInitialPIDNamespace("/proc")).To(Equal(nsid.Ino == initialPIDNamespaceIno))
+		procfs := GinkgoT().TempDir()
+		Expect(inInitialPIDNamespace(procfs)).To(BeFalse())
+
+		wctx, cancel := context.WithCancel(ctx)
+		w := Successful(NewWatcher(wctx, 100*time.Millisecond,
+			WithStandardDiscovery(), WithProcfsRoot(procfs)))
+		Expect(w.conn).To(BeNil())
+		Consistently(w.Err).Within(500 * time.Millisecond).Should(Succeed())
+		cancel()
+		Eventually(w.Events()).Should(BeClosed())
+		Expect(w.Err()).To(MatchError(context.Canceled))
+	})
+
+})
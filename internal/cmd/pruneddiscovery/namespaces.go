@@ -140,6 +140,16 @@ func asPlain(namespace model.Namespace) *namespaces.PlainNamespace {
 		plain = &namspc.PlainNamespace
 	case *namespaces.UserNamespace:
 		plain = &namspc.PlainNamespace
+	case *namespaces.TimeNamespace:
+		plain = &namspc.PlainNamespace
+	case *namespaces.UTSNamespace:
+		plain = &namspc.PlainNamespace
+	case *namespaces.IPCNamespace:
+		plain = &namspc.PlainNamespace
+	case *namespaces.NetNamespace:
+		plain = &namspc.PlainNamespace
+	case *namespaces.CgroupNamespace:
+		plain = &namspc.PlainNamespace
 	default:
 		panic(fmt.Sprintf("cannot cast %T to *PlainNamespace", namespace))
 	}
@@ -0,0 +1,233 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cnproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// EventType identifies the type of a process event.
+type EventType uint32
+
+// The process event types we're interested in, see also
+// include/uapi/linux/cn_proc.h.
+const (
+	EventNone EventType = 0x00000000 // acknowledgement of our subscription.
+	EventFork EventType = 0x00000001 // process or task got forked.
+	EventExec EventType = 0x00000002 // process exec'ed.
+	EventComm EventType = 0x00000200 // process or task changed its name.
+	EventExit EventType = 0x80000000 // process or task terminated.
+)
+
+// Event is a process event that is either about a process (PID and TGID are
+// the same) or a task (TID in PID differs from TGID).
+type Event struct {
+	Type       EventType
+	PID        model.PIDType // PID of process, or TID of task.
+	TGID       model.PIDType // thread group ID, that is, the process PID.
+	ParentPID  model.PIDType // fork and exit only: parent process or task.
+	ParentTGID model.PIDType // fork and exit only: parent process.
+	Comm       string        // comm only: new name.
+}
+
+// IsProcess returns true if the event is about a process (in terms of its main
+// task), and false if it is about some other task of a process.
+func (e Event) IsProcess() bool {
+	return e.PID == e.TGID
+}
+
+// ErrOverrun signals that the kernel had to drop process events because we
+// didn't read them fast enough.
+var ErrOverrun = errors.New("process events overrun")
+
+// Connector-related definitions, see also include/uapi/linux/connector.h and
+// include/uapi/linux/cn_proc.h.
+const (
+	cnIdxProc = 0x1
+	cnValProc = 0x1
+
+	procCnMcastListen = 1
+
+	nlmsghdrLen   = unix.SizeofNlMsghdr
+	cnMsgLen      = 20 // idx, val, seq, ack: uint32; len, flags: uint16.
+	procEventLen  = 16 // what, cpu: uint32; timestamp_ns: uint64.
+	maxMessageLen = 4096
+)
+
+// Conn is a subscription to process events.
+type Conn struct {
+	f   *os.File
+	buf []byte
+}
+
+// Dial subscribes to the process events connector, returning a connection
+// to read process events from.
+func Dial() (*Conn, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK,
+		unix.SOCK_DGRAM|unix.SOCK_NONBLOCK|unix.SOCK_CLOEXEC,
+		unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open process events connector, %w", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: cnIdxProc,
+	}); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("cannot bind to process events connector, %w", err)
+	}
+	if err := unix.Sendto(fd, listenMessage(), 0, &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+	}); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("cannot subscribe to process events, %w", err)
+	}
+	// As the socket is non-blocking, os.NewFile registers it with the runtime
+	// poller, so that closing the file unblocks any pending reads.
+	return &Conn{
+		f:   os.NewFile(uintptr(fd), "cn_proc"),
+		buf: make([]byte, maxMessageLen),
+	}, nil
+}
+
+// Close the subscription, unblocking any pending [Conn.Receive].
+func (c *Conn) Close() error {
+	return c.f.Close()
+}
+
+// Receive blocks until receiving the next process events and then returns
+// them. In case the kernel had to drop process events, Receive returns
+// [ErrOverrun]; the connection then remains usable.
+func (c *Conn) Receive() ([]Event, error) {
+	n, err := c.f.Read(c.buf)
+	if err != nil {
+		if errors.Is(err, unix.ENOBUFS) {
+			return nil, ErrOverrun
+		}
+		return nil, err
+	}
+	return parseMessages(c.buf[:n])
+}
+
+// listenMessage returns a netlink message subscribing to process events.
+func listenMessage() []byte {
+	var b bytes.Buffer
+	msglen := nlmsghdrLen + cnMsgLen + 4
+	_ = binary.Write(&b, binary.NativeEndian, unix.NlMsghdr{
+		Len:  uint32(msglen),
+		Type: unix.NLMSG_DONE,
+		Pid:  uint32(os.Getpid()),
+	})
+	_ = binary.Write(&b, binary.NativeEndian, struct {
+		Idx, Val, Seq, Ack uint32
+		Len, Flags         uint16
+	}{
+		Idx: cnIdxProc,
+		Val: cnValProc,
+		Len: 4,
+	})
+	_ = binary.Write(&b, binary.NativeEndian, uint32(procCnMcastListen))
+	return b.Bytes()
+}
+
+// parseMessages parses the process events in the specified netlink messages,
+// skipping any events we aren't interested in.
+func parseMessages(b []byte) ([]Event, error) {
+	var events []Event
+	for len(b) >= nlmsghdrLen {
+		msglen := int(binary.NativeEndian.Uint32(b[0:4]))
+		msgtype := binary.NativeEndian.Uint16(b[4:6])
+		if msglen < nlmsghdrLen || msglen > len(b) {
+			return events, fmt.Errorf("invalid netlink message length %d", msglen)
+		}
+		switch msgtype {
+		case unix.NLMSG_ERROR:
+			if msglen >= nlmsghdrLen+4 {
+				if errno := int32(binary.NativeEndian.Uint32(b[nlmsghdrLen:])); errno != 0 {
+					return events, fmt.Errorf("process events connector error, %w", unix.Errno(-errno))
+				}
+			}
+		case unix.NLMSG_DONE:
+			if event, ok := parseProcEvent(b[nlmsghdrLen:msglen]); ok {
+				events = append(events, event)
+			}
+		}
+		b = b[min(nlmsgAlign(msglen), len(b)):]
+	}
+	return events, nil
+}
+
+// parseProcEvent parses a connector message with a process event.
+func parseProcEvent(b []byte) (Event, bool) {
+	if len(b) < cnMsgLen+procEventLen {
+		return Event{}, false
+	}
+	if binary.NativeEndian.Uint32(b[0:4]) != cnIdxProc ||
+		binary.NativeEndian.Uint32(b[4:8]) != cnValProc {
+		return Event{}, false
+	}
+	b = b[cnMsgLen:]
+	event := Event{Type: EventType(binary.NativeEndian.Uint32(b[0:4]))}
+	data := b[procEventLen:]
+	pid := func(idx int) model.PIDType {
+		return model.PIDType(binary.NativeEndian.Uint32(data[idx*4:]))
+	}
+	switch event.Type {
+	case EventFork:
+		if len(data) < 16 {
+			return Event{}, false
+		}
+		event.ParentPID, event.ParentTGID = pid(0), pid(1)
+		event.PID, event.TGID = pid(2), pid(3)
+	case EventExec:
+		if len(data) < 8 {
+			return Event{}, false
+		}
+		event.PID, event.TGID = pid(0), pid(1)
+	case EventComm:
+		if len(data) < 8+16 {
+			return Event{}, false
+		}
+		event.PID, event.TGID = pid(0), pid(1)
+		comm := data[8 : 8+16]
+		if idx := bytes.IndexByte(comm, 0); idx >= 0 {
+			comm = comm[:idx]
+		}
+		event.Comm = string(comm)
+	case EventExit:
+		if len(data) < 24 {
+			return Event{}, false
+		}
+		event.PID, event.TGID = pid(0), pid(1)
+		event.ParentPID, event.ParentTGID = pid(4), pid(5)
+	default:
+		return Event{}, false
+	}
+	return event, true
+}
+
+func nlmsgAlign(l int) int {
+	return (l + unix.NLMSG_ALIGNTO - 1) &^ (unix.NLMSG_ALIGNTO - 1)
+}
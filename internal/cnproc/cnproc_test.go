@@ -0,0 +1,137 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package cnproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/model"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+// procEventMessage returns a netlink message with a process event of the
+// specified type and event data.
+func procEventMessage(what EventType, data ...uint32) []byte {
+	var payload bytes.Buffer
+	_ = binary.Write(&payload, binary.NativeEndian, []uint32{cnIdxProc, cnValProc, 0, 0, 0})
+	_ = binary.Write(&payload, binary.NativeEndian, []uint32{uint32(what), 0, 0, 0})
+	_ = binary.Write(&payload, binary.NativeEndian, data)
+	var b bytes.Buffer
+	_ = binary.Write(&b, binary.NativeEndian, unix.NlMsghdr{
+		Len:  uint32(nlmsghdrLen + payload.Len()),
+		Type: unix.NLMSG_DONE,
+	})
+	b.Write(payload.Bytes())
+	for b.Len()%unix.NLMSG_ALIGNTO != 0 {
+		b.WriteByte(0)
+	}
+	return b.Bytes()
+}
+
+var _ = Describe("process events", func() {
+
+	It("parses process events", func() {
+		var commbuf [16]byte
+		copy(commbuf[:], "foobar")
+		comm := make([]uint32, 4)
+		Expect(binary.Read(bytes.NewReader(commbuf[:]), binary.NativeEndian, comm)).To(Succeed())
+
+		msgs := append(procEventMessage(EventFork, 1, 1, 42, 42),
+			procEventMessage(EventExec, 42, 42)...)
+		msgs = append(msgs, procEventMessage(EventComm, append([]uint32{43, 42}, comm...)...)...)
+		msgs = append(msgs, procEventMessage(0x4 /* UID */, 42, 42, 0, 0)...)
+		msgs = append(msgs, procEventMessage(EventExit, 43, 42, 0, 0, 1, 1)...)
+
+		events := Successful(parseMessages(msgs))
+		Expect(events).To(HaveExactElements(
+			Event{Type: EventFork, PID: 42, TGID: 42, ParentPID: 1, ParentTGID: 1},
+			Event{Type: EventExec, PID: 42, TGID: 42},
+			Event{Type: EventComm, PID: 43, TGID: 42, Comm: "foobar"},
+			Event{Type: EventExit, PID: 43, TGID: 42, ParentPID: 1, ParentTGID: 1},
+		))
+		Expect(events[0].IsProcess()).To(BeTrue())
+		Expect(events[2].IsProcess()).To(BeFalse())
+	})
+
+	It("rejects invalid messages", func() {
+		msg := procEventMessage(EventExec, 42, 42)
+		binary.NativeEndian.PutUint32(msg, uint32(len(msg)+1))
+		Expect(parseMessages(msg)).Error().To(HaveOccurred())
+
+		Expect(parseMessages(procEventMessage(EventExit, 42))).To(BeEmpty())
+	})
+
+	It("receives live process events", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+
+		conn := Successful(Dial())
+		defer func() { _ = conn.Close() }()
+
+		cmd := exec.Command("/bin/true")
+		Expect(cmd.Run()).To(Succeed())
+		pid := model.PIDType(cmd.Process.Pid)
+
+		var seen []EventType
+		Eventually(func() []EventType {
+			events, err := conn.Receive()
+			Expect(err).NotTo(HaveOccurred())
+			for _, event := range events {
+				if event.PID == pid {
+					seen = append(seen, event.Type)
+				}
+			}
+			return seen
+		}).Within(5 * time.Second).ProbeEvery(time.Millisecond).Should(
+			ContainElements(EventFork, EventExec, EventExit))
+	})
+
+	It("unblocks a pending receive when closing", func() {
+		if os.Geteuid() != 0 {
+			Skip("needs root")
+		}
+		conn := Successful(Dial())
+		done := make(chan error)
+		go func() {
+			defer close(done)
+			for {
+				if _, err := conn.Receive(); err != nil {
+					done <- err
+					return
+				}
+			}
+		}()
+		Expect(conn.Close()).To(Succeed())
+		Eventually(done).Within(2 * time.Second).Should(Receive(MatchError(os.ErrClosed)))
+	})
+
+})
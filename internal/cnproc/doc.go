@@ -0,0 +1,12 @@
+/*
+Package cnproc subscribes to the Linux kernel's process events connector
+(“cn_proc”) in order to learn about processes and tasks getting forked,
+exec'ing, changing their names, and terminating.
+
+Subscribing requires the CAP_NET_ADMIN capability in the initial user
+namespace. Please note that the process events connector only tells about
+fork, exec, comm and exit events (amongst some others we're not interested
+in), but not about processes switching namespaces, as there are no events for
+setns(2) and unshare(2).
+*/
+package cnproc
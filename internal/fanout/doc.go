@@ -0,0 +1,6 @@
+/*
+Package fanout fans out per-item work, such as scanning individual processes,
+to a bounded number of goroutines, while keeping the results in the same order
+as the items worked on, so that callers can deterministically merge them.
+*/
+package fanout
@@ -0,0 +1,78 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package fanout
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Map calls fn for each of the specified items, using up to the specified
+// number of workers (goroutines) in parallel, and returns the results in the
+// same order as the items. If workers is less than two, Map calls fn
+// sequentially on the caller's goroutine.
+//
+// As soon as the passed context is cancelled, Map doesn't start working on any
+// further items and returns; the results of items not worked on are zero
+// values.
+func Map[T, R any](ctx context.Context, workers int, items []T, fn func(T) R) []R {
+	results := make([]R, len(items))
+	each(ctx, workers, len(items), func(idx int) {
+		results[idx] = fn(items[idx])
+	})
+	return results
+}
+
+// Each calls fn for each of the specified items, using up to the specified
+// number of workers (goroutines) in parallel. If workers is less than two, Each
+// calls fn sequentially on the caller's goroutine. As soon as the passed
+// context is cancelled, Each doesn't start working on any further items and
+// returns.
+func Each[T any](ctx context.Context, workers int, items []T, fn func(T)) {
+	each(ctx, workers, len(items), func(idx int) {
+		fn(items[idx])
+	})
+}
+
+// each calls fn for all indices 0..n-1, using up to the specified number of
+// workers.
+func each(ctx context.Context, workers int, n int, fn func(idx int)) {
+	if workers < 2 || n < 2 {
+		for idx := range n {
+			if ctx.Err() != nil {
+				return
+			}
+			fn(idx)
+		}
+		return
+	}
+	// Instead of handing out items through a channel we simply let the workers
+	// pick the next item index, as this is cheaper.
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Go(func() {
+			for ctx.Err() == nil {
+				idx := int(next.Add(1) - 1)
+				if idx >= n {
+					return
+				}
+				fn(idx)
+			}
+		})
+	}
+	wg.Wait()
+}
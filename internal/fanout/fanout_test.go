@@ -0,0 +1,76 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package fanout
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+)
+
+var _ = Describe("fanning out", func() {
+
+	BeforeEach(func() {
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+		})
+	})
+
+	DescribeTable("keeps results in item order",
+		func(workers int) {
+			items := make([]int, 1000)
+			for idx := range items {
+				items[idx] = idx
+			}
+			results := Map(context.Background(), workers, items, func(item int) int { return 2 * item })
+			Expect(results).To(HaveLen(len(items)))
+			for idx, result := range results {
+				Expect(result).To(Equal(2 * idx))
+			}
+		},
+		Entry("sequentially", 0),
+		Entry("with a single worker", 1),
+		Entry("with multiple workers", 8),
+		Entry("with more workers than items", 2000),
+	)
+
+	It("calls for each item", func() {
+		var sum atomic.Int64
+		Each(context.Background(), 4, []int64{1, 2, 3, 4, 5}, func(item int64) { sum.Add(item) })
+		Expect(sum.Load()).To(Equal(int64(15)))
+	})
+
+	It("stops when cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		var count atomic.Int64
+		results := Map(ctx, 4, make([]int, 100), func(int) bool {
+			if count.Add(1) == 10 {
+				cancel()
+			}
+			return true
+		})
+		Expect(results).To(ContainElement(false))
+		Expect(count.Load()).To(BeNumerically("<", 100))
+
+		count.Store(0)
+		_ = Map(ctx, 0, make([]int, 100), func(int) bool { count.Add(1); return true })
+		Expect(count.Load()).To(BeZero())
+	})
+
+})
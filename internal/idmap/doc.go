@@ -0,0 +1,4 @@
+/*
+Package idmap translates user and group IDs between different user namespaces.
+*/
+package idmap
@@ -0,0 +1,131 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package idmap
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// DefaultOverflowID is the default overflow user and group ID the Linux kernel
+// uses in place of unmapped IDs.
+const DefaultOverflowID = 65534
+
+// IDMap implements [model.IDMapper] for translating user and group IDs between
+// user namespaces.
+//
+// The ID mappings of user namespaces, as read from “/proc/[PID]/uid_map” and
+// “/proc/[PID]/gid_map”, are relative to the user namespace of the reading
+// process. IDMap thus translates IDs by first mapping them from the source
+// user namespace onto the reading user namespace, and then from there into the
+// destination user namespace. This is the same as the Linux kernel does when
+// it maps IDs onto its kernel-internal IDs and back again, as long as the
+// reading user namespace is the initial user namespace.
+type IDMap struct {
+	readerns    model.Namespace // user namespace the ID mappings are relative to.
+	overflowuid uint32          // overflow user ID.
+	overflowgid uint32          // overflow group ID.
+}
+
+// Ensure that IDMap implements and thus satisfies IDMapper.
+var _ (model.IDMapper) = (*IDMap)(nil)
+
+// NewIDMap returns a new ID map for translating user and group IDs between
+// user namespaces, where the ID mappings of the user namespaces are relative
+// to the specified reading user namespace. Unmapped IDs are munged into the
+// specified overflow user and group IDs.
+func NewIDMap(readerns model.Namespace, overflowuid, overflowgid uint32) *IDMap {
+	return &IDMap{
+		readerns:    readerns,
+		overflowuid: overflowuid,
+		overflowgid: overflowgid,
+	}
+}
+
+// NewIDMapInProcfs returns a new ID map as [NewIDMap] does, but reads the
+// overflow user and group IDs from the proc filesystem mounted at procroot.
+func NewIDMapInProcfs(readerns model.Namespace, procroot string) *IDMap {
+	return NewIDMap(readerns,
+		overflowID(procroot+"/sys/kernel/overflowuid"),
+		overflowID(procroot+"/sys/kernel/overflowgid"))
+}
+
+// overflowID returns the overflow ID read from the specified file, or the
+// default overflow ID if the file cannot be read.
+func overflowID(path string) uint32 {
+	b, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return DefaultOverflowID
+	}
+	id, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 32)
+	if err != nil {
+		return DefaultOverflowID
+	}
+	return uint32(id)
+}
+
+// Translate translates the user or group ID "id" in user namespace "from" to
+// the corresponding ID in user namespace "to". It returns false if the ID
+// either has no mapping in user namespace "from", or if it has no mapping in
+// user namespace "to". It also returns false if the ID mappings of either user
+// namespace are unknown.
+func (m *IDMap) Translate(id uint32, kind model.IDKind, from model.Namespace, to model.Namespace) (uint32, bool) {
+	if from == nil || to == nil {
+		return 0, false
+	}
+	if from == to {
+		return id, true
+	}
+	lowerid := id
+	if from != m.readerns {
+		var ok bool
+		if lowerid, ok = idMapOf(from, kind).ToLower(id); !ok {
+			return 0, false
+		}
+	}
+	if to == m.readerns {
+		return lowerid, true
+	}
+	return idMapOf(to, kind).FromLower(lowerid)
+}
+
+// TranslateMunged translates the user or group ID "id" in user namespace
+// "from" to the corresponding ID in user namespace "to". In case the ID cannot
+// be translated, it returns the overflow user or group ID instead.
+func (m *IDMap) TranslateMunged(id uint32, kind model.IDKind, from model.Namespace, to model.Namespace) uint32 {
+	if id, ok := m.Translate(id, kind, from, to); ok {
+		return id
+	}
+	if kind == model.GroupIDs {
+		return m.overflowgid
+	}
+	return m.overflowuid
+}
+
+// idMapOf returns either the user ID mapping or the group ID mapping of the
+// specified user namespace, if known.
+func idMapOf(userns model.Namespace, kind model.IDKind) model.IDMap {
+	idm, ok := userns.(model.IDMapping)
+	if !ok {
+		return nil
+	}
+	if kind == model.GroupIDs {
+		return idm.GIDMap()
+	}
+	return idm.UIDMap()
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package idmap
+
+import (
+	"os"
+
+	"github.com/thediveo/lxkns/internal/namespaces"
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// newUserNamespace returns a new user namespace with the specified ID mappings.
+func newUserNamespace(ino uint64, uidmap, gidmap model.IDMap) model.Namespace {
+	userns := namespaces.New(species.CLONE_NEWUSER, species.NamespaceIDfromInode(ino), nil)
+	userns.(namespaces.UserConfigurer).SetIDMaps(uidmap, gidmap, model.SetgroupsUnknown)
+	return userns
+}
+
+var _ = Describe("translating IDs", func() {
+
+	identity := model.IDMap{{First: 0, LowerFirst: 0, Count: ^uint32(0)}}
+	hostns := newUserNamespace(1, identity, identity)
+	rootlessns := newUserNamespace(2,
+		model.IDMap{{First: 0, LowerFirst: 1000, Count: 1}, {First: 1, LowerFirst: 100000, Count: 65536}},
+		model.IDMap{{First: 0, LowerFirst: 1000, Count: 1}})
+	nestedns := newUserNamespace(3,
+		model.IDMap{{First: 0, LowerFirst: 100000, Count: 1000}},
+		nil)
+	idmappedns := newUserNamespace(4,
+		model.IDMap{{First: 0, LowerFirst: 200000, Count: 65536}},
+		model.IDMap{{First: 0, LowerFirst: 200000, Count: 65536}})
+	unmappedns := newUserNamespace(5, nil, nil)
+
+	idmap := NewIDMap(hostns, 65534, 65533)
+
+	DescribeTable("translates user IDs",
+		func(id uint32, from, to model.Namespace, expected uint32, ok bool) {
+			actual, actualok := idmap.Translate(id, model.UserIDs, from, to)
+			Expect(actualok).To(Equal(ok))
+			Expect(actual).To(Equal(expected))
+		},
+		Entry("rootless root to host", uint32(0), rootlessns, hostns, uint32(1000), true),
+		Entry("host to rootless root", uint32(1000), hostns, rootlessns, uint32(0), true),
+		Entry("rootless to host", uint32(42), rootlessns, hostns, uint32(100041), true),
+		Entry("nested to rootless", uint32(5), nestedns, rootlessns, uint32(6), true),
+		Entry("rootless to nested", uint32(6), rootlessns, nestedns, uint32(5), true),
+		Entry("same user namespace", uint32(123), unmappedns, unmappedns, uint32(123), true),
+		Entry("unmapped in destination", uint32(0), rootlessns, idmappedns, uint32(0), false),
+		Entry("unmapped in source", uint32(65536), idmappedns, hostns, uint32(0), false),
+		Entry("unknown source mapping", uint32(0), unmappedns, hostns, uint32(0), false),
+		Entry("unknown destination mapping", uint32(0), hostns, unmappedns, uint32(0), false),
+		Entry("no source", uint32(0), nil, hostns, uint32(0), false),
+	)
+
+	It("translates group IDs", func() {
+		gid, ok := idmap.Translate(0, model.GroupIDs, idmappedns, hostns)
+		Expect(ok).To(BeTrue())
+		Expect(gid).To(Equal(uint32(200000)))
+		_, ok = idmap.Translate(1, model.GroupIDs, rootlessns, hostns)
+		Expect(ok).To(BeFalse())
+		_, ok = idmap.Translate(0, model.GroupIDs, nestedns, hostns)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("munges unmapped IDs", func() {
+		Expect(idmap.TranslateMunged(0, model.UserIDs, rootlessns, hostns)).To(Equal(uint32(1000)))
+		Expect(idmap.TranslateMunged(0, model.UserIDs, rootlessns, idmappedns)).To(Equal(uint32(65534)))
+		Expect(idmap.TranslateMunged(1, model.GroupIDs, rootlessns, hostns)).To(Equal(uint32(65533)))
+	})
+
+	It("reads the overflow IDs", func() {
+		Expect(overflowID("/proc/non-existing")).To(Equal(uint32(DefaultOverflowID)))
+		if _, err := os.Stat("/proc/sys/kernel/overflowuid"); err != nil {
+			Skip("no overflowuid")
+		}
+		m := NewIDMapInProcfs(hostns, "/proc")
+		Expect(m.overflowuid).NotTo(BeZero())
+		Expect(m.overflowgid).NotTo(BeZero())
+	})
+
+})
@@ -0,0 +1,63 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package namespaces
+
+import (
+	"strconv"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// CgroupNamespace stores the root of a cgroup namespace in the cgroup
+// hierarchy in addition to the information for plain namespaces. On top of the
+// interfaces supported by a PlainNamespace, CgroupNamespace implements the
+// CgroupNamespaceRoot interface.
+type CgroupNamespace struct {
+	PlainNamespace
+	root string
+}
+
+// Ensure that our "class" *does* implement the required interfaces.
+var (
+	_ model.Namespace           = (*CgroupNamespace)(nil)
+	_ model.NamespaceStringer   = (*CgroupNamespace)(nil)
+	_ model.CgroupNamespaceRoot = (*CgroupNamespace)(nil)
+	_ NamespaceConfigurer       = (*CgroupNamespace)(nil)
+	_ CgroupConfigurer          = (*CgroupNamespace)(nil)
+)
+
+// CgroupRoot returns the path of the root of this cgroup namespace in the
+// cgroup hierarchy, or "" if unknown.
+func (cns *CgroupNamespace) CgroupRoot() string { return cns.root }
+
+// SetCgroupRoot sets the path of the root of this cgroup namespace in the
+// cgroup hierarchy.
+func (cns *CgroupNamespace) SetCgroupRoot(root string) { cns.root = root }
+
+// String describes this instance of a cgroup namespace, including its root,
+// if known.
+func (cns *CgroupNamespace) String() string {
+	if cns.root == "" {
+		return cns.PlainNamespace.String()
+	}
+	return cns.PlainNamespace.String() + ", cgroup root " + strconv.Quote(cns.root)
+}
+
+// ResolveOwner sets the owning user namespace reference based on the owning
+// user namespace id discovered earlier. Please see also
+// [UserNamespace.ResolveOwner] for why we need to repeat ourselves here.
+func (cns *CgroupNamespace) ResolveOwner(usernsmap model.NamespaceMap) {
+	cns.resolveOwner(cns, usernsmap)
+}
@@ -29,4 +29,37 @@ type HierarchyConfigurer interface {
 // the information hold by user namespaces.
 type UserConfigurer interface {
 	SetOwnerUID(uid int)
+	SetIDMaps(uidmap, gidmap model.IDMap, setgroups model.Setgroups)
+}
+
+// TimeConfigurer allows discovery and unmarshalling mechanisms to configure
+// the information hold by time namespaces.
+type TimeConfigurer interface {
+	SetClockOffsets(offsets *model.ClockOffsets)
+}
+
+// UTSConfigurer allows discovery and unmarshalling mechanisms to configure
+// the information hold by UTS namespaces.
+type UTSConfigurer interface {
+	SetUTSNames(nodename, domainname string)
+}
+
+// CgroupConfigurer allows discovery and unmarshalling mechanisms to configure
+// the information hold by cgroup namespaces.
+type CgroupConfigurer interface {
+	SetCgroupRoot(root string)
+}
+
+// IPCConfigurer allows discovery and unmarshalling mechanisms to configure
+// the information hold by IPC namespaces.
+type IPCConfigurer interface {
+	SetIPCObjects(objects *model.IPCObjects)
+}
+
+// NetConfigurer allows discovery and unmarshalling mechanisms to configure
+// the information hold by network namespaces.
+type NetConfigurer interface {
+	SetNetworkInterfaces(netifs []model.NetworkInterface)
+	SetNetNSIDs(nsids map[int32]species.NamespaceID)
+	SetSockets(sockets []model.Socket)
 }
@@ -0,0 +1,65 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package namespaces
+
+import (
+	"fmt"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// IPCNamespace stores the IPC objects of an IPC namespace in addition to the
+// information for plain namespaces. On top of the interfaces supported by a
+// PlainNamespace, IPCNamespace implements the IPCInventory interface.
+type IPCNamespace struct {
+	PlainNamespace
+	objects *model.IPCObjects
+}
+
+// Ensure that our "class" *does* implement the required interfaces.
+var (
+	_ model.Namespace         = (*IPCNamespace)(nil)
+	_ model.NamespaceStringer = (*IPCNamespace)(nil)
+	_ model.IPCInventory      = (*IPCNamespace)(nil)
+	_ NamespaceConfigurer     = (*IPCNamespace)(nil)
+	_ IPCConfigurer           = (*IPCNamespace)(nil)
+)
+
+// IPCObjects returns the IPC objects of this IPC namespace, or nil if unknown.
+func (ins *IPCNamespace) IPCObjects() *model.IPCObjects { return ins.objects }
+
+// SetIPCObjects sets the IPC objects of this IPC namespace.
+func (ins *IPCNamespace) SetIPCObjects(objects *model.IPCObjects) {
+	ins.objects = objects
+}
+
+// String describes this instance of an IPC namespace, including the number of
+// its IPC objects, if known.
+func (ins *IPCNamespace) String() string {
+	if ins.objects == nil {
+		return ins.PlainNamespace.String()
+	}
+	return ins.PlainNamespace.String() + fmt.Sprintf(
+		", %d shm, %d sem, %d msg, %d mqueue",
+		len(ins.objects.SharedMemory), len(ins.objects.Semaphores),
+		len(ins.objects.MessageQueues), len(ins.objects.POSIXMessageQueues))
+}
+
+// ResolveOwner sets the owning user namespace reference based on the owning
+// user namespace id discovered earlier. Please see also
+// [UserNamespace.ResolveOwner] for why we need to repeat ourselves here.
+func (ins *IPCNamespace) ResolveOwner(usernsmap model.NamespaceMap) {
+	ins.resolveOwner(ins, usernsmap)
+}
@@ -161,13 +161,111 @@ var _ = Describe("namespaces", func() {
 
 	})
 
+	Describe("time namespaces", func() {
+
+		It("render details", func() {
+			tns := New(species.CLONE_NEWTIME, species.NamespaceID{Dev: 1, Ino: 1111}, nil).(*TimeNamespace)
+			Expect(tns.ClockOffsets()).To(BeNil())
+			Expect(tns.String()).NotTo(ContainSubstring("clock offsets"))
+
+			tns.SetClockOffsets(&model.ClockOffsets{Monotonic: time.Hour})
+			Expect(tns.ClockOffsets()).To(Equal(&model.ClockOffsets{Monotonic: time.Hour}))
+			s := tns.String()
+			Expect(s).To(ContainSubstring("time:[1111]"))
+			Expect(s).To(ContainSubstring("clock offsets monotonic +1h0m0s, boottime 0s"))
+		})
+
+	})
+
+	Describe("UTS namespaces", func() {
+
+		It("render details", func() {
+			uns := New(species.CLONE_NEWUTS, species.NamespaceID{Dev: 1, Ino: 1111}, nil).(*UTSNamespace)
+			Expect(uns.Nodename()).To(BeEmpty())
+			Expect(uns.String()).NotTo(ContainSubstring("hostname"))
+
+			uns.SetUTSNames("foobar", "(none)")
+			Expect(uns.Nodename()).To(Equal("foobar"))
+			Expect(uns.Domainname()).To(Equal("(none)"))
+			s := uns.String()
+			Expect(s).To(ContainSubstring("uts:[1111]"))
+			Expect(s).To(ContainSubstring(`hostname "foobar"`))
+		})
+
+	})
+
+	Describe("cgroup namespaces", func() {
+
+		It("render details", func() {
+			cns := New(species.CLONE_NEWCGROUP, species.NamespaceID{Dev: 1, Ino: 1111}, nil).(*CgroupNamespace)
+			Expect(cns.CgroupRoot()).To(BeEmpty())
+			Expect(cns.String()).NotTo(ContainSubstring("cgroup root"))
+
+			cns.SetCgroupRoot("/foo.slice")
+			Expect(cns.CgroupRoot()).To(Equal("/foo.slice"))
+			s := cns.String()
+			Expect(s).To(ContainSubstring("cgroup:[1111]"))
+			Expect(s).To(ContainSubstring(`cgroup root "/foo.slice"`))
+		})
+
+	})
+
+	Describe("IPC namespaces", func() {
+
+		It("render details", func() {
+			ins := New(species.CLONE_NEWIPC, species.NamespaceID{Dev: 1, Ino: 1111}, nil).(*IPCNamespace)
+			Expect(ins.IPCObjects()).To(BeNil())
+			Expect(ins.String()).NotTo(ContainSubstring("shm"))
+
+			ins.SetIPCObjects(&model.IPCObjects{
+				SharedMemory: []model.SysVSharedMemory{{}, {}},
+				Semaphores:   []model.SysVSemaphoreSet{{}},
+			})
+			Expect(ins.IPCObjects().SharedMemory).To(HaveLen(2))
+			s := ins.String()
+			Expect(s).To(ContainSubstring("ipc:[1111]"))
+			Expect(s).To(ContainSubstring("2 shm, 1 sem, 0 msg, 0 mqueue"))
+		})
+
+	})
+
+	Describe("network namespaces", func() {
+
+		It("render details", func() {
+			nns := New(species.CLONE_NEWNET, species.NamespaceID{Dev: 1, Ino: 1111}, nil).(*NetNamespace)
+			Expect(nns.NetworkInterfaces()).To(BeNil())
+			Expect(nns.String()).NotTo(ContainSubstring("interfaces"))
+
+			nns.SetNetworkInterfaces([]model.NetworkInterface{{Index: 1, Name: "lo"}})
+			Expect(nns.NetworkInterfaces()).To(HaveLen(1))
+			s := nns.String()
+			Expect(s).To(ContainSubstring("net:[1111]"))
+			Expect(s).To(ContainSubstring("1 interfaces"))
+
+			Expect(nns.NetNSIDs()).To(BeNil())
+			nns.SetNetNSIDs(map[int32]species.NamespaceID{0: {Dev: 1, Ino: 2222}})
+			Expect(nns.NetNSIDs()).To(HaveKeyWithValue(int32(0), species.NamespaceID{Dev: 1, Ino: 2222}))
+
+			Expect(nns.Sockets()).To(BeNil())
+			Expect(nns.String()).NotTo(ContainSubstring("sockets"))
+			nns.SetSockets([]model.Socket{{Protocol: model.SocketProtocolTCP, Inode: 42}})
+			Expect(nns.Sockets()).To(HaveLen(1))
+			Expect(nns.String()).To(ContainSubstring("1 interfaces, 1 sockets"))
+		})
+
+	})
+
 	It("creates new namespace objects", func() {
-		plainns := NewWithSimpleRef(species.CLONE_NEWNET, species.NamespaceID{Dev: 1, Ino: 1111}, "/foobar")
+		plainns := NewWithSimpleRef(species.CLONE_NEWNS, species.NamespaceID{Dev: 1, Ino: 1111}, "/foobar")
 		Expect(plainns).To(BeAssignableToTypeOf(&PlainNamespace{}))
 		Expect(plainns).NotTo(BeAssignableToTypeOf(&HierarchicalNamespace{}))
-		Expect(plainns.Type()).To(Equal(species.CLONE_NEWNET))
+		Expect(plainns.Type()).To(Equal(species.CLONE_NEWNS))
 		Expect(plainns.Ref()).To(ConsistOf("/foobar"))
 
+		cgroupns := NewWithSimpleRef(species.CLONE_NEWCGROUP, species.NamespaceID{Dev: 1, Ino: 1111}, "/foobar")
+		Expect(cgroupns).To(BeAssignableToTypeOf(&CgroupNamespace{}))
+		Expect(cgroupns.Type()).To(Equal(species.CLONE_NEWCGROUP))
+
 		pidns := NewWithSimpleRef(species.CLONE_NEWPID, species.NamespaceID{Dev: 1, Ino: 1111}, "/foobar")
 		Expect(pidns).To(BeAssignableToTypeOf(&HierarchicalNamespace{}))
 		Expect(pidns).NotTo(BeAssignableToTypeOf(&UserNamespace{}))
@@ -175,4 +273,35 @@ var _ = Describe("namespaces", func() {
 		Expect(pidns.Ref()).To(ConsistOf("/foobar"))
 	})
 
+	It("snapshots namespace objects", func() {
+		leader := &model.Process{PID: 42}
+		for _, nstype := range []species.NamespaceType{
+			species.CLONE_NEWNS, species.CLONE_NEWCGROUP, species.CLONE_NEWUTS,
+			species.CLONE_NEWIPC, species.CLONE_NEWNET, species.CLONE_NEWPID,
+			species.CLONE_NEWUSER, species.CLONE_NEWTIME,
+		} {
+			ns := NewWithSimpleRef(nstype, species.NamespaceID{Dev: 1, Ino: 1111}, "/foobar")
+			ns.(NamespaceConfigurer).AddLeader(leader)
+			snapshot := Snapshot(ns)
+			Expect(snapshot).To(BeAssignableToTypeOf(ns))
+			Expect(snapshot).NotTo(BeIdenticalTo(ns))
+			Expect(snapshot.ID()).To(Equal(ns.ID()))
+			Expect(snapshot.Ref()).To(Equal(ns.Ref()))
+
+			ns.(interface{ RemoveLeader(*model.Process) }).RemoveLeader(leader)
+			ns.(NamespaceConfigurer).SetRef(model.NamespaceRef{"/barfoo"})
+			Expect(ns.Leaders()).To(BeEmpty())
+			Expect(snapshot.Leaders()).To(ConsistOf(leader))
+			Expect(snapshot.Ref()).To(ConsistOf("/foobar"))
+		}
+
+		parent := NewWithSimpleRef(species.CLONE_NEWUSER, species.NamespaceID{Dev: 1, Ino: 1}, "/parent")
+		child := NewWithSimpleRef(species.CLONE_NEWUSER, species.NamespaceID{Dev: 1, Ino: 2}, "/child")
+		parent.(HierarchyConfigurer).AddChild(child.(model.Hierarchy))
+		snapshot := Snapshot(parent)
+		parent.(interface{ RemoveChild(model.Hierarchy) }).RemoveChild(child.(model.Hierarchy))
+		Expect(parent.(model.Hierarchy).Children()).To(BeEmpty())
+		Expect(snapshot.(model.Hierarchy).Children()).To(ConsistOf(child))
+	})
+
 })
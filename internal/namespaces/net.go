@@ -0,0 +1,91 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package namespaces
+
+import (
+	"fmt"
+
+	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/species"
+)
+
+// NetNamespace stores the network interfaces of a network namespace, as well
+// as the identifiers it assigned to other network namespaces and its sockets,
+// in addition to the information for plain namespaces. On top of the
+// interfaces supported by a PlainNamespace, NetNamespace implements the
+// NetworkInventory and SocketInventory interfaces.
+type NetNamespace struct {
+	PlainNamespace
+	netifs  []model.NetworkInterface
+	nsids   map[int32]species.NamespaceID
+	sockets []model.Socket
+}
+
+// Ensure that our "class" *does* implement the required interfaces.
+var (
+	_ model.Namespace         = (*NetNamespace)(nil)
+	_ model.NamespaceStringer = (*NetNamespace)(nil)
+	_ model.NetworkInventory  = (*NetNamespace)(nil)
+	_ model.SocketInventory   = (*NetNamespace)(nil)
+	_ NamespaceConfigurer     = (*NetNamespace)(nil)
+	_ NetConfigurer           = (*NetNamespace)(nil)
+)
+
+// NetworkInterfaces returns the network interfaces of this network namespace,
+// or nil if unknown.
+func (nns *NetNamespace) NetworkInterfaces() []model.NetworkInterface { return nns.netifs }
+
+// SetNetworkInterfaces sets the network interfaces of this network namespace.
+func (nns *NetNamespace) SetNetworkInterfaces(netifs []model.NetworkInterface) {
+	nns.netifs = netifs
+}
+
+// NetNSIDs returns the identifiers (“nsids”) this network namespace assigned to
+// other network namespaces, or nil if unknown.
+func (nns *NetNamespace) NetNSIDs() map[int32]species.NamespaceID { return nns.nsids }
+
+// SetNetNSIDs sets the identifiers (“nsids”) this network namespace assigned
+// to other network namespaces.
+func (nns *NetNamespace) SetNetNSIDs(nsids map[int32]species.NamespaceID) {
+	nns.nsids = nsids
+}
+
+// Sockets returns the sockets of this network namespace, or nil if unknown.
+func (nns *NetNamespace) Sockets() []model.Socket { return nns.sockets }
+
+// SetSockets sets the sockets of this network namespace.
+func (nns *NetNamespace) SetSockets(sockets []model.Socket) {
+	nns.sockets = sockets
+}
+
+// String describes this instance of a network namespace, including the
+// numbers of its network interfaces and sockets, if known.
+func (nns *NetNamespace) String() string {
+	s := nns.PlainNamespace.String()
+	if nns.netifs != nil {
+		s += fmt.Sprintf(", %d interfaces", len(nns.netifs))
+	}
+	if nns.sockets != nil {
+		s += fmt.Sprintf(", %d sockets", len(nns.sockets))
+	}
+	return s
+}
+
+// ResolveOwner sets the owning user namespace reference based on the owning
+// user namespace id discovered earlier. Please see also
+// [UserNamespace.ResolveOwner] for why we need to repeat ourselves here.
+func (nns *NetNamespace) ResolveOwner(usernsmap model.NamespaceMap) {
+	nns.resolveOwner(nns, usernsmap)
+}
@@ -39,6 +39,46 @@ func New(nstype species.NamespaceType, nsid species.NamespaceID, ref model.Names
 				ref:    ref,
 			},
 		}
+	case species.CLONE_NEWTIME:
+		return &TimeNamespace{
+			PlainNamespace: PlainNamespace{
+				nsid:   nsid,
+				nstype: nstype,
+				ref:    ref,
+			},
+		}
+	case species.CLONE_NEWUTS:
+		return &UTSNamespace{
+			PlainNamespace: PlainNamespace{
+				nsid:   nsid,
+				nstype: nstype,
+				ref:    ref,
+			},
+		}
+	case species.CLONE_NEWIPC:
+		return &IPCNamespace{
+			PlainNamespace: PlainNamespace{
+				nsid:   nsid,
+				nstype: nstype,
+				ref:    ref,
+			},
+		}
+	case species.CLONE_NEWCGROUP:
+		return &CgroupNamespace{
+			PlainNamespace: PlainNamespace{
+				nsid:   nsid,
+				nstype: nstype,
+				ref:    ref,
+			},
+		}
+	case species.CLONE_NEWNET:
+		return &NetNamespace{
+			PlainNamespace: PlainNamespace{
+				nsid:   nsid,
+				nstype: nstype,
+				ref:    ref,
+			},
+		}
 	default:
 		return &PlainNamespace{nsid: nsid, nstype: nstype, ref: ref}
 	}
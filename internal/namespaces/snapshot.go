@@ -0,0 +1,69 @@
+package namespaces
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// Snapshot returns a copy of the specified namespace object, so that later
+// changes to the namespace object, such as to its leaders, don't affect the
+// copy. The copy still shares the processes, tasks, and other namespaces it
+// relates to with the original namespace object.
+func Snapshot(ns model.Namespace) model.Namespace {
+	switch ns := ns.(type) {
+	case *UserNamespace:
+		uns := *ns
+		uns.HierarchicalNamespace = ns.HierarchicalNamespace.snapshot()
+		for idx := range uns.ownedns {
+			uns.ownedns[idx] = maps.Clone(ns.ownedns[idx])
+		}
+		return &uns
+	case *HierarchicalNamespace:
+		hns := ns.snapshot()
+		return &hns
+	case *TimeNamespace:
+		tns := *ns
+		tns.PlainNamespace = ns.PlainNamespace.snapshot()
+		return &tns
+	case *UTSNamespace:
+		uns := *ns
+		uns.PlainNamespace = ns.PlainNamespace.snapshot()
+		return &uns
+	case *IPCNamespace:
+		ins := *ns
+		ins.PlainNamespace = ns.PlainNamespace.snapshot()
+		return &ins
+	case *CgroupNamespace:
+		cns := *ns
+		cns.PlainNamespace = ns.PlainNamespace.snapshot()
+		return &cns
+	case *NetNamespace:
+		nns := *ns
+		nns.PlainNamespace = ns.PlainNamespace.snapshot()
+		return &nns
+	case *PlainNamespace:
+		pns := ns.snapshot()
+		return &pns
+	}
+	return ns
+}
+
+// snapshot returns a copy of this plain namespace with its own lists of
+// leaders and loose threads.
+func (pns *PlainNamespace) snapshot() PlainNamespace {
+	c := *pns
+	c.leaders = slices.Clone(pns.leaders)
+	c.loosethreads = slices.Clone(pns.loosethreads)
+	return c
+}
+
+// snapshot returns a copy of this hierarchical namespace with its own lists
+// of leaders, loose threads, and children.
+func (hns *HierarchicalNamespace) snapshot() HierarchicalNamespace {
+	c := *hns
+	c.PlainNamespace = hns.PlainNamespace.snapshot()
+	c.children = slices.Clone(hns.children)
+	return c
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package namespaces
+
+import (
+	"github.com/thediveo/lxkns/model"
+)
+
+// TimeNamespace stores the clock offsets of a time namespace in addition to
+// the information for plain namespaces. On top of the interfaces supported by
+// a PlainNamespace, TimeNamespace implements the TimeOffsets interface.
+type TimeNamespace struct {
+	PlainNamespace
+	offsets *model.ClockOffsets
+}
+
+// Ensure that our "class" *does* implement the required interfaces.
+var (
+	_ model.Namespace         = (*TimeNamespace)(nil)
+	_ model.NamespaceStringer = (*TimeNamespace)(nil)
+	_ model.TimeOffsets       = (*TimeNamespace)(nil)
+	_ NamespaceConfigurer     = (*TimeNamespace)(nil)
+	_ TimeConfigurer          = (*TimeNamespace)(nil)
+)
+
+// ClockOffsets returns the offsets of the monotonic and boottime clocks of
+// this time namespace, or nil if unknown.
+func (tns *TimeNamespace) ClockOffsets() *model.ClockOffsets { return tns.offsets }
+
+// SetClockOffsets sets the clock offsets of this time namespace.
+func (tns *TimeNamespace) SetClockOffsets(offsets *model.ClockOffsets) {
+	tns.offsets = offsets
+}
+
+// String describes this instance of a time namespace, including its clock
+// offsets, if known.
+func (tns *TimeNamespace) String() string {
+	if tns.offsets == nil {
+		return tns.PlainNamespace.String()
+	}
+	return tns.PlainNamespace.String() + ", clock offsets " + tns.offsets.String()
+}
+
+// ResolveOwner sets the owning user namespace reference based on the owning
+// user namespace id discovered earlier. Please see also
+// [UserNamespace.ResolveOwner] for why we need to repeat ourselves here.
+func (tns *TimeNamespace) ResolveOwner(usernsmap model.NamespaceMap) {
+	tns.resolveOwner(tns, usernsmap)
+}
@@ -15,8 +15,11 @@ import (
 // hierarchicalNamespace, UserNamespace implements the Ownership interface.
 type UserNamespace struct {
 	HierarchicalNamespace
-	owneruid int
-	ownedns  model.AllNamespaces
+	owneruid  int
+	ownedns   model.AllNamespaces
+	uidmap    model.IDMap
+	gidmap    model.IDMap
+	setgroups model.Setgroups
 }
 
 // Ensure that our "class" *does* implement the required interfaces.
@@ -25,6 +28,7 @@ var (
 	_ model.NamespaceStringer = (*UserNamespace)(nil)
 	_ model.Hierarchy         = (*UserNamespace)(nil)
 	_ model.Ownership         = (*UserNamespace)(nil)
+	_ model.IDMapping         = (*UserNamespace)(nil)
 	_ NamespaceConfigurer     = (*UserNamespace)(nil)
 	_ HierarchyConfigurer     = (*UserNamespace)(nil)
 	_ UserConfigurer          = (*UserNamespace)(nil)
@@ -38,6 +42,18 @@ func (uns *UserNamespace) UID() int { return uns.owneruid }
 // user namespaces, so they are returned through Hierarchy.Children() instead.
 func (uns *UserNamespace) Ownings() model.AllNamespaces { return uns.ownedns }
 
+// UIDMap returns the user ID mapping of this user namespace, or nil if
+// unknown.
+func (uns *UserNamespace) UIDMap() model.IDMap { return uns.uidmap }
+
+// GIDMap returns the group ID mapping of this user namespace, or nil if
+// unknown.
+func (uns *UserNamespace) GIDMap() model.IDMap { return uns.gidmap }
+
+// Setgroups returns whether processes in this user namespace are allowed to
+// call setgroups(2).
+func (uns *UserNamespace) Setgroups() model.Setgroups { return uns.setgroups }
+
 // String describes this instance of a user namespace, with its parent,
 // children, and owned namespaces. This description is non-recursive.
 func (uns *UserNamespace) String() string {
@@ -81,6 +97,14 @@ func (uns *UserNamespace) SetOwnerUID(uid int) {
 	uns.owneruid = uid
 }
 
+// SetIDMaps sets the user and group ID mappings, as well as the setgroups(2)
+// permission state of this user namespace.
+func (uns *UserNamespace) SetIDMaps(uidmap, gidmap model.IDMap, setgroups model.Setgroups) {
+	uns.uidmap = uidmap
+	uns.gidmap = gidmap
+	uns.setgroups = setgroups
+}
+
 // ResolveOwner sets the owning user namespace reference based on the owning
 // user namespace id discovered earlier. Yes, we're repeating us ourselves with
 // this method, because Golang is self-inflicted pain when trying to emulate
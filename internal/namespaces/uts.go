@@ -0,0 +1,69 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package namespaces
+
+import (
+	"strconv"
+
+	"github.com/thediveo/lxkns/model"
+)
+
+// UTSNamespace stores the host and domain names of a UTS namespace in addition
+// to the information for plain namespaces. On top of the interfaces supported
+// by a PlainNamespace, UTSNamespace implements the UTSNames interface.
+type UTSNamespace struct {
+	PlainNamespace
+	nodename   string
+	domainname string
+}
+
+// Ensure that our "class" *does* implement the required interfaces.
+var (
+	_ model.Namespace         = (*UTSNamespace)(nil)
+	_ model.NamespaceStringer = (*UTSNamespace)(nil)
+	_ model.UTSNames          = (*UTSNamespace)(nil)
+	_ NamespaceConfigurer     = (*UTSNamespace)(nil)
+	_ UTSConfigurer           = (*UTSNamespace)(nil)
+)
+
+// Nodename returns the host (node) name of this UTS namespace, or "" if
+// unknown.
+func (uns *UTSNamespace) Nodename() string { return uns.nodename }
+
+// Domainname returns the NIS domain name of this UTS namespace, or "" if
+// unknown.
+func (uns *UTSNamespace) Domainname() string { return uns.domainname }
+
+// SetUTSNames sets the host (node) and NIS domain names of this UTS namespace.
+func (uns *UTSNamespace) SetUTSNames(nodename, domainname string) {
+	uns.nodename = nodename
+	uns.domainname = domainname
+}
+
+// String describes this instance of a UTS namespace, including its host name,
+// if known.
+func (uns *UTSNamespace) String() string {
+	if uns.nodename == "" {
+		return uns.PlainNamespace.String()
+	}
+	return uns.PlainNamespace.String() + ", hostname " + strconv.Quote(uns.nodename)
+}
+
+// ResolveOwner sets the owning user namespace reference based on the owning
+// user namespace id discovered earlier. Please see also
+// [UserNamespace.ResolveOwner] for why we need to repeat ourselves here.
+func (uns *UTSNamespace) ResolveOwner(usernsmap model.NamespaceMap) {
+	uns.resolveOwner(uns, usernsmap)
+}
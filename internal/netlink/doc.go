@@ -0,0 +1,10 @@
+/*
+Package netlink implements the minimal netlink plumbing shared by the routing
+netlink and socket diagnostics clients: netlink sockets, request-response
+exchanges including dumps, and netlink attributes.
+
+A netlink socket of a network namespace-aware netlink protocol, such as
+NETLINK_ROUTE and NETLINK_SOCK_DIAG, is bound to the network namespace it was
+created in.
+*/
+package netlink
@@ -0,0 +1,217 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package netlink
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	nlmsghdrLen   = unix.SizeofNlMsghdr
+	nlattrLen     = unix.SizeofNlAttr
+	maxMessageLen = 32768
+
+	nlaTypeMask = ^uint16(unix.NLA_F_NESTED | unix.NLA_F_NET_BYTEORDER)
+)
+
+// Conn is a netlink socket of a particular netlink protocol.
+type Conn struct {
+	fd  int
+	seq uint32
+	buf []byte
+}
+
+// Dial returns a new netlink connection for the specified netlink protocol,
+// such as unix.NETLINK_ROUTE. For network namespace-aware netlink protocols,
+// the connection is bound to the network namespace of the current OS thread.
+func Dial(protocol int) (*Conn, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK,
+		unix.SOCK_RAW|unix.SOCK_CLOEXEC,
+		protocol)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open netlink socket, %w", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		_ = unix.Close(fd)
+		return nil, fmt.Errorf("cannot bind netlink socket, %w", err)
+	}
+	return &Conn{
+		fd:  fd,
+		buf: make([]byte, maxMessageLen),
+	}, nil
+}
+
+// Close the netlink connection.
+func (c *Conn) Close() error {
+	return unix.Close(c.fd)
+}
+
+// Message is a netlink message received from the kernel.
+type Message struct {
+	Type uint16 // message type, such as RTM_NEWLINK.
+	Data []byte // message payload, without the netlink message header.
+}
+
+// Request sends a netlink request of the specified type, flags, and payload,
+// and then returns the messages received in response. In case of a dump
+// request, Request receives all messages until the end of the dump.
+func (c *Conn) Request(msgtype uint16, flags uint16, payload []byte) ([]Message, error) {
+	c.seq++
+	seq := c.seq
+	req := make([]byte, nlmsghdrLen, nlmsghdrLen+len(payload))
+	binary.NativeEndian.PutUint32(req[0:4], uint32(nlmsghdrLen+len(payload))) // #nosec G115
+	binary.NativeEndian.PutUint16(req[4:6], msgtype)
+	binary.NativeEndian.PutUint16(req[6:8], flags|unix.NLM_F_REQUEST)
+	binary.NativeEndian.PutUint32(req[8:12], seq)
+	req = append(req, payload...)
+	if err := unix.Sendto(c.fd, req, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+	var msgs []Message
+	for {
+		n, _, err := unix.Recvfrom(c.fd, c.buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return nil, err
+		}
+		done, err := parseMessages(c.buf[:n], seq, flags&unix.NLM_F_DUMP != 0, &msgs)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return msgs, nil
+		}
+	}
+}
+
+// parseMessages parses the netlink messages with the specified sequence
+// number, appending them to msgs and ignoring any other messages. It returns
+// true when the response is complete: this is the case for non-dump requests
+// after receiving the first response message, and for dump requests after
+// receiving the final NLMSG_DONE message.
+func parseMessages(b []byte, seq uint32, dump bool, msgs *[]Message) (bool, error) {
+	for len(b) >= nlmsghdrLen {
+		msglen := int(binary.NativeEndian.Uint32(b[0:4]))
+		msgtype := binary.NativeEndian.Uint16(b[4:6])
+		msgseq := binary.NativeEndian.Uint32(b[8:12])
+		if msglen < nlmsghdrLen || msglen > len(b) {
+			return true, fmt.Errorf("invalid netlink message length %d", msglen)
+		}
+		data := b[nlmsghdrLen:msglen]
+		b = b[min(Align(msglen), len(b)):]
+		if msgseq != seq {
+			continue
+		}
+		switch msgtype {
+		case unix.NLMSG_ERROR:
+			if len(data) >= 4 {
+				if errno := int32(binary.NativeEndian.Uint32(data)); errno != 0 { // #nosec G115
+					return true, unix.Errno(-errno)
+				}
+			}
+			return true, nil
+		case unix.NLMSG_DONE:
+			return true, nil
+		case unix.NLMSG_NOOP, unix.NLMSG_OVERRUN:
+			continue
+		}
+		*msgs = append(*msgs, Message{
+			Type: msgtype,
+			Data: append([]byte(nil), data...),
+		})
+		if !dump {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Attribute is a netlink attribute.
+type Attribute struct {
+	Type  uint16 // attribute type, without the nested and byte order flags.
+	Value []byte // attribute value.
+}
+
+// ParseAttributes parses the netlink attributes in the specified buffer.
+func ParseAttributes(b []byte) []Attribute {
+	var attrs []Attribute
+	for len(b) >= nlattrLen {
+		attrlen := int(binary.NativeEndian.Uint16(b[0:2]))
+		attrtype := binary.NativeEndian.Uint16(b[2:4])
+		if attrlen < nlattrLen || attrlen > len(b) {
+			break
+		}
+		attrs = append(attrs, Attribute{
+			Type:  attrtype & nlaTypeMask,
+			Value: b[nlattrLen:attrlen],
+		})
+		b = b[min(Align(attrlen), len(b)):]
+	}
+	return attrs
+}
+
+// String returns the attribute value as a string, with any terminating zero
+// removed.
+func (a Attribute) String() string {
+	return unix.ByteSliceToString(a.Value)
+}
+
+// Uint32 returns the attribute value as an unsigned 32 bit integer, or zero
+// if the value is too short.
+func (a Attribute) Uint32() uint32 {
+	if len(a.Value) < 4 {
+		return 0
+	}
+	return binary.NativeEndian.Uint32(a.Value)
+}
+
+// Int32 returns the attribute value as a signed 32 bit integer, or zero if the
+// value is too short.
+func (a Attribute) Int32() int32 {
+	return int32(a.Uint32()) // #nosec G115
+}
+
+// Uint8 returns the attribute value as an unsigned 8 bit integer, or zero if
+// the value is too short.
+func (a Attribute) Uint8() uint8 {
+	if len(a.Value) < 1 {
+		return 0
+	}
+	return a.Value[0]
+}
+
+// Attr returns a netlink attribute of the specified type and value, including
+// any padding, for use in requests.
+func Attr(attrtype uint16, value []byte) []byte {
+	b := make([]byte, nlattrLen, Align(nlattrLen+len(value)))
+	binary.NativeEndian.PutUint16(b[0:2], uint16(nlattrLen+len(value))) // #nosec G115
+	binary.NativeEndian.PutUint16(b[2:4], attrtype)
+	b = append(b, value...)
+	return b[:cap(b)]
+}
+
+// Align returns the specified length aligned to the netlink message and
+// attribute alignment.
+func Align(l int) int {
+	return (l + unix.NLMSG_ALIGNTO - 1) &^ (unix.NLMSG_ALIGNTO - 1)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package netlink
+
+import (
+	"encoding/binary"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+// netlinkMessage returns a netlink message of the specified type and sequence
+// number, with the specified payload.
+func netlinkMessage(msgtype uint16, seq uint32, payload []byte) []byte {
+	b := make([]byte, nlmsghdrLen, nlmsghdrLen+len(payload))
+	binary.NativeEndian.PutUint32(b[0:4], uint32(nlmsghdrLen+len(payload)))
+	binary.NativeEndian.PutUint16(b[4:6], msgtype)
+	binary.NativeEndian.PutUint32(b[8:12], seq)
+	b = append(b, payload...)
+	for len(b)%unix.NLMSG_ALIGNTO != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+var _ = Describe("netlink", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("dials and closes", func() {
+		conn := Successful(Dial(unix.NETLINK_ROUTE))
+		Expect(conn.Close()).To(Succeed())
+		Expect(Dial(-1)).Error().To(HaveOccurred())
+	})
+
+	It("parses netlink messages", func() {
+		var msgs []Message
+		b := append(netlinkMessage(unix.RTM_NEWLINK, 42, []byte{1, 2, 3}),
+			netlinkMessage(unix.RTM_NEWLINK, 666, []byte{4})...)
+		Expect(parseMessages(b, 42, true, &msgs)).To(BeFalse())
+		Expect(msgs).To(HaveExactElements(Message{Type: unix.RTM_NEWLINK, Data: []byte{1, 2, 3}}))
+
+		Expect(parseMessages(netlinkMessage(unix.NLMSG_DONE, 42, nil), 42, true, &msgs)).To(BeTrue())
+
+		errno := make([]byte, 4)
+		binary.NativeEndian.PutUint32(errno, uint32(0x100000000-int64(unix.EPERM)))
+		_, err := parseMessages(netlinkMessage(unix.NLMSG_ERROR, 42, errno), 42, true, &msgs)
+		Expect(err).To(MatchError(unix.EPERM))
+
+		_, err = parseMessages([]byte{0xff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 42, true, &msgs)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("parses nested attributes", func() {
+		kind := []byte{4 + 5, 0, unix.IFLA_INFO_KIND, 0, 'v', 'e', 't', 'h', 0, 0, 0, 0}
+		linkinfo := append([]byte{byte(4 + len(kind)), 0, unix.IFLA_LINKINFO, unix.NLA_F_NESTED >> 8}, kind...)
+		attrs := ParseAttributes(linkinfo)
+		Expect(attrs).To(HaveLen(1))
+		Expect(attrs[0].Type).To(Equal(uint16(unix.IFLA_LINKINFO)))
+		nested := ParseAttributes(attrs[0].Value)
+		Expect(nested).To(HaveLen(1))
+		Expect(nested[0].String()).To(Equal("veth"))
+		Expect(Attribute{}.Uint32()).To(BeZero())
+		Expect(Attribute{}.Uint8()).To(BeZero())
+	})
+
+	It("builds padded attributes", func() {
+		attr := Attr(unix.IFLA_IFNAME, []byte("lo\x00"))
+		Expect(attr).To(HaveLen(8))
+		attrs := ParseAttributes(append(attr, Attr(unix.IFLA_MTU, []byte{1, 2, 3, 4})...))
+		Expect(attrs).To(HaveLen(2))
+		Expect(attrs[0].String()).To(Equal("lo"))
+		Expect(attrs[1].Type).To(Equal(uint16(unix.IFLA_MTU)))
+		Expect(attrs[1].Value).To(Equal([]byte{1, 2, 3, 4}))
+	})
+
+})
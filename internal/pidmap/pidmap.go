@@ -71,11 +71,17 @@ func (pidmap PIDMap) NamespacedPIDs(pid model.PIDType, from model.Namespace) mod
 // discovered including tasks, then these tasks will be mapped too, similar to
 // what the Linux procfs does using the TIDs as PIDs.
 func NewPIDMap(processes model.ProcessTable) PIDMap {
+	return NewPIDMapInProcfs(processes, "/proc")
+}
+
+// NewPIDMapInProcfs implements [NewPIDMap], but gathers information from the
+// proc filesystem mounted at the specified procroot instead of /proc.
+func NewPIDMapInProcfs(processes model.ProcessTable, procroot string) PIDMap {
 	pidmap := PIDMap{}
 	for _, proc := range processes {
-		pidmap.mapTask(proc.PID, proc.Namespaces[model.PIDNS])
+		pidmap.mapTask(proc.PID, proc.Namespaces[model.PIDNS], procroot)
 		for _, task := range proc.Tasks {
-			pidmap.mapTask(task.TID, task.Namespaces[model.PIDNS])
+			pidmap.mapTask(task.TID, task.Namespaces[model.PIDNS], procroot)
 		}
 	}
 	return pidmap
@@ -83,11 +89,11 @@ func NewPIDMap(processes model.ProcessTable) PIDMap {
 
 // mapTask adds the PID (TID) mappings between different PID namespaces to this
 // PIDMap.
-func (pidmap PIDMap) mapTask(pid model.PIDType, pidns model.Namespace) {
+func (pidmap PIDMap) mapTask(pid model.PIDType, pidns model.Namespace, procroot string) {
 	// For each process, first get its list of namespaced PIDs, which
 	// lists the PIDs starting from the PID namespace we're currently in
 	// and continues into nested child PID namespaces.
-	pids := NSpid(pid)
+	pids := nspid(pid, procroot)
 	if pidns == nil {
 		return
 	}
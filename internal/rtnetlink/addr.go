@@ -0,0 +1,87 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package rtnetlink
+
+import (
+	"encoding/binary"
+	"net/netip"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+)
+
+// Address describes an IPv4 or IPv6 address assigned to a network interface.
+type Address struct {
+	Index  int          // index of the network interface the address is assigned to.
+	Prefix netip.Prefix // address together with its prefix length.
+	Scope  uint8        // address scope, such as unix.RT_SCOPE_UNIVERSE.
+}
+
+// Addresses returns the IPv4 and IPv6 addresses of the network interfaces of
+// the network namespace this routing netlink connection is bound to.
+func (c *Conn) Addresses() ([]Address, error) {
+	req := make([]byte, unix.SizeofIfAddrmsg)
+	req[0] = unix.AF_UNSPEC
+	msgs, err := c.Request(unix.RTM_GETADDR, unix.NLM_F_DUMP, req)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]Address, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Type != unix.RTM_NEWADDR {
+			continue
+		}
+		if addr, ok := parseAddress(msg.Data); ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil
+}
+
+// parseAddress parses the payload of an RTM_NEWADDR message, consisting of an
+// ifaddrmsg followed by netlink attributes.
+func parseAddress(b []byte) (Address, bool) {
+	if len(b) < unix.SizeofIfAddrmsg {
+		return Address{}, false
+	}
+	prefixlen := int(b[1])
+	addr := Address{
+		Scope: b[3],
+		Index: int(binary.NativeEndian.Uint32(b[4:8])),
+	}
+	// For point-to-point interfaces, IFA_ADDRESS is the address of the
+	// remote end, while IFA_LOCAL is the local address; otherwise, both are
+	// the same, if IFA_LOCAL is present at all.
+	var local, address netip.Addr
+	for _, attr := range netlink.ParseAttributes(b[unix.SizeofIfAddrmsg:]) {
+		switch attr.Type {
+		case unix.IFA_LOCAL:
+			local, _ = netip.AddrFromSlice(attr.Value)
+		case unix.IFA_ADDRESS:
+			address, _ = netip.AddrFromSlice(attr.Value)
+		}
+	}
+	if local.IsValid() {
+		address = local
+	}
+	if !address.IsValid() {
+		return Address{}, false
+	}
+	addr.Prefix = netip.PrefixFrom(address, prefixlen)
+	return addr, true
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package rtnetlink
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+)
+
+// Conn is a routing netlink socket bound to the network namespace it was
+// created in.
+type Conn struct {
+	*netlink.Conn
+}
+
+// Dial returns a new routing netlink connection to the network namespace of
+// the current OS thread.
+func Dial() (*Conn, error) {
+	conn, err := netlink.Dial(unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{Conn: conn}, nil
+}
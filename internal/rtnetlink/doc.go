@@ -0,0 +1,13 @@
+/*
+Package rtnetlink implements a minimal routing netlink (“rtnetlink”) client
+for dumping the network interfaces and their addresses of a network namespace,
+as well as the identifiers (“nsids”) it assigned to peer network namespaces.
+
+A routing netlink socket is bound to the network namespace it was created in.
+Callers thus need to create a [Conn] while the current OS thread is attached
+to the network namespace to dump, such as from within [ops.Execute]. Once
+created, a [Conn] can be used from any OS thread.
+
+[ops.Execute]: https://pkg.go.dev/github.com/thediveo/lxkns/ops#Execute
+*/
+package rtnetlink
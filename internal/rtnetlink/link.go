@@ -0,0 +1,100 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package rtnetlink
+
+import (
+	"encoding/binary"
+	"net"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+)
+
+// Link describes a network interface (“link”) of a network namespace.
+type Link struct {
+	Index        int              // interface index.
+	Name         string           // interface name.
+	Kind         string           // kind of interface, such as "veth", "bridge", "macvlan", or "" for physical and loopback interfaces.
+	HardwareAddr net.HardwareAddr // hardware (MAC) address, if any.
+	MTU          uint32           // maximum transmission unit.
+	OperState    uint8            // RFC 2863 operational state, such as unix.IF_OPER_UP.
+	Flags        uint32           // interface flags, such as unix.IFF_UP.
+	ParentIndex  int              // index of the veth peer or lower interface, if any; see also LinkNetNSID.
+	LinkNetNSID  int32            // nsid of the network namespace of the parent interface, or unix.NETNSA_NSID_NOT_ASSIGNED if in the same network namespace.
+	MasterIndex  int              // index of the master interface, such as a bridge or bond, if any.
+}
+
+// Links returns the network interfaces of the network namespace this routing
+// netlink connection is bound to.
+func (c *Conn) Links() ([]Link, error) {
+	req := make([]byte, unix.SizeofIfInfomsg)
+	req[0] = unix.AF_UNSPEC
+	msgs, err := c.Request(unix.RTM_GETLINK, unix.NLM_F_DUMP, req)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]Link, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Type != unix.RTM_NEWLINK {
+			continue
+		}
+		if link, ok := parseLink(msg.Data); ok {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+// parseLink parses the payload of an RTM_NEWLINK message, consisting of an
+// ifinfomsg followed by netlink attributes.
+func parseLink(b []byte) (Link, bool) {
+	if len(b) < unix.SizeofIfInfomsg {
+		return Link{}, false
+	}
+	link := Link{
+		Index: int(int32(binary.NativeEndian.Uint32(b[4:8]))), // #nosec G115
+		Flags: binary.NativeEndian.Uint32(b[8:12]),
+
+		LinkNetNSID: unix.NETNSA_NSID_NOT_ASSIGNED,
+	}
+	for _, attr := range netlink.ParseAttributes(b[unix.SizeofIfInfomsg:]) {
+		switch attr.Type {
+		case unix.IFLA_IFNAME:
+			link.Name = attr.String()
+		case unix.IFLA_ADDRESS:
+			link.HardwareAddr = net.HardwareAddr(attr.Value)
+		case unix.IFLA_MTU:
+			link.MTU = attr.Uint32()
+		case unix.IFLA_OPERSTATE:
+			link.OperState = attr.Uint8()
+		case unix.IFLA_LINK:
+			link.ParentIndex = int(attr.Int32())
+		case unix.IFLA_LINK_NETNSID:
+			link.LinkNetNSID = attr.Int32()
+		case unix.IFLA_MASTER:
+			link.MasterIndex = int(attr.Int32())
+		case unix.IFLA_LINKINFO:
+			for _, info := range netlink.ParseAttributes(attr.Value) {
+				if info.Type == unix.IFLA_INFO_KIND {
+					link.Kind = info.String()
+				}
+			}
+		}
+	}
+	return link, true
+}
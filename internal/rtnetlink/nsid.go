@@ -0,0 +1,86 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package rtnetlink
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+)
+
+// rtgenmsgLen is the length of a struct rtgenmsg, including its padding.
+const rtgenmsgLen = 4
+
+// NetNSIDs returns the network namespace identifiers (“nsids”) assigned in
+// the network namespace this routing netlink connection is bound to. These
+// nsids identify peer network namespaces, such as those of veth peers.
+func (c *Conn) NetNSIDs() ([]int32, error) {
+	req := make([]byte, rtgenmsgLen)
+	req[0] = unix.AF_UNSPEC
+	msgs, err := c.Request(unix.RTM_GETNSID, unix.NLM_F_DUMP, req)
+	if err != nil {
+		return nil, err
+	}
+	nsids := make([]int32, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Type != unix.RTM_NEWNSID {
+			continue
+		}
+		if nsid := parseNetNSID(msg.Data); nsid != unix.NETNSA_NSID_NOT_ASSIGNED {
+			nsids = append(nsids, nsid)
+		}
+	}
+	return nsids, nil
+}
+
+// NetNSIDOf returns the nsid assigned to the network namespace referenced by
+// the specified file descriptor, as seen from the network namespace this
+// routing netlink connection is bound to. It returns
+// unix.NETNSA_NSID_NOT_ASSIGNED if there is no nsid assigned; NetNSIDOf never
+// assigns new nsids.
+func (c *Conn) NetNSIDOf(fd int) (int32, error) {
+	req := make([]byte, rtgenmsgLen, rtgenmsgLen+unix.SizeofNlAttr+4)
+	req[0] = unix.AF_UNSPEC
+	req = append(req, netlink.Attr(unix.NETNSA_FD,
+		binary.NativeEndian.AppendUint32(nil, uint32(fd)))...) // #nosec G115
+	msgs, err := c.Request(unix.RTM_GETNSID, 0, req)
+	if err != nil {
+		return unix.NETNSA_NSID_NOT_ASSIGNED, err
+	}
+	for _, msg := range msgs {
+		if msg.Type == unix.RTM_NEWNSID {
+			return parseNetNSID(msg.Data), nil
+		}
+	}
+	return unix.NETNSA_NSID_NOT_ASSIGNED, nil
+}
+
+// parseNetNSID parses the payload of an RTM_NEWNSID message, consisting of an
+// rtgenmsg followed by netlink attributes, returning the NETNSA_NSID value.
+func parseNetNSID(b []byte) int32 {
+	if len(b) < rtgenmsgLen {
+		return unix.NETNSA_NSID_NOT_ASSIGNED
+	}
+	for _, attr := range netlink.ParseAttributes(b[rtgenmsgLen:]) {
+		if attr.Type == unix.NETNSA_NSID && len(attr.Value) >= 4 {
+			return attr.Int32()
+		}
+	}
+	return unix.NETNSA_NSID_NOT_ASSIGNED
+}
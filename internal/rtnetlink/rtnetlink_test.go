@@ -0,0 +1,109 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package rtnetlink
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"runtime"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("routing netlink", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("parses links and nsids", func() {
+		attr := func(typ uint16, value uint32) []byte {
+			return netlink.Attr(typ, binary.NativeEndian.AppendUint32(nil, value))
+		}
+		ifinfomsg := make([]byte, unix.SizeofIfInfomsg)
+		binary.NativeEndian.PutUint32(ifinfomsg[4:8], 42)
+		b := append(ifinfomsg, attr(unix.IFLA_LINK, 666)...)
+		b = append(b, attr(unix.IFLA_MASTER, 7)...)
+		link, ok := parseLink(b)
+		Expect(ok).To(BeTrue())
+		Expect(link).To(And(
+			HaveField("Index", 42),
+			HaveField("ParentIndex", 666),
+			HaveField("LinkNetNSID", int32(unix.NETNSA_NSID_NOT_ASSIGNED)),
+			HaveField("MasterIndex", 7),
+		))
+		link, ok = parseLink(append(b, attr(unix.IFLA_LINK_NETNSID, 1)...))
+		Expect(ok).To(BeTrue())
+		Expect(link.LinkNetNSID).To(Equal(int32(1)))
+
+		Expect(parseNetNSID(append(make([]byte, rtgenmsgLen), attr(unix.NETNSA_NSID, 3)...))).
+			To(Equal(int32(3)))
+		Expect(parseNetNSID(make([]byte, rtgenmsgLen))).To(Equal(int32(unix.NETNSA_NSID_NOT_ASSIGNED)))
+		Expect(parseNetNSID(nil)).To(Equal(int32(unix.NETNSA_NSID_NOT_ASSIGNED)))
+	})
+
+	It("dumps the links and addresses of the current network namespace", func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		conn := Successful(Dial())
+		defer func() { _ = conn.Close() }()
+
+		links := Successful(conn.Links())
+		Expect(links).To(ContainElement(And(
+			HaveField("Index", 1),
+			HaveField("Name", "lo"),
+			HaveField("Kind", ""),
+			HaveField("MTU", Not(BeZero())),
+		)))
+		addrs := Successful(conn.Addresses())
+		Expect(addrs).To(ContainElement(Address{
+			Index:  1,
+			Prefix: netip.MustParsePrefix("127.0.0.1/8"),
+			Scope:  unix.RT_SCOPE_HOST,
+		}))
+	})
+
+	It("queries nsids", func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		conn := Successful(Dial())
+		defer func() { _ = conn.Close() }()
+
+		Expect(conn.NetNSIDs()).Error().NotTo(HaveOccurred())
+
+		netns := Successful(os.Open("/proc/thread-self/ns/net"))
+		defer func() { _ = netns.Close() }()
+		Expect(conn.NetNSIDOf(int(netns.Fd()))).To(BeNumerically(">=", unix.NETNSA_NSID_NOT_ASSIGNED))
+		_, err := conn.NetNSIDOf(-1)
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,13 @@
+/*
+Package sockdiag implements a minimal socket diagnostics (“sock_diag”) netlink
+client for dumping the TCP, UDP, UNIX domain, and packet sockets of a network
+namespace.
+
+A sock_diag netlink socket is bound to the network namespace it was created
+in and only reports the sockets of this network namespace. Callers thus need
+to create a [Conn] while the current OS thread is attached to the network
+namespace to dump, such as from within [ops.Execute].
+
+[ops.Execute]: https://pkg.go.dev/github.com/thediveo/lxkns/ops#Execute
+*/
+package sockdiag
@@ -0,0 +1,227 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package sockdiag
+
+import (
+	"encoding/binary"
+	"net/netip"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+)
+
+// Sizes of the sock_diag request and response message headers, see
+// include/uapi/linux/inet_diag.h, unix_diag.h, and packet_diag.h.
+const (
+	inetDiagReqV2Len = 56
+	inetDiagMsgLen   = 72
+	unixDiagReqLen   = 24
+	unixDiagMsgLen   = 16
+	packetDiagReqLen = 20
+	packetDiagMsgLen = 16
+)
+
+// What to show (and request) about UNIX domain and packet sockets.
+const (
+	udiagShowName  = 0x01
+	udiagShowVFS   = 0x02
+	udiagShowPeer  = 0x04
+	packetShowInfo = 0x01
+)
+
+// Attributes of UNIX domain and packet socket diagnostic responses.
+const (
+	unixDiagName   = 0
+	unixDiagVFS    = 1
+	unixDiagPeer   = 2
+	packetDiagInfo = 0
+)
+
+// allStates requests sockets in any (TCP-style) state.
+const allStates = ^uint32(0)
+
+// Conn is a socket diagnostics netlink socket bound to the network namespace
+// it was created in.
+type Conn struct {
+	*netlink.Conn
+}
+
+// Dial returns a new socket diagnostics netlink connection to the network
+// namespace of the current OS thread.
+func Dial() (*Conn, error) {
+	conn, err := netlink.Dial(unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{Conn: conn}, nil
+}
+
+// Socket describes a socket of a network namespace. Which fields are set
+// depends on the socket's address family.
+type Socket struct {
+	Family   uint8  // address family, such as unix.AF_INET6 or unix.AF_UNIX.
+	Protocol uint8  // unix.IPPROTO_TCP or unix.IPPROTO_UDP for IP sockets, otherwise zero.
+	Type     uint8  // socket type of UNIX domain and packet sockets, such as unix.SOCK_STREAM.
+	State    uint8  // TCP-style socket state, such as unix.BPF_TCP_LISTEN; zero for packet sockets.
+	Inode    uint32 // socket inode number.
+
+	Local  netip.AddrPort // IP sockets only: local address and port.
+	Remote netip.AddrPort // IP sockets only: remote address and port.
+	UID    uint32         // IP sockets only: owner's user ID.
+
+	Path      string // UNIX domain sockets only: bound path, with abstract names starting with "@".
+	Peer      uint32 // UNIX domain sockets only: inode number of the connected peer socket, if any.
+	VFSInode  uint64 // UNIX domain sockets only: inode number of the bound socket file, if any.
+	VFSDev    uint64 // UNIX domain sockets only: device number of the bound socket file, as in stat(2).
+	Ifindex   int    // packet sockets only: index of the bound network interface, if any.
+	EtherType uint16 // packet sockets only: protocol in host byte order, such as unix.ETH_P_ALL.
+}
+
+// InetSockets returns the IP sockets of the specified address family
+// (unix.AF_INET or unix.AF_INET6) and protocol (unix.IPPROTO_TCP or
+// unix.IPPROTO_UDP) in any state.
+func (c *Conn) InetSockets(family, protocol uint8) ([]Socket, error) {
+	req := make([]byte, inetDiagReqV2Len)
+	req[0] = family
+	req[1] = protocol
+	binary.NativeEndian.PutUint32(req[4:8], allStates)
+	return c.sockets(req, func(b []byte) (Socket, bool) { return parseInetSocket(b, protocol) })
+}
+
+// UnixSockets returns the UNIX domain sockets in any state.
+func (c *Conn) UnixSockets() ([]Socket, error) {
+	req := make([]byte, unixDiagReqLen)
+	req[0] = unix.AF_UNIX
+	binary.NativeEndian.PutUint32(req[4:8], allStates)
+	binary.NativeEndian.PutUint32(req[12:16], udiagShowName|udiagShowVFS|udiagShowPeer)
+	return c.sockets(req, parseUnixSocket)
+}
+
+// PacketSockets returns the packet sockets.
+func (c *Conn) PacketSockets() ([]Socket, error) {
+	req := make([]byte, packetDiagReqLen)
+	req[0] = unix.AF_PACKET
+	binary.NativeEndian.PutUint32(req[8:12], packetShowInfo)
+	return c.sockets(req, parsePacketSocket)
+}
+
+// sockets sends the specified sock_diag dump request and returns the sockets
+// parsed from the responses using the specified parse function.
+func (c *Conn) sockets(req []byte, parse func([]byte) (Socket, bool)) ([]Socket, error) {
+	msgs, err := c.Request(unix.SOCK_DIAG_BY_FAMILY, unix.NLM_F_DUMP, req)
+	if err != nil {
+		return nil, err
+	}
+	socks := make([]Socket, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Type != unix.SOCK_DIAG_BY_FAMILY {
+			continue
+		}
+		if sock, ok := parse(msg.Data); ok {
+			socks = append(socks, sock)
+		}
+	}
+	return socks, nil
+}
+
+// parseInetSocket parses an inet_diag_msg of the specified protocol.
+func parseInetSocket(b []byte, protocol uint8) (Socket, bool) {
+	if len(b) < inetDiagMsgLen {
+		return Socket{}, false
+	}
+	sock := Socket{
+		Family:   b[0],
+		Protocol: protocol,
+		State:    b[1],
+		UID:      binary.NativeEndian.Uint32(b[64:68]),
+		Inode:    binary.NativeEndian.Uint32(b[68:72]),
+	}
+	// struct inet_diag_sockid starts at offset 4, with the ports and
+	// addresses in network byte order.
+	sport := binary.BigEndian.Uint16(b[4:6])
+	dport := binary.BigEndian.Uint16(b[6:8])
+	var src, dst netip.Addr
+	switch sock.Family {
+	case unix.AF_INET:
+		src = netip.AddrFrom4([4]byte(b[8:12]))
+		dst = netip.AddrFrom4([4]byte(b[24:28]))
+	case unix.AF_INET6:
+		src = netip.AddrFrom16([16]byte(b[8:24]))
+		dst = netip.AddrFrom16([16]byte(b[24:40]))
+	default:
+		return Socket{}, false
+	}
+	sock.Local = netip.AddrPortFrom(src, sport)
+	sock.Remote = netip.AddrPortFrom(dst, dport)
+	return sock, true
+}
+
+// parseUnixSocket parses a unix_diag_msg followed by netlink attributes.
+func parseUnixSocket(b []byte) (Socket, bool) {
+	if len(b) < unixDiagMsgLen {
+		return Socket{}, false
+	}
+	sock := Socket{
+		Family: b[0],
+		Type:   b[1],
+		State:  b[2],
+		Inode:  binary.NativeEndian.Uint32(b[4:8]),
+	}
+	for _, attr := range netlink.ParseAttributes(b[unixDiagMsgLen:]) {
+		switch attr.Type {
+		case unixDiagName:
+			if len(attr.Value) > 0 && attr.Value[0] == 0 {
+				sock.Path = "@" + string(attr.Value[1:])
+			} else {
+				sock.Path = attr.String()
+			}
+		case unixDiagVFS:
+			// struct unix_diag_vfs consists of the inode number, followed
+			// by the device number in its kernel-internal encoding with a
+			// 12 bit major and 20 bit minor number, which we convert into
+			// the user space encoding used by stat(2).
+			if len(attr.Value) >= 8 {
+				dev := binary.NativeEndian.Uint32(attr.Value[4:8])
+				sock.VFSInode = uint64(binary.NativeEndian.Uint32(attr.Value[0:4]))
+				sock.VFSDev = unix.Mkdev(dev>>20, dev&0xfffff)
+			}
+		case unixDiagPeer:
+			sock.Peer = attr.Uint32()
+		}
+	}
+	return sock, true
+}
+
+// parsePacketSocket parses a packet_diag_msg followed by netlink attributes.
+func parsePacketSocket(b []byte) (Socket, bool) {
+	if len(b) < packetDiagMsgLen {
+		return Socket{}, false
+	}
+	sock := Socket{
+		Family:    b[0],
+		Type:      b[1],
+		EtherType: binary.NativeEndian.Uint16(b[2:4]), // kernel already converts to host byte order.
+		Inode:     binary.NativeEndian.Uint32(b[4:8]),
+	}
+	for _, attr := range netlink.ParseAttributes(b[packetDiagMsgLen:]) {
+		if attr.Type == packetDiagInfo {
+			sock.Ifindex = int(attr.Int32())
+		}
+	}
+	return sock, true
+}
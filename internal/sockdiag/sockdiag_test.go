@@ -0,0 +1,190 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package sockdiag
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/internal/netlink"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+// inodeOf returns the socket inode number of the specified file descriptor.
+func inodeOf(fd uintptr) uint32 {
+	GinkgoHelper()
+	var stat unix.Stat_t
+	Expect(unix.Fstat(int(fd), &stat)).To(Succeed())
+	return uint32(stat.Ino)
+}
+
+// rawconnInodeOf returns the socket inode number of the specified connection.
+func rawconnInodeOf(conn syscall.Conn) uint32 {
+	GinkgoHelper()
+	rawconn := Successful(conn.SyscallConn())
+	var ino uint32
+	Expect(rawconn.Control(func(fd uintptr) { ino = inodeOf(fd) })).To(Succeed())
+	return ino
+}
+
+var _ = Describe("socket diagnostics", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("parses socket diagnostics messages", func() {
+		_, ok := parseInetSocket(nil, unix.IPPROTO_TCP)
+		Expect(ok).To(BeFalse())
+		msg := make([]byte, inetDiagMsgLen)
+		msg[0] = unix.AF_INET
+		msg[1] = unix.BPF_TCP_LISTEN
+		binary.BigEndian.PutUint16(msg[4:6], 8080)
+		copy(msg[8:12], []byte{127, 0, 0, 1})
+		binary.NativeEndian.PutUint32(msg[68:72], 42)
+		sock, ok := parseInetSocket(msg, unix.IPPROTO_TCP)
+		Expect(ok).To(BeTrue())
+		Expect(sock).To(Equal(Socket{
+			Family:   unix.AF_INET,
+			Protocol: unix.IPPROTO_TCP,
+			State:    unix.BPF_TCP_LISTEN,
+			Inode:    42,
+			Local:    netip.MustParseAddrPort("127.0.0.1:8080"),
+			Remote:   netip.MustParseAddrPort("0.0.0.0:0"),
+		}))
+		msg[0] = unix.AF_UNIX
+		_, ok = parseInetSocket(msg, unix.IPPROTO_TCP)
+		Expect(ok).To(BeFalse())
+
+		msg = make([]byte, unixDiagMsgLen)
+		msg[0] = unix.AF_UNIX
+		msg[1] = unix.SOCK_STREAM
+		binary.NativeEndian.PutUint32(msg[4:8], 42)
+		msg = append(msg, netlink.Attr(unixDiagName, []byte("\x00abstract"))...)
+		msg = append(msg, netlink.Attr(unixDiagPeer, binary.NativeEndian.AppendUint32(nil, 666))...)
+		msg = append(msg, netlink.Attr(unixDiagVFS, binary.NativeEndian.AppendUint32(
+			binary.NativeEndian.AppendUint32(nil, 123), 8<<20|1))...)
+		sock, ok = parseUnixSocket(msg)
+		Expect(ok).To(BeTrue())
+		Expect(sock).To(And(
+			HaveField("Type", uint8(unix.SOCK_STREAM)),
+			HaveField("Inode", uint32(42)),
+			HaveField("Path", "@abstract"),
+			HaveField("Peer", uint32(666)),
+			HaveField("VFSInode", uint64(123)),
+			HaveField("VFSDev", unix.Mkdev(8, 1)),
+		))
+		_, ok = parseUnixSocket(nil)
+		Expect(ok).To(BeFalse())
+
+		msg = make([]byte, packetDiagMsgLen)
+		msg[0] = unix.AF_PACKET
+		msg[1] = unix.SOCK_RAW
+		binary.NativeEndian.PutUint16(msg[2:4], unix.ETH_P_ALL)
+		msg = append(msg, netlink.Attr(packetDiagInfo, binary.NativeEndian.AppendUint32(make([]byte, 0, 24), 7))...)
+		sock, ok = parsePacketSocket(msg)
+		Expect(ok).To(BeTrue())
+		Expect(sock).To(And(
+			HaveField("Type", uint8(unix.SOCK_RAW)),
+			HaveField("EtherType", uint16(unix.ETH_P_ALL)),
+			HaveField("Ifindex", 7),
+		))
+		_, ok = parsePacketSocket(nil)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("dumps the sockets of the current network namespace", func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		conn := Successful(Dial())
+		defer func() { _ = conn.Close() }()
+
+		tcp := Successful(net.Listen("tcp4", "127.0.0.1:0"))
+		defer func() { _ = tcp.Close() }()
+		socks := Successful(conn.InetSockets(unix.AF_INET, unix.IPPROTO_TCP))
+		Expect(socks).To(ContainElement(And(
+			HaveField("Inode", rawconnInodeOf(tcp.(*net.TCPListener))),
+			HaveField("State", uint8(unix.BPF_TCP_LISTEN)),
+			HaveField("Local", netip.MustParseAddrPort(tcp.Addr().String())),
+		)))
+
+		sockpath := filepath.Join(GinkgoT().TempDir(), "lxkns.sock")
+		unixl := Successful(net.Listen("unix", sockpath))
+		defer func() { _ = unixl.Close() }()
+		unixc := Successful(net.Dial("unix", sockpath))
+		defer func() { _ = unixc.Close() }()
+		unixs := Successful(unixl.Accept())
+		defer func() { _ = unixs.Close() }()
+		var st unix.Stat_t
+		Expect(unix.Stat(sockpath, &st)).To(Succeed())
+		socks = Successful(conn.UnixSockets())
+		Expect(socks).To(ContainElement(And(
+			HaveField("Inode", rawconnInodeOf(unixl.(*net.UnixListener))),
+			HaveField("Type", uint8(unix.SOCK_STREAM)),
+			HaveField("Path", sockpath),
+			HaveField("VFSInode", st.Ino),
+			HaveField("VFSDev", st.Dev),
+		)))
+		clientino := rawconnInodeOf(unixc.(*net.UnixConn))
+		Expect(socks).To(ContainElement(And(
+			HaveField("Inode", clientino),
+			HaveField("Peer", Not(BeZero())),
+		)))
+		// the accepted server-side end of the connection reports the same
+		// bound socket file as the listening socket.
+		Expect(socks).To(ContainElement(And(
+			HaveField("Peer", clientino),
+			HaveField("VFSInode", st.Ino),
+		)))
+
+		if os.Geteuid() != 0 {
+			return
+		}
+		// socket(2) expects the protocol in network byte order.
+		proto := binary.NativeEndian.Uint16(binary.BigEndian.AppendUint16(nil, unix.ETH_P_ALL))
+		fd := Successful(unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(proto)))
+		defer func() { _ = unix.Close(fd) }()
+		socks, err := conn.PacketSockets()
+		if errors.Is(err, unix.ENOENT) {
+			Skip("packet socket diagnostics not supported")
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(socks).To(ContainElement(And(
+			HaveField("Inode", inodeOf(uintptr(fd))),
+			HaveField("EtherType", uint16(unix.ETH_P_ALL)),
+		)))
+	})
+
+})
@@ -0,0 +1,116 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/thediveo/caps"
+)
+
+// Capabilities are the capability sets of a process or task, as read from
+// “/proc/[PID]/status”. The capabilities in each set are given by their lower
+// case names, such as “cap_sys_admin”, in lexicographic order.
+type Capabilities struct {
+	Effective   []string `json:"effective"`         // capabilities checked by the kernel.
+	Permitted   []string `json:"permitted"`         // capabilities that may become effective.
+	Inheritable []string `json:"inheritable"`       // capabilities preserved across execve(2).
+	Bounding    []string `json:"bounding"`          // limiting superset of capabilities.
+	Ambient     []string `json:"ambient,omitempty"` // capabilities preserved across execve(2) of unprivileged programs; not on older kernels.
+}
+
+// ReadCapabilities reads the capability sets from the specified process or
+// task status file, such as “/proc/self/status”.
+func ReadCapabilities(path string) (*Capabilities, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseCapabilities(f)
+}
+
+// ParseCapabilities parses the capability sets in the textual format of
+// “/proc/[PID]/status”, ignoring all other fields. It returns an error if the
+// effective capabilities are missing or any capability set is invalid.
+func ParseCapabilities(r io.Reader) (*Capabilities, error) {
+	capabilities := &Capabilities{}
+	sets := map[string]*[]string{
+		"CapInh": &capabilities.Inheritable,
+		"CapPrm": &capabilities.Permitted,
+		"CapEff": &capabilities.Effective,
+		"CapBnd": &capabilities.Bounding,
+		"CapAmb": &capabilities.Ambient,
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		field, value, ok := strings.Cut(scanner.Text(), ":\t")
+		if !ok {
+			continue
+		}
+		set, ok := sets[field]
+		if !ok {
+			continue
+		}
+		capset, err := caps.CapabilitiesFromHex(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s capabilities %q, %w", field, value, err)
+		}
+		names := capset.SortedNames()
+		for idx := range names {
+			names[idx] = strings.ToLower(names[idx])
+		}
+		*set = names
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if capabilities.Effective == nil {
+		return nil, errors.New("missing effective capabilities")
+	}
+	return capabilities, nil
+}
+
+// HasEffective returns true if the specified capability is in the effective
+// set, where the capability name is case-insensitive, such as “CAP_SYS_ADMIN”
+// or “cap_sys_admin”.
+func (c *Capabilities) HasEffective(capname string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.Contains(c.Effective, strings.ToLower(capname))
+}
+
+// RetrieveCapabilities updates this Process object's capability sets from the
+// proc filesystem mounted at procroot, returning nil when successful.
+// Otherwise, it returns an error.
+func (p *Process) RetrieveCapabilities(procroot string) error {
+	return p.RetrieveStatus(procroot, StatusCapabilities)
+}
+
+// RetrieveCapabilities updates this Task object's capability sets from the
+// proc filesystem mounted at procroot, returning nil when successful.
+// Otherwise, it returns an error.
+func (t *Task) RetrieveCapabilities(procroot string) error {
+	return t.RetrieveStatus(procroot, StatusCapabilities)
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("capabilities", func() {
+
+	It("rejects status without effective capabilities", func() {
+		Expect(ReadCapabilities("test/capabilities/status-bad")).Error().To(
+			MatchError(ContainSubstring("missing effective capabilities")))
+	})
+
+	It("rejects corrupt capabilities", func() {
+		Expect(ReadCapabilities("test/capabilities/status-corrupt")).Error().To(
+			MatchError(ContainSubstring("invalid CapEff capabilities")))
+	})
+
+	It("reads non-existing status", func() {
+		Expect(ReadCapabilities("test/capabilities/status-missing")).Error().To(HaveOccurred())
+	})
+
+	It("reads capability sets", func() {
+		capabilities, err := ReadCapabilities("test/capabilities/status-good")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capabilities.Effective).To(HaveLen(38))
+		Expect(capabilities.Effective).To(ContainElement("cap_sys_admin"))
+		Expect(capabilities.Bounding).To(Equal(capabilities.Effective))
+		Expect(capabilities.Permitted).To(BeNil())
+		Expect(capabilities.Ambient).To(BeNil())
+
+		capabilities, err = ReadCapabilities("test/capabilities/status-container")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capabilities.Effective).To(ConsistOf(
+			"cap_chown", "cap_dac_override", "cap_fowner", "cap_fsetid",
+			"cap_kill", "cap_setgid", "cap_setuid", "cap_setpcap",
+			"cap_net_bind_service", "cap_net_raw", "cap_sys_chroot",
+			"cap_mknod", "cap_audit_write", "cap_setfcap"))
+		Expect(capabilities.Permitted).To(Equal(capabilities.Effective))
+		Expect(capabilities.Inheritable).To(BeEmpty())
+		Expect(capabilities.Ambient).To(ConsistOf("cap_net_bind_service"))
+		Expect(capabilities.HasEffective("CAP_SYS_ADMIN")).To(BeFalse())
+		Expect(capabilities.HasEffective("CAP_NET_RAW")).To(BeTrue())
+		Expect((*Capabilities)(nil).HasEffective("cap_net_raw")).To(BeFalse())
+
+		j, err := json.Marshal(capabilities)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(ContainSubstring(`"inheritable":[]`))
+		Expect(string(j)).To(ContainSubstring(`"ambient":["cap_net_bind_service"]`))
+	})
+
+	It("retrieves capabilities of processes and tasks", func() {
+		proc := NewProcess(PIDType(os.Getpid()), true)
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Capabilities).To(BeNil())
+		Expect(proc.RetrieveCapabilities("/proc")).To(Succeed())
+		Expect(proc.Capabilities).NotTo(BeNil())
+		Expect(proc.Capabilities.Bounding).NotTo(BeEmpty())
+		Expect(proc.Capabilities.Effective).To(HaveEach(HavePrefix("cap_")))
+
+		Expect(proc.Tasks).NotTo(BeEmpty())
+		task := proc.Tasks[0]
+		Expect(task.RetrieveCapabilities("/proc")).To(Succeed())
+		Expect(task.Capabilities).To(Equal(proc.Capabilities))
+
+		Expect((&Process{PID: -1}).RetrieveCapabilities("/proc")).NotTo(Succeed())
+	})
+
+})
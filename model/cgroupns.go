@@ -0,0 +1,34 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// CgroupNamespaceRoot informs about where the root of a cgroup namespace lies
+// in the cgroup hierarchy. Only cgroup namespaces provide and implement
+// CgroupNamespaceRoot.
+//
+// The root is determined by comparing the control group of a leader process of
+// the cgroup namespace as seen from the outside with how it looks from inside
+// the cgroup namespace. The root is thus unknown for cgroup namespaces without
+// processes, or when the discovery couldn't switch into a cgroup namespace.
+type CgroupNamespaceRoot interface {
+	// CgroupRoot returns the path of the root of this cgroup namespace in the
+	// cgroup hierarchy, such as “/system.slice/docker-1234.scope”, or "" if
+	// unknown. The path is relative to the cgroup namespace of the
+	// discovering process, which usually is the initial cgroup namespace. In
+	// case of cgroups v2, the cgroup namespace root of a container thus
+	// corresponds with “/sys/fs/cgroup/system.slice/docker-1234.scope” on the
+	// host.
+	CgroupRoot() string
+}
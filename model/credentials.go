@@ -0,0 +1,176 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Credentials are the user and group credentials of a process or task,
+// together with its seccomp mode and no_new_privs flag, as read from
+// “/proc/[PID]/status”.
+//
+// Please note that the user and group IDs are relative to the user namespace
+// of the process that read the credentials; use an [IDMapper] to translate
+// them into other user namespaces.
+type Credentials struct {
+	UIDs           CredentialIDs `json:"uids"`                      // real, effective, saved, and filesystem user IDs.
+	GIDs           CredentialIDs `json:"gids"`                      // real, effective, saved, and filesystem group IDs.
+	Groups         []uint32      `json:"groups"`                    // supplementary group IDs.
+	NoNewPrivs     bool          `json:"no-new-privs"`              // execve(2) never grants privileges.
+	Seccomp        SeccompMode   `json:"seccomp,omitempty"`         // seccomp mode; unknown without kernel seccomp support.
+	SeccompFilters int           `json:"seccomp-filters,omitempty"` // number of seccomp filters in use; not on older kernels.
+}
+
+// CredentialIDs are the real, effective, saved set, and filesystem user or
+// group IDs of a process or task.
+type CredentialIDs struct {
+	Real      uint32 `json:"real"`
+	Effective uint32 `json:"effective"`
+	Saved     uint32 `json:"saved"`
+	FS        uint32 `json:"fs"`
+}
+
+// SeccompMode is the seccomp mode of a process or task, as read from the
+// “Seccomp” field of “/proc/[PID]/status”.
+type SeccompMode string
+
+// The seccomp modes of processes and tasks.
+const (
+	SeccompUnknown  SeccompMode = ""         // unknown seccomp mode.
+	SeccompDisabled SeccompMode = "disabled" // SECCOMP_MODE_DISABLED.
+	SeccompStrict   SeccompMode = "strict"   // SECCOMP_MODE_STRICT.
+	SeccompFilter   SeccompMode = "filter"   // SECCOMP_MODE_FILTER.
+)
+
+// seccompModes maps the numeric seccomp modes as reported by the kernel onto
+// their SeccompMode values.
+var seccompModes = map[string]SeccompMode{
+	"0": SeccompDisabled,
+	"1": SeccompStrict,
+	"2": SeccompFilter,
+}
+
+// ReadCredentials reads the credentials from the specified process or task
+// status file, such as “/proc/self/status”.
+func ReadCredentials(path string) (*Credentials, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseCredentials(f)
+}
+
+// ParseCredentials parses the credentials in the textual format of
+// “/proc/[PID]/status”, ignoring all other fields. It returns an error if the
+// user or group IDs are missing or any credential field is invalid.
+func ParseCredentials(r io.Reader) (*Credentials, error) {
+	credentials := &Credentials{Groups: []uint32{}}
+	var hasUIDs, hasGIDs bool
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		field, value, ok := strings.Cut(scanner.Text(), ":\t")
+		if !ok {
+			continue
+		}
+		var err error
+		switch field {
+		case "Uid":
+			credentials.UIDs, err = parseCredentialIDs(value)
+			hasUIDs = true
+		case "Gid":
+			credentials.GIDs, err = parseCredentialIDs(value)
+			hasGIDs = true
+		case "Groups":
+			credentials.Groups, err = parseIDs(strings.Fields(value))
+		case "NoNewPrivs":
+			credentials.NoNewPrivs, err = strconv.ParseBool(strings.TrimSpace(value))
+		case "Seccomp":
+			mode, ok := seccompModes[strings.TrimSpace(value)]
+			if !ok {
+				err = errors.New("unknown seccomp mode")
+			}
+			credentials.Seccomp = mode
+		case "Seccomp_filters":
+			credentials.SeccompFilters, err = strconv.Atoi(strings.TrimSpace(value))
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q, %w", field, value, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !hasUIDs || !hasGIDs {
+		return nil, errors.New("missing user or group IDs")
+	}
+	return credentials, nil
+}
+
+// parseCredentialIDs parses the four tab-separated real, effective, saved set,
+// and filesystem user or group IDs.
+func parseCredentialIDs(value string) (CredentialIDs, error) {
+	ids, err := parseIDs(strings.Fields(value))
+	if err != nil {
+		return CredentialIDs{}, err
+	}
+	if len(ids) != 4 {
+		return CredentialIDs{}, fmt.Errorf("expected 4 IDs, got %d", len(ids))
+	}
+	return CredentialIDs{
+		Real:      ids[0],
+		Effective: ids[1],
+		Saved:     ids[2],
+		FS:        ids[3],
+	}, nil
+}
+
+// parseIDs parses a list of decimal user or group IDs.
+func parseIDs(fields []string) ([]uint32, error) {
+	ids := make([]uint32, 0, len(fields))
+	for _, field := range fields {
+		id, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
+
+// RetrieveCredentials updates this Process object's credentials from the proc
+// filesystem mounted at procroot, returning nil when successful. Otherwise, it
+// returns an error.
+func (p *Process) RetrieveCredentials(procroot string) error {
+	return p.RetrieveStatus(procroot, StatusCredentials)
+}
+
+// RetrieveCredentials updates this Task object's credentials from the proc
+// filesystem mounted at procroot, returning nil when successful. Otherwise, it
+// returns an error.
+func (t *Task) RetrieveCredentials(procroot string) error {
+	return t.RetrieveStatus(procroot, StatusCredentials)
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("credentials", func() {
+
+	It("rejects status without user IDs", func() {
+		Expect(ReadCredentials("test/credentials/status-bad")).Error().To(
+			MatchError(ContainSubstring("missing user or group IDs")))
+	})
+
+	It("rejects corrupt user IDs", func() {
+		Expect(ReadCredentials("test/credentials/status-corrupt")).Error().To(
+			MatchError(ContainSubstring("invalid Uid field")))
+	})
+
+	It("reads non-existing status", func() {
+		Expect(ReadCredentials("test/credentials/status-missing")).Error().To(HaveOccurred())
+	})
+
+	It("reads credentials", func() {
+		credentials, err := ReadCredentials("test/credentials/status-good")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials.UIDs).To(Equal(CredentialIDs{
+			Real: 1000, Effective: 0, Saved: 0, FS: 1000}))
+		Expect(credentials.GIDs).To(Equal(CredentialIDs{
+			Real: 1000, Effective: 1000, Saved: 1000, FS: 1000}))
+		Expect(credentials.Groups).To(Equal([]uint32{4, 27, 1000}))
+		Expect(credentials.NoNewPrivs).To(BeTrue())
+		Expect(credentials.Seccomp).To(Equal(SeccompFilter))
+		Expect(credentials.SeccompFilters).To(Equal(3))
+
+		credentials, err = ReadCredentials("test/credentials/status-minimal")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials.UIDs).To(BeZero())
+		Expect(credentials.Groups).To(BeEmpty())
+		Expect(credentials.NoNewPrivs).To(BeFalse())
+		Expect(credentials.Seccomp).To(Equal(SeccompUnknown))
+
+		j, err := json.Marshal(credentials)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(j)).To(MatchJSON(`{
+			"uids":{"real":0,"effective":0,"saved":0,"fs":0},
+			"gids":{"real":0,"effective":0,"saved":0,"fs":0},
+			"groups":[],
+			"no-new-privs":false
+		}`))
+	})
+
+	It("retrieves credentials of processes and tasks", func() {
+		proc := NewProcess(PIDType(os.Getpid()), true)
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.Credentials).To(BeNil())
+		Expect(proc.RetrieveCredentials("/proc")).To(Succeed())
+		Expect(proc.Credentials).NotTo(BeNil())
+		Expect(proc.Credentials.UIDs.Effective).To(Equal(uint32(os.Geteuid())))
+		Expect(proc.Credentials.GIDs.Effective).To(Equal(uint32(os.Getegid())))
+		Expect(proc.Credentials.Seccomp).NotTo(Equal(SeccompUnknown))
+
+		Expect(proc.Tasks).NotTo(BeEmpty())
+		task := proc.Tasks[0]
+		Expect(task.RetrieveCredentials("/proc")).To(Succeed())
+		Expect(task.Credentials.UIDs).To(Equal(proc.Credentials.UIDs))
+
+		Expect((&Process{PID: -1}).RetrieveCredentials("/proc")).NotTo(Succeed())
+	})
+
+})
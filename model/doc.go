@@ -21,6 +21,25 @@ All namespaces are additionally owned by one user namespace or another. In case
 of user namespaces this ownership actually is the parent-child namespace
 relationship instead.
 
+User namespaces additionally map user and group IDs onto the IDs of other user
+namespaces; the [IDMapping] interface informs about these ID mappings, where
+known, so it becomes visible which user namespaces are rootless or idmapped.
+Based on these ID mappings, an [IDMapper] translates user and group IDs between
+user namespaces, such as from a rootless container to the host.
+
+Time namespaces offset the monotonic and boottime clocks; the [TimeOffsets]
+interface informs about these clock offsets, where known. Similarly, the
+[UTSNames] interface informs about the host and domain names of UTS namespaces,
+and the [CgroupNamespaceRoot] interface about where the roots of cgroup
+namespaces lie in the cgroup hierarchy. When opted in, the [IPCInventory]
+interface lists the System V IPC objects and POSIX message queues of IPC
+namespaces, and the [NetworkInventory] interface lists the network interfaces
+of network namespaces. A [NetworkTopology] then relates these network
+interfaces across network namespaces. Finally, the [SocketInventory] interface
+lists the sockets of network namespaces, which can then be attributed to
+processes and containers. A [UnixSocketGraph] relates connected UNIX domain
+sockets across network namespaces.
+
 Namespaces may exist with processes, but also without any processes. The latter
 requires references to such a namespace in form of either bind mounts or
 parent-children relationships.
@@ -47,7 +66,15 @@ Moreover, leader processes are related to (userland) containers, where
 applicable. Containers are also organized according to their managing container
 engine. Of course, depending on host configuration, multiple container engines
 might be present at the same time. A typical example is a Docker engine (daemon)
-together with a containerd engine.
+together with a containerd engine. [Containers.SharedNamespaces] then groups
+containers by the namespaces they share, telling intended sharing, such as
+inside Kubernetes pods, apart from unexpected sharing, as well as sharing with
+the host.
+
+When opted in, processes additionally carry their [Capabilities], their
+[Credentials], and their [LSMContexts]. As the security contexts of a
+container are those of its initial process, this allows spotting unconfined
+containers next to confined ones.
 
 [Information Model in the lxkns online manual]: https://thediveo.github.io/lxkns/#/model
 */
@@ -0,0 +1,196 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// IDMapping informs about the user and group ID mappings of a user namespace,
+// as well as whether processes in this user namespace are allowed to call
+// setgroups(2). Only user namespaces provide and implement IDMapping.
+//
+// The ID mappings are read from the “uid_map” and “gid_map” files of a leader
+// process of a user namespace, so user namespaces without any processes lack
+// ID mapping information.
+type IDMapping interface {
+	// UIDMap returns the user ID mapping of this user namespace, or nil if
+	// unknown.
+	UIDMap() IDMap
+	// GIDMap returns the group ID mapping of this user namespace, or nil if
+	// unknown.
+	GIDMap() IDMap
+	// Setgroups returns whether processes in this user namespace are allowed
+	// to call setgroups(2).
+	Setgroups() Setgroups
+}
+
+// Setgroups is the setgroups(2) permission state of a user namespace, as read
+// from “/proc/[PID]/setgroups”.
+type Setgroups string
+
+// The setgroups(2) permission states of user namespaces.
+const (
+	SetgroupsUnknown Setgroups = ""      // unknown setgroups(2) permission.
+	SetgroupsAllow   Setgroups = "allow" // setgroups(2) is allowed.
+	SetgroupsDeny    Setgroups = "deny"  // setgroups(2) is permanently denied.
+)
+
+// IDMapRange is a single range of consecutive user or group IDs mapped from a
+// user namespace into another user namespace, corresponding with a single line
+// of a “/proc/[PID]/uid_map” or “/proc/[PID]/gid_map” file.
+//
+// Please note that the LowerFirst IDs are relative to the user namespace of
+// the process that read the ID mapping: this is the parent user namespace if
+// the reading process is in the mapped user namespace itself, or otherwise
+// the user namespace of the reading process. In case of a discovery from the
+// initial user namespace, LowerFirst thus is a host UID or GID.
+type IDMapRange struct {
+	First      uint32 `json:"first"`       // first ID inside the mapped user namespace.
+	LowerFirst uint32 `json:"lower-first"` // first ID the range maps onto.
+	Count      uint32 `json:"count"`       // number of consecutive IDs mapped.
+}
+
+// IDMap is a user or group ID mapping, consisting of zero or more ranges. A
+// zero IDMap doesn't map any IDs; this is the case for user namespaces that
+// have been created, but not yet been assigned an ID mapping.
+type IDMap []IDMapRange
+
+// ReadIDMap reads a user or group ID mapping from the specified file, such as
+// “/proc/self/uid_map”.
+func ReadIDMap(path string) (IDMap, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseIDMap(f)
+}
+
+// ParseIDMap parses a user or group ID mapping in the textual format of
+// “/proc/[PID]/uid_map” and “/proc/[PID]/gid_map”, with one range per line
+// consisting of three whitespace-separated fields.
+func ParseIDMap(r io.Reader) (IDMap, error) {
+	idmap := IDMap{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid ID map line %q", scanner.Text())
+		}
+		var values [3]uint32
+		for idx, field := range fields {
+			value, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ID map line %q, %w", scanner.Text(), err)
+			}
+			values[idx] = uint32(value)
+		}
+		idmap = append(idmap, IDMapRange{
+			First:      values[0],
+			LowerFirst: values[1],
+			Count:      values[2],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return idmap, nil
+}
+
+// IsIdentity returns true if the ID mapping maps all IDs onto themselves, as
+// is the case for the initial user namespace.
+func (m IDMap) IsIdentity() bool {
+	return len(m) == 1 && m[0].First == 0 && m[0].LowerFirst == 0 && m[0].Count == ^uint32(0)
+}
+
+// String returns a textual representation of the ID mapping, such as
+// “0→1000, 1-65536→100000-165535”.
+func (m IDMap) String() string {
+	ranges := make([]string, 0, len(m))
+	for _, r := range m {
+		ranges = append(ranges, r.String())
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// String returns a textual representation of the ID mapping range, such as
+// “0→1000” or “1-65536→100000-165535”.
+func (r IDMapRange) String() string {
+	if r.Count == 1 {
+		return strconv.FormatUint(uint64(r.First), 10) + "→" +
+			strconv.FormatUint(uint64(r.LowerFirst), 10)
+	}
+	last := uint64(r.Count) - 1
+	return strconv.FormatUint(uint64(r.First), 10) + "-" +
+		strconv.FormatUint(uint64(r.First)+last, 10) + "→" +
+		strconv.FormatUint(uint64(r.LowerFirst), 10) + "-" +
+		strconv.FormatUint(uint64(r.LowerFirst)+last, 10)
+}
+
+// ToLower maps the specified ID inside the user namespace onto the
+// corresponding lower ID. It returns false if the ID isn't mapped.
+func (m IDMap) ToLower(id uint32) (uint32, bool) {
+	for _, r := range m {
+		if id >= r.First && uint64(id) < uint64(r.First)+uint64(r.Count) {
+			return r.LowerFirst + (id - r.First), true
+		}
+	}
+	return 0, false
+}
+
+// FromLower maps the specified lower ID onto the corresponding ID inside the
+// user namespace. It returns false if the lower ID isn't mapped.
+func (m IDMap) FromLower(lowerid uint32) (uint32, bool) {
+	for _, r := range m {
+		if lowerid >= r.LowerFirst && uint64(lowerid) < uint64(r.LowerFirst)+uint64(r.Count) {
+			return r.First + (lowerid - r.LowerFirst), true
+		}
+	}
+	return 0, false
+}
+
+// IDKind specifies whether IDs are user IDs or group IDs.
+type IDKind int
+
+// The kinds of IDs an [IDMapper] translates.
+const (
+	UserIDs  IDKind = iota // user IDs (UIDs).
+	GroupIDs               // group IDs (GIDs).
+)
+
+// IDMapper translates user and group IDs ([IDKind]) from one user namespace to
+// another, based on the ID mappings of the user namespaces involved.
+type IDMapper interface {
+	// Translate translates the user or group ID "id" in user namespace "from"
+	// to the corresponding ID in user namespace "to". It returns false if the
+	// ID either has no mapping in user namespace "from", or if it has no
+	// mapping in user namespace "to".
+	Translate(id uint32, kind IDKind, from Namespace, to Namespace) (uint32, bool)
+	// TranslateMunged translates the user or group ID "id" in user namespace
+	// "from" to the corresponding ID in user namespace "to". In case the ID
+	// cannot be translated, it returns the overflow user or group ID instead
+	// (usually 65534), the same as the Linux kernel does when it reports IDs
+	// that aren't mapped.
+	TranslateMunged(id uint32, kind IDKind, from Namespace, to Namespace) uint32
+}
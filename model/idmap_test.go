@@ -0,0 +1,55 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ID mappings", func() {
+
+	It("parses ID maps", func() {
+		idmap, err := ParseIDMap(strings.NewReader(
+			"         0       1000          1\n" +
+				"         1     100000      65536\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(idmap).To(Equal(IDMap{
+			{First: 0, LowerFirst: 1000, Count: 1},
+			{First: 1, LowerFirst: 100000, Count: 65536},
+		}))
+		Expect(idmap.IsIdentity()).To(BeFalse())
+		Expect(idmap.String()).To(Equal("0→1000, 1-65536→100000-165535"))
+
+		Expect(ParseIDMap(strings.NewReader(""))).To(BeEmpty())
+
+		_, err = ParseIDMap(strings.NewReader("0 1000\n"))
+		Expect(err).To(HaveOccurred())
+		_, err = ParseIDMap(strings.NewReader("0 1000 -1\n"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reads the identity ID map of the initial user namespace", func() {
+		idmap, err := ReadIDMap("/proc/1/uid_map")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(idmap.IsIdentity()).To(BeTrue())
+
+		_, err = ReadIDMap("/proc/non-existing/uid_map")
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,80 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// IPCInventory informs about the IPC objects living in an IPC namespace. Only
+// IPC namespaces provide and implement IPCInventory.
+//
+// IPC objects are only discovered when opting in, as this requires switching
+// into each IPC namespace.
+type IPCInventory interface {
+	// IPCObjects returns the IPC objects of this IPC namespace, or nil if
+	// unknown.
+	IPCObjects() *IPCObjects
+}
+
+// IPCObjects lists the System V IPC objects, as well as the POSIX message
+// queues, of an IPC namespace.
+type IPCObjects struct {
+	SharedMemory       []SysVSharedMemory  `json:"shm,omitempty"`         // System V shared memory segments.
+	Semaphores         []SysVSemaphoreSet  `json:"sem,omitempty"`         // System V semaphore sets.
+	MessageQueues      []SysVMessageQueue  `json:"msg,omitempty"`         // System V message queues.
+	POSIXMessageQueues []POSIXMessageQueue `json:"mqueue,omitempty"`      // POSIX message queues, if an mqueue mount was found.
+	MqueuePath         string              `json:"mqueue-path,omitempty"` // path of the mqueue mount the POSIX message queues were listed from.
+}
+
+// SysVIPCObject describes the properties common to all System V IPC objects,
+// as read from the files in “/proc/sysvipc/”.
+type SysVIPCObject struct {
+	Key   int32  `json:"key"`   // IPC key, or 0 for IPC_PRIVATE.
+	ID    int    `json:"id"`    // IPC object identifier.
+	Perms uint32 `json:"perms"` // permission bits.
+	UID   uint32 `json:"uid"`   // owner's user ID.
+	GID   uint32 `json:"gid"`   // owner's group ID.
+	CUID  uint32 `json:"cuid"`  // creator's user ID.
+	CGID  uint32 `json:"cgid"`  // creator's group ID.
+}
+
+// SysVSharedMemory describes a System V shared memory segment.
+type SysVSharedMemory struct {
+	SysVIPCObject
+	Size       uint64  `json:"size"`   // size of segment in bytes.
+	Attached   uint64  `json:"nattch"` // number of current attaches.
+	CreatorPID PIDType `json:"cpid"`   // PID of creator.
+	LastPID    PIDType `json:"lpid"`   // PID of last shmat(2) or shmdt(2).
+}
+
+// SysVSemaphoreSet describes a System V semaphore set.
+type SysVSemaphoreSet struct {
+	SysVIPCObject
+	Semaphores uint64 `json:"nsems"` // number of semaphores in set.
+}
+
+// SysVMessageQueue describes a System V message queue.
+type SysVMessageQueue struct {
+	SysVIPCObject
+	Bytes    uint64 `json:"cbytes"` // number of bytes currently in the queue.
+	Messages uint64 `json:"qnum"`   // number of messages currently in the queue.
+}
+
+// POSIXMessageQueue describes a POSIX message queue, as found in an “mqueue”
+// filesystem mount.
+type POSIXMessageQueue struct {
+	Name string `json:"name"`  // name of the queue, without leading slash.
+	Mode uint32 `json:"mode"`  // permission bits.
+	UID  uint32 `json:"uid"`   // owner's user ID.
+	GID  uint32 `json:"gid"`   // owner's group ID.
+	Size uint64 `json:"qsize"` // number of bytes currently in the queue.
+}
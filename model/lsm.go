@@ -0,0 +1,122 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// LSMContext is the security context (label) of a process, as assigned by a
+// Linux security module (LSM). Examples are the AppArmor profile
+// “docker-default (enforce)”, the SELinux context
+// “system_u:system_r:container_t:s0:c42,c666”, or the Smack label “_”.
+type LSMContext struct {
+	// name of the LSM, such as “apparmor”, “selinux”, or “smack”; empty if
+	// the LSM couldn't be determined.
+	LSM     string `json:"lsm,omitempty"`
+	Context string `json:"context"` // security context (label).
+}
+
+// LSMContexts are the security contexts of a process, as assigned by one or
+// more (stacked) Linux security modules.
+type LSMContexts []LSMContext
+
+// stackedLSMs lists the names of the LSMs that provide their own
+// “/proc/[PID]/attr/[LSM]/” directories on stacked-LSM kernels.
+var stackedLSMs = []string{"apparmor", "smack"}
+
+// Unconfined returns true if any of the security contexts is “unconfined”,
+// or in case of SELinux, the type is “unconfined_t” or “spc_t”.
+func (c LSMContexts) Unconfined() bool {
+	for _, ctx := range c {
+		if ctx.Context == "unconfined" {
+			return true
+		}
+		fields := strings.Split(ctx.Context, ":")
+		if len(fields) >= 3 && (fields[2] == "unconfined_t" || fields[2] == "spc_t") {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadLSMContexts reads the security contexts of the process with the
+// specified PID from the proc filesystem mounted at procroot. It first reads
+// the LSM-specific “/proc/[PID]/attr/[LSM]/current” files available on
+// stacked-LSM kernels, and then the generic “/proc/[PID]/attr/current” file,
+// unless it duplicates one of the LSM-specific contexts. ReadLSMContexts
+// returns no contexts and no error if no LSM is active.
+func ReadLSMContexts(procroot string, pid PIDType) (LSMContexts, error) {
+	attrdir := procroot + "/" + strconv.Itoa(int(pid)) + "/attr/"
+	var contexts LSMContexts
+	for _, lsm := range stackedLSMs {
+		context, err := readLSMContext(attrdir + lsm + "/current")
+		if err != nil || context == "" {
+			continue
+		}
+		contexts = append(contexts, LSMContext{LSM: lsm, Context: context})
+	}
+	context, err := readLSMContext(attrdir + "current")
+	if err != nil {
+		if errors.Is(err, syscall.EINVAL) {
+			return contexts, nil // no LSM active (or none providing contexts).
+		}
+		return nil, err
+	}
+	if context == "" || slices.ContainsFunc(contexts, func(ctx LSMContext) bool {
+		return ctx.Context == context
+	}) {
+		return contexts, nil
+	}
+	return append(contexts, LSMContext{Context: context}), nil
+}
+
+// readLSMContext returns the security context read from the specified file,
+// without any trailing zero and newline characters.
+func readLSMContext(path string) (string, error) {
+	b, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\x00\n"), nil
+}
+
+// RetrieveLSMContexts updates this Process object's security contexts from
+// the proc filesystem mounted at procroot, returning nil when successful.
+// Otherwise, it returns an error.
+func (p *Process) RetrieveLSMContexts(procroot string) error {
+	contexts, err := ReadLSMContexts(procroot, p.PID)
+	if err != nil {
+		return err
+	}
+	p.LSMContexts = contexts
+	return nil
+}
+
+// LSMContexts returns the security contexts of the initial process of this
+// container, if known.
+func (c *Container) LSMContexts() LSMContexts {
+	if c.Process == nil {
+		return nil
+	}
+	return c.Process.LSMContexts
+}
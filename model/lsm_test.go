@@ -0,0 +1,69 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LSM contexts", func() {
+
+	It("reads LSM contexts", func() {
+		Expect(ReadLSMContexts("test/lsm", 1)).To(ConsistOf(
+			LSMContext{LSM: "apparmor", Context: "docker-default (enforce)"}))
+		Expect(ReadLSMContexts("test/lsm", 2)).To(ConsistOf(
+			LSMContext{Context: "system_u:system_r:container_t:s0:c42,c666"}))
+		Expect(ReadLSMContexts("test/lsm", 3)).To(ConsistOf(
+			LSMContext{LSM: "apparmor", Context: "unconfined"},
+			LSMContext{LSM: "smack", Context: "_"}))
+		Expect(ReadLSMContexts("test/lsm", 666)).Error().To(HaveOccurred())
+	})
+
+	It("detects unconfined contexts", func() {
+		Expect(LSMContexts(nil).Unconfined()).To(BeFalse())
+		Expect(LSMContexts{{LSM: "apparmor", Context: "docker-default (enforce)"}}.Unconfined()).To(BeFalse())
+		Expect(LSMContexts{{LSM: "apparmor", Context: "unconfined"}}.Unconfined()).To(BeTrue())
+		Expect(LSMContexts{{Context: "system_u:system_r:container_t:s0:c1,c2"}}.Unconfined()).To(BeFalse())
+		Expect(LSMContexts{{Context: "system_u:system_r:spc_t:s0"}}.Unconfined()).To(BeTrue())
+		Expect(LSMContexts{{Context: "unconfined_u:unconfined_r:unconfined_t:s0"}}.Unconfined()).To(BeTrue())
+	})
+
+	It("marshals LSM contexts", func() {
+		j, err := json.Marshal(LSMContexts{
+			{LSM: "apparmor", Context: "unconfined"},
+			{Context: "_"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`[{"lsm":"apparmor","context":"unconfined"},{"context":"_"}]`))
+	})
+
+	It("retrieves LSM contexts of processes and their containers", func() {
+		proc := &Process{PID: 3}
+		Expect(proc.RetrieveLSMContexts("test/lsm")).To(Succeed())
+		Expect(proc.LSMContexts).To(HaveLen(2))
+		Expect((&Container{Process: proc}).LSMContexts()).To(Equal(proc.LSMContexts))
+		Expect((&Container{}).LSMContexts()).To(BeNil())
+
+		Expect((&Process{PID: -1}).RetrieveLSMContexts("/proc")).NotTo(Succeed())
+		Expect((&Process{PID: PIDType(os.Getpid())}).RetrieveLSMContexts("/proc")).To(Succeed())
+	})
+
+})
@@ -0,0 +1,96 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"net/netip"
+
+	"github.com/thediveo/lxkns/species"
+)
+
+// NetworkInventory informs about the network interfaces of a network
+// namespace, together with their IP addresses. Only network namespaces
+// provide and implement NetworkInventory.
+//
+// Network interfaces are only discovered when opting in, as this requires
+// switching into each network namespace.
+type NetworkInventory interface {
+	// NetworkInterfaces returns the network interfaces of this network
+	// namespace, sorted by their interface indices, or nil if unknown.
+	NetworkInterfaces() []NetworkInterface
+	// NetNSIDs returns the identifiers (“nsids”) this network namespace
+	// assigned to other network namespaces, mapped to the IDs of these
+	// network namespaces, or nil if unknown. Only nsids of discovered network
+	// namespaces are included.
+	NetNSIDs() map[int32]species.NamespaceID
+}
+
+// NetworkInterface describes a network interface of a network namespace.
+type NetworkInterface struct {
+	Index     int            `json:"index"`               // interface index.
+	Name      string         `json:"name"`                // interface name.
+	Kind      string         `json:"kind,omitempty"`      // kind of interface, such as "veth", "bridge", "macvlan"; empty for physical and loopback interfaces.
+	MAC       string         `json:"mac,omitempty"`       // hardware (MAC) address, if any.
+	MTU       uint32         `json:"mtu"`                 // maximum transmission unit.
+	OperState OperState      `json:"oper-state"`          // RFC 2863 operational state.
+	Addresses []netip.Prefix `json:"addresses,omitempty"` // IPv4 and IPv6 addresses with their prefix lengths.
+
+	// Index of the master interface in the same network namespace, such as a
+	// bridge or bond, if any.
+	Master int `json:"master,omitempty"`
+	// Index of the veth peer interface or the lower interface of a macvlan,
+	// ipvlan, or VLAN interface, if any. The interface is located in the
+	// network namespace LinkNetNS, if set, and otherwise in the same network
+	// namespace. Link is zero when the interface is located in a network
+	// namespace that wasn't discovered.
+	Link      int                 `json:"link,omitempty"`
+	LinkNetNS species.NamespaceID `json:"link-netns,omitzero"` // network namespace of the Link interface, unless the same.
+}
+
+// OperState is the RFC 2863 operational state of a network interface.
+type OperState string
+
+// The RFC 2863 operational states of network interfaces, as reported by the
+// Linux kernel.
+const (
+	OperStateUnknown        OperState = "unknown"
+	OperStateNotPresent     OperState = "notpresent"
+	OperStateDown           OperState = "down"
+	OperStateLowerLayerDown OperState = "lowerlayerdown"
+	OperStateTesting        OperState = "testing"
+	OperStateDormant        OperState = "dormant"
+	OperStateUp             OperState = "up"
+)
+
+// operStates maps the operational state values used by the Linux kernel
+// (IF_OPER_*) to their names.
+var operStates = [...]OperState{
+	OperStateUnknown,
+	OperStateNotPresent,
+	OperStateDown,
+	OperStateLowerLayerDown,
+	OperStateTesting,
+	OperStateDormant,
+	OperStateUp,
+}
+
+// OperStateFromKernel returns the operational state corresponding with the
+// specified Linux kernel IF_OPER_* value.
+func OperStateFromKernel(state uint8) OperState {
+	if int(state) >= len(operStates) {
+		return OperStateUnknown
+	}
+	return operStates[state]
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"net/netip"
+
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("network interfaces", func() {
+
+	It("maps kernel operational states", func() {
+		Expect(OperStateFromKernel(0)).To(Equal(OperStateUnknown))
+		Expect(OperStateFromKernel(2)).To(Equal(OperStateDown))
+		Expect(OperStateFromKernel(6)).To(Equal(OperStateUp))
+		Expect(OperStateFromKernel(42)).To(Equal(OperStateUnknown))
+	})
+
+	It("marshals network interfaces", func() {
+		j, err := json.Marshal(NetworkInterface{
+			Index:     2,
+			Name:      "eth0",
+			Kind:      "veth",
+			MAC:       "02:42:ac:11:00:02",
+			MTU:       1500,
+			OperState: OperStateUp,
+			Addresses: []netip.Prefix{netip.MustParsePrefix("172.17.0.2/16")},
+			Link:      42,
+			LinkNetNS: species.NamespaceID{Dev: 4, Ino: 4026531840},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`{
+			"index": 2,
+			"name": "eth0",
+			"kind": "veth",
+			"mac": "02:42:ac:11:00:02",
+			"mtu": 1500,
+			"oper-state": "up",
+			"addresses": ["172.17.0.2/16"],
+			"link": 42,
+			"link-netns": {"dev": 4, "ino": 4026531840}
+		}`))
+	})
+
+})
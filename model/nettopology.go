@@ -0,0 +1,241 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/thediveo/lxkns/species"
+)
+
+// NetworkTopology is a graph relating network interfaces across network
+// namespaces: its nodes are network interfaces and its edges are veth peer,
+// bridge/bond port, and macvlan/ipvlan/VLAN lower interface relations.
+//
+// Use [NewNetworkTopology] to build the topology from the network interfaces
+// of discovered network namespaces.
+type NetworkTopology struct {
+	Nodes []NetworkTopologyNode `json:"nodes,omitempty"` // network interfaces, sorted by network namespace and interface index.
+	Edges []NetworkTopologyEdge `json:"edges,omitempty"` // relations between network interfaces.
+}
+
+// NetworkInterfaceRef references a network interface in a particular network
+// namespace.
+type NetworkInterfaceRef struct {
+	NetNS species.NamespaceID `json:"netns"` // network namespace of the interface.
+	Index int                 `json:"index"` // interface index.
+}
+
+// NetworkTopologyNode is a network interface in a [NetworkTopology].
+type NetworkTopologyNode struct {
+	NetworkInterfaceRef
+	Name string `json:"name"`           // interface name.
+	Kind string `json:"kind,omitempty"` // kind of interface, such as "veth" or "bridge".
+}
+
+// NetworkRelation is the type of relation between two network interfaces.
+type NetworkRelation string
+
+// The relations between network interfaces in a [NetworkTopology].
+const (
+	NetworkRelationPeer  NetworkRelation = "peer"  // From and To are veth peers.
+	NetworkRelationPort  NetworkRelation = "port"  // From is a port of the master To, such as a bridge or bond.
+	NetworkRelationLower NetworkRelation = "lower" // To is the lower interface of the macvlan, ipvlan, or VLAN interface From.
+)
+
+// NetworkTopologyEdge relates two network interfaces in a [NetworkTopology].
+// Peer relations are symmetric and thus only present once.
+type NetworkTopologyEdge struct {
+	From     NetworkInterfaceRef `json:"from"`
+	To       NetworkInterfaceRef `json:"to"`
+	Relation NetworkRelation     `json:"relation"`
+}
+
+// NewNetworkTopology returns the topology of the network interfaces of the
+// specified network namespaces. Network namespaces without known network
+// interfaces don't contribute any nodes, but might still be referenced by
+// edges.
+func NewNetworkTopology(netnsmap NamespaceMap) NetworkTopology {
+	topo := NetworkTopology{}
+	netifs := map[NetworkInterfaceRef]*NetworkInterface{}
+	for netnsid, netns := range netnsmap {
+		inventory, ok := netns.(NetworkInventory)
+		if !ok {
+			continue
+		}
+		nsnetifs := inventory.NetworkInterfaces()
+		for idx := range nsnetifs {
+			ref := NetworkInterfaceRef{NetNS: netnsid, Index: nsnetifs[idx].Index}
+			netifs[ref] = &nsnetifs[idx]
+			topo.Nodes = append(topo.Nodes, NetworkTopologyNode{
+				NetworkInterfaceRef: ref,
+				Name:                nsnetifs[idx].Name,
+				Kind:                nsnetifs[idx].Kind,
+			})
+		}
+	}
+	slices.SortFunc(topo.Nodes, func(a, b NetworkTopologyNode) int {
+		return a.NetworkInterfaceRef.compare(b.NetworkInterfaceRef)
+	})
+	for _, node := range topo.Nodes {
+		topo.addEdges(node.NetworkInterfaceRef, netifs[node.NetworkInterfaceRef])
+	}
+	return topo
+}
+
+// addEdges adds the edges for the relations of the specified network
+// interface to other network interfaces.
+func (t *NetworkTopology) addEdges(ref NetworkInterfaceRef, netif *NetworkInterface) {
+	if netif.Master != 0 {
+		t.Edges = append(t.Edges, NetworkTopologyEdge{
+			From:     ref,
+			To:       NetworkInterfaceRef{NetNS: ref.NetNS, Index: netif.Master},
+			Relation: NetworkRelationPort,
+		})
+	}
+	if netif.Link == 0 || netif.Link == netif.Index && netif.LinkNetNS == species.NoneID {
+		return
+	}
+	link := NetworkInterfaceRef{NetNS: netif.LinkNetNS, Index: netif.Link}
+	if link.NetNS == species.NoneID {
+		link.NetNS = ref.NetNS
+	}
+	if netif.Kind != "veth" {
+		t.Edges = append(t.Edges, NetworkTopologyEdge{
+			From:     ref,
+			To:       link,
+			Relation: NetworkRelationLower,
+		})
+		return
+	}
+	// Both veth peers reference each other, so we add the peer relation only
+	// once, unless the peer is unknown.
+	if _, ok := t.Node(link); ok && link.compare(ref) < 0 {
+		return
+	}
+	t.Edges = append(t.Edges, NetworkTopologyEdge{
+		From:     ref,
+		To:       link,
+		Relation: NetworkRelationPeer,
+	})
+}
+
+// Node returns the network interface node for the specified reference, if
+// known.
+func (t *NetworkTopology) Node(ref NetworkInterfaceRef) (NetworkTopologyNode, bool) {
+	idx, ok := slices.BinarySearchFunc(t.Nodes, ref, func(node NetworkTopologyNode, ref NetworkInterfaceRef) int {
+		return node.NetworkInterfaceRef.compare(ref)
+	})
+	if !ok {
+		return NetworkTopologyNode{}, false
+	}
+	return t.Nodes[idx], true
+}
+
+// Peer returns the veth peer of the specified network interface, if any.
+func (t *NetworkTopology) Peer(ref NetworkInterfaceRef) (NetworkInterfaceRef, bool) {
+	for _, edge := range t.Edges {
+		if edge.Relation != NetworkRelationPeer {
+			continue
+		}
+		switch ref {
+		case edge.From:
+			return edge.To, true
+		case edge.To:
+			return edge.From, true
+		}
+	}
+	return NetworkInterfaceRef{}, false
+}
+
+// Master returns the master interface of the specified network interface, such
+// as a bridge or bond, if any.
+func (t *NetworkTopology) Master(ref NetworkInterfaceRef) (NetworkInterfaceRef, bool) {
+	return t.to(ref, NetworkRelationPort)
+}
+
+// Lower returns the lower interface of the specified macvlan, ipvlan, or VLAN
+// network interface, if any.
+func (t *NetworkTopology) Lower(ref NetworkInterfaceRef) (NetworkInterfaceRef, bool) {
+	return t.to(ref, NetworkRelationLower)
+}
+
+// Ports returns the ports of the specified master network interface, such as
+// a bridge or bond.
+func (t *NetworkTopology) Ports(master NetworkInterfaceRef) []NetworkInterfaceRef {
+	var ports []NetworkInterfaceRef
+	for _, edge := range t.Edges {
+		if edge.Relation == NetworkRelationPort && edge.To == master {
+			ports = append(ports, edge.From)
+		}
+	}
+	return ports
+}
+
+// Bridge returns the bridge the specified network interface hangs off, if
+// any. Bridge follows veth peers, lower interfaces, and masters other than
+// bridges, such as bonds, until it finds a bridge. For instance, for the
+// “eth0” veth interface of a container, Bridge returns the host bridge the
+// veth peer of “eth0” is a port of.
+func (t *NetworkTopology) Bridge(ref NetworkInterfaceRef) (NetworkInterfaceRef, bool) {
+	visited := map[NetworkInterfaceRef]struct{}{}
+	for {
+		if _, ok := visited[ref]; ok {
+			return NetworkInterfaceRef{}, false
+		}
+		visited[ref] = struct{}{}
+		if master, ok := t.Master(ref); ok {
+			if node, ok := t.Node(master); ok && node.Kind == "bridge" {
+				return master, true
+			}
+			ref = master
+			continue
+		}
+		if lower, ok := t.Lower(ref); ok {
+			ref = lower
+			continue
+		}
+		// don't cross back over the veth pair we came from.
+		if peer, ok := t.Peer(ref); ok {
+			if _, ok := visited[peer]; !ok {
+				ref = peer
+				continue
+			}
+		}
+		return NetworkInterfaceRef{}, false
+	}
+}
+
+// to returns the network interface the specified network interface relates
+// to in the specified way, if any.
+func (t *NetworkTopology) to(ref NetworkInterfaceRef, relation NetworkRelation) (NetworkInterfaceRef, bool) {
+	for _, edge := range t.Edges {
+		if edge.Relation == relation && edge.From == ref {
+			return edge.To, true
+		}
+	}
+	return NetworkInterfaceRef{}, false
+}
+
+// compare returns an integer comparing two network interface references,
+// first by their network namespace inode numbers and then by their interface
+// indices.
+func (r NetworkInterfaceRef) compare(other NetworkInterfaceRef) int {
+	return cmp.Or(
+		cmp.Compare(r.NetNS.Ino, other.NetNS.Ino),
+		cmp.Compare(r.NetNS.Dev, other.NetNS.Dev),
+		cmp.Compare(r.Index, other.Index))
+}
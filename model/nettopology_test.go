@@ -0,0 +1,120 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+// netnsInventory is a network namespace stand-in only implementing the
+// NetworkInventory interface.
+type netnsInventory struct {
+	Namespace
+	netifs []NetworkInterface
+}
+
+func (n *netnsInventory) NetworkInterfaces() []NetworkInterface { return n.netifs }
+
+func (n *netnsInventory) NetNSIDs() map[int32]species.NamespaceID { return nil }
+
+var _ = Describe("network topology", func() {
+
+	hostid := species.NamespaceID{Dev: 4, Ino: 1}
+	ctrid := species.NamespaceID{Dev: 4, Ino: 2}
+
+	// host: lo, eth0 (port of bond0), bond0 (port of br0), br0, vethA (port
+	// of br0, peer of the container's eth0), and macvlan0 on top of eth1.
+	//
+	// container: lo, eth0 (peer of the host's vethA), mv0 (on top of the
+	// host's eth1).
+	netnsmap := NamespaceMap{
+		hostid: &netnsInventory{netifs: []NetworkInterface{
+			{Index: 1, Name: "lo", Link: 1},
+			{Index: 2, Name: "eth0", Master: 3},
+			{Index: 3, Name: "bond0", Kind: "bond", Master: 4},
+			{Index: 4, Name: "br0", Kind: "bridge"},
+			{Index: 5, Name: "vethA", Kind: "veth", Master: 4, Link: 2, LinkNetNS: ctrid},
+			{Index: 6, Name: "eth1"},
+		}},
+		ctrid: &netnsInventory{netifs: []NetworkInterface{
+			{Index: 1, Name: "lo", Link: 1},
+			{Index: 2, Name: "eth0", Kind: "veth", Link: 5, LinkNetNS: hostid},
+			{Index: 3, Name: "mv0", Kind: "macvlan", Link: 6, LinkNetNS: hostid},
+		}},
+	}
+
+	ref := func(netns species.NamespaceID, index int) NetworkInterfaceRef {
+		return NetworkInterfaceRef{NetNS: netns, Index: index}
+	}
+
+	// related returns the related network interface, expecting it to exist.
+	related := func(ref NetworkInterfaceRef, ok bool) NetworkInterfaceRef {
+		GinkgoHelper()
+		Expect(ok).To(BeTrue())
+		return ref
+	}
+
+	It("builds the topology", func() {
+		topo := NewNetworkTopology(netnsmap)
+		Expect(topo.Nodes).To(HaveLen(9))
+		Expect(topo.Nodes[0]).To(Equal(NetworkTopologyNode{
+			NetworkInterfaceRef: ref(hostid, 1), Name: "lo"}))
+		Expect(topo.Nodes[8].NetworkInterfaceRef).To(Equal(ref(ctrid, 3)))
+		Expect(topo.Edges).To(ConsistOf(
+			NetworkTopologyEdge{From: ref(hostid, 2), To: ref(hostid, 3), Relation: NetworkRelationPort},
+			NetworkTopologyEdge{From: ref(hostid, 3), To: ref(hostid, 4), Relation: NetworkRelationPort},
+			NetworkTopologyEdge{From: ref(hostid, 5), To: ref(hostid, 4), Relation: NetworkRelationPort},
+			NetworkTopologyEdge{From: ref(hostid, 5), To: ref(ctrid, 2), Relation: NetworkRelationPeer},
+			NetworkTopologyEdge{From: ref(ctrid, 3), To: ref(hostid, 6), Relation: NetworkRelationLower},
+		))
+	})
+
+	It("navigates the topology", func() {
+		topo := NewNetworkTopology(netnsmap)
+		_, ok := topo.Node(ref(ctrid, 42))
+		Expect(ok).To(BeFalse())
+		Expect(related(topo.Peer(ref(ctrid, 2)))).To(Equal(ref(hostid, 5)))
+		Expect(related(topo.Peer(ref(hostid, 5)))).To(Equal(ref(ctrid, 2)))
+		Expect(related(topo.Master(ref(hostid, 5)))).To(Equal(ref(hostid, 4)))
+		Expect(related(topo.Lower(ref(ctrid, 3)))).To(Equal(ref(hostid, 6)))
+		Expect(topo.Ports(ref(hostid, 4))).To(ConsistOf(ref(hostid, 3), ref(hostid, 5)))
+
+		Expect(related(topo.Bridge(ref(ctrid, 2)))).To(Equal(ref(hostid, 4)))
+		Expect(related(topo.Bridge(ref(hostid, 2)))).To(Equal(ref(hostid, 4)))
+		_, ok = topo.Bridge(ref(ctrid, 3))
+		Expect(ok).To(BeFalse())
+		_, ok = topo.Bridge(ref(ctrid, 1))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("marshals the topology", func() {
+		topo := NewNetworkTopology(NamespaceMap{
+			ctrid: netnsmap[ctrid],
+		})
+		j, err := json.Marshal(topo)
+		Expect(err).NotTo(HaveOccurred())
+		var topo2 NetworkTopology
+		Expect(json.Unmarshal(j, &topo2)).To(Succeed())
+		Expect(topo2).To(Equal(topo))
+		Expect(topo2.Edges).To(ContainElement(NetworkTopologyEdge{
+			From: ref(ctrid, 2), To: ref(hostid, 5), Relation: NetworkRelationPeer}))
+	})
+
+})
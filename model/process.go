@@ -22,6 +22,7 @@ package model
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -30,6 +31,8 @@ import (
 
 	"github.com/thediveo/cpus"
 	"github.com/thediveo/faf"
+
+	"github.com/thediveo/lxkns/internal/fanout"
 )
 
 // PIDType expresses things more clearly.
@@ -70,6 +73,13 @@ type ProTaskCommon struct {
 	//   - SCHED_BATCH: nice is taken into account.
 	//   - SCHED_IDLE: nice is ignored (basically below a nic of +19).
 	Nice int `json:"nice,omitempty"`
+	// capability sets, need explicit request via Process.RetrieveCapabilities
+	// or Task.RetrieveCapabilities.
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+	// user and group credentials, as well as seccomp mode and no_new_privs
+	// flag, need explicit request via Process.RetrieveCredentials or
+	// Task.RetrieveCredentials.
+	Credentials *Credentials `json:"credentials,omitempty"`
 }
 
 // Task represents our very, very limited view and interest in a particular
@@ -93,6 +103,9 @@ type Process struct {
 	Cmdline   []string   `json:"cmdline"`         // command line of process.
 	Tasks     []*Task    `json:"tasks,omitempty"` // tasks of this process, including the main task.
 	Container *Container `json:"-"`               // associated container; only for the leader.
+	// security contexts (labels) of this process, need explicit request via
+	// Process.RetrieveLSMContexts.
+	LSMContexts LSMContexts `json:"lsm-contexts,omitempty"`
 }
 
 // ProcessTable maps PIDs to their [model.Process] descriptions, allowing for
@@ -179,6 +192,20 @@ func NewProcessInProcfs(PID PIDType, withtasks bool, procroot string) (proc *Pro
 	return proc
 }
 
+// StarttimeInProcfs returns the start time of the process with the specified
+// PID, reading it from the proc filesystem mounted at procroot.
+func StarttimeInProcfs(PID PIDType, procroot string) (uint64, error) {
+	line, err := os.ReadFile(procroot + "/" + strconv.Itoa(int(PID)) + "/stat") // #nosec G304
+	if err != nil {
+		return 0, err
+	}
+	_, starttime, _, _, _, statFields := commonFromStatline(string(line))
+	if statFields == nil {
+		return 0, fmt.Errorf("invalid stat of process %d", PID)
+	}
+	return starttime, nil
+}
+
 // discoverTasks discovers the tasks of this particular process in the process
 // filesystem pointed to by procbase.
 func (p *Process) discoverTasks(procbase string) {
@@ -312,6 +339,19 @@ func (p *Process) Valid() bool {
 	return digitaltwin != nil && p.Starttime == digitaltwin.Starttime
 }
 
+// EnclosingContainer returns the container this process belongs to, if any.
+// As only the initial process of a container references its container,
+// EnclosingContainer looks for the nearest container along the process and
+// its ancestors.
+func (p *Process) EnclosingContainer() *Container {
+	for ; p != nil; p = p.Parent {
+		if p.Container != nil {
+			return p.Container
+		}
+	}
+	return nil
+}
+
 // String praises a Process object with a text hymn.
 func (p *Process) String() string {
 	if p == nil {
@@ -343,9 +383,19 @@ func NewProcessTableWithTasks(freezer bool) (pt ProcessTable) {
 // NewProcessTableFromProcfs implements [model.NewProcessTable] and allows for
 // testing on fake /proc "filesystems".
 func NewProcessTableFromProcfs(freezer bool, withtasks bool, procroot string) (pt ProcessTable) {
+	return NewProcessTableFromProcfsConcurrently(context.Background(), freezer, withtasks, procroot, 1)
+}
+
+// NewProcessTableFromProcfsConcurrently implements [NewProcessTableFromProcfs],
+// reading the details of the individual processes (and their tasks) as well as
+// their control groups using up to the specified number of workers in
+// parallel. Please note that the resulting process table is exactly the same
+// as when reading the process details sequentially. As soon as the specified
+// context gets cancelled, the scan stops and returns nil.
+func NewProcessTableFromProcfsConcurrently(ctx context.Context, freezer bool, withtasks bool, procroot string, workers int) (pt ProcessTable) {
 	// Phase I: discover all processes, together with some of their
 	// properties, such as name and PPID.
-	pt = map[PIDType]*Process{}
+	pids := []PIDType{}
 	for procentry := range faf.ReadDir(procroot) {
 		// Get the process PID as a number and then read its /proc/[PID]/stat
 		// procfs entry in order to get some details about the process. Skip
@@ -354,7 +404,16 @@ func NewProcessTableFromProcfs(freezer bool, withtasks bool, procroot string) (p
 		if err != nil || pid <= 0 {
 			continue
 		}
-		proc := NewProcessInProcfs(PIDType(pid), withtasks, procroot)
+		pids = append(pids, PIDType(pid))
+	}
+	procs := fanout.Map(ctx, workers, pids, func(pid PIDType) *Process {
+		return NewProcessInProcfs(pid, withtasks, procroot)
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	pt = map[PIDType]*Process{}
+	for _, proc := range procs {
 		if proc == nil {
 			continue
 		}
@@ -367,8 +426,13 @@ func NewProcessTableFromProcfs(freezer bool, withtasks bool, procroot string) (p
 	// which we'll need to run during namespace discovery. This is a simple
 	// optimization, just cutting map lookups at the expense of typed
 	// pointers. We're even so lazy as to not check for the PPID being
-	// present, as we'll get back a zero value anyway.
-	for _, proc := range pt {
+	// present, as we'll get back a zero value anyway. As the children are
+	// added in the order of the directory entries we've read, the order of
+	// children doesn't depend on the number of workers.
+	for _, proc := range procs {
+		if proc == nil {
+			continue
+		}
 		if parent, ok := pt[proc.PPID]; ok {
 			proc.Parent = parent
 			parent.Children = append(parent.Children, proc)
@@ -378,13 +442,16 @@ func NewProcessTableFromProcfs(freezer bool, withtasks bool, procroot string) (p
 	// scan, as we can do so from our current mount and cgroup namespaces.
 	// However, in order to correctly discover the cgroup paths for all
 	// processes we need to run this while inside the initial cgroup namespace.
-	pt.scanCgroups()
+	pt.scanCgroups(ctx, workers, procroot)
+	if ctx.Err() != nil {
+		return nil
+	}
 	// If requested, additionally scan for the freezer states; this is a more
 	// expensive operation in case we need to switch into the initial mount
 	// namespace, as otherwise we might not see the full cgroups freezer
 	// hierarchy.
 	if freezer {
-		pt.scanFridges()
+		pt.scanFridges(procroot)
 	}
 	// Phew: done.
 	return
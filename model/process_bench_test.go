@@ -0,0 +1,92 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+// Number of synthetic processes and tasks per process in the synthetic procfs
+// used for benchmarking.
+const (
+	benchProcesses       = 5000
+	benchTasksPerProcess = 4
+)
+
+// newSyntheticProcfs creates a synthetic procfs in a temporary directory with
+// the specified number of processes, each having the specified number of
+// tasks, and returns the path to the synthetic procfs root. The synthetic
+// processes use PIDs well above the usual PID range in order to not
+// accidentally pick up information from real processes, such as their control
+// groups.
+func newSyntheticProcfs(tb testing.TB, processes int, tasks int) string {
+	tb.Helper()
+	procroot := tb.TempDir()
+	const basePID = 3_000_000
+	for p := range processes {
+		pid := basePID + p*(tasks+1)
+		ppid := 1
+		if p > 0 {
+			ppid = basePID + (p/2)*(tasks+1)
+		}
+		procbase := filepath.Join(procroot, strconv.Itoa(pid))
+		writeSyntheticFile(tb, filepath.Join(procbase, "stat"), syntheticStatline(pid, ppid))
+		writeSyntheticFile(tb, filepath.Join(procbase, "cmdline"), "/usr/bin/synthetic\x00--foo\x00")
+		for t := range tasks + 1 {
+			tid := pid + t
+			writeSyntheticFile(tb,
+				filepath.Join(procbase, "task", strconv.Itoa(tid), "stat"),
+				syntheticStatline(tid, ppid))
+		}
+	}
+	return procroot
+}
+
+func syntheticStatline(pid, ppid int) string {
+	return fmt.Sprintf("%d (synthetic) S %d %d %d 0 -1 4194560 1 0 0 0 0 0 0 0 20 0 1 0 %d 1 1 1 1 1 1 1 1 1 1 1 1 1 1 1 1 1 0 0",
+		pid, ppid, pid, pid, 1000+pid)
+}
+
+func writeSyntheticFile(tb testing.TB, path string, contents string) {
+	tb.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func BenchmarkNewProcessTableFromProcfs(b *testing.B) {
+	procroot := newSyntheticProcfs(b, benchProcesses, benchTasksPerProcess)
+	for _, workers := range []int{1, 2, 4, runtime.NumCPU()} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				pt := NewProcessTableFromProcfsConcurrently(context.Background(), false, true, procroot, workers)
+				if len(pt) != benchProcesses {
+					b.Fatalf("expected %d processes, got %d", benchProcesses, len(pt))
+				}
+			}
+		})
+	}
+}
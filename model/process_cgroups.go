@@ -17,13 +17,17 @@ package model
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/thediveo/go-mntinfo"
+	"github.com/thediveo/lxkns/internal/fanout"
+	"github.com/thediveo/lxkns/mounts"
 )
 
 // scanCgroups scans all processes for their control groups; it scans only on a
@@ -32,25 +36,41 @@ import (
 // (well, "freezer") state. On a side note, the "memory" controller
 // unfortunately has been disabled on some architectures (ARM) for quite some
 // time.
-func (p ProcessTable) scanCgroups() {
-	for pid, proc := range p {
-		controllers := processCgroup(cgrouptypes, pid)
+//
+// The processes are scanned using up to the specified number of workers in
+// parallel; as each worker only updates its own process and tasks, there's no
+// need for any further synchronization. The control groups are read from the
+// proc filesystem mounted at procroot. Scanning stops as soon as the specified
+// context gets cancelled.
+func (p ProcessTable) scanCgroups(ctx context.Context, workers int, procroot string) {
+	fanout.Each(ctx, workers, slices.Collect(maps.Values(p)), func(proc *Process) {
+		controllers := processCgroup(cgrouptypes, proc.PID, procroot)
 		proc.CpuCgroup = controllers[0]
 		proc.FridgeCgroup = controllers[1]
 		for _, task := range proc.Tasks {
-			controllers := processCgroup(cgrouptypes, task.TID)
+			controllers := processCgroup(cgrouptypes, task.TID, procroot)
 			task.CpuCgroup = controllers[0]
 			task.FridgeCgroup = controllers[1]
 		}
-	}
+	})
 }
 
 var cgrouptypes = []string{"cpu", "freezer"}
 
+// ProcessCpuCgroup returns the path of the "cpu" control group of the process
+// with the specified PID, falling back to the unified cgroups v2 hierarchy,
+// as read from the proc filesystem mounted at procroot. This is the same path
+// as in [Process.CpuCgroup], but read freshly from the current cgroup
+// namespace. It returns "" if the control group cannot be read.
+func ProcessCpuCgroup(pid PIDType, procroot string) string {
+	return processCgroup(cgrouptypes[:1], pid, procroot)[0]
+}
+
 // scanFridges discovers the freezer states in the cgroups hierarchy; either
 // from the cgroups v1 freezer hierarchy if available, or from the unified
-// cgroups v2 hierarchy.
-func (p ProcessTable) scanFridges() {
+// cgroups v2 hierarchy. The hierarchy is accessed via the proc filesystem
+// mounted at procroot.
+func (p ProcessTable) scanFridges(procroot string) {
 	// First determine the list of unique cgroup freezer paths, as not every
 	// process (and most probably tasks) will have its own personal freezer and
 	// we can thus avoid reading the same states over and over again via the PID
@@ -72,7 +92,7 @@ func (p ProcessTable) scanFridges() {
 	// Now read the freezer states ... via the initial mount namespace because
 	// we can safely assume that there we have the proper full cgroup gory glory
 	// mounted to.
-	frozens := fridgeStates(fridgepaths)
+	frozens := fridgeStates(fridgepaths, procroot)
 	// ...and finally distribute the freezer states into the appropriate process
 	// (and task) objects. As there is no stable iteration order over the map
 	// between cgroup paths and freezer states we now propagate the states into
@@ -94,8 +114,8 @@ func (p ProcessTable) scanFridges() {
 // cannot be determined. The cgroup freezer paths are relative to the
 // auto-discovered cgroups hierarchy root, albeit usually specified as (pseudo)
 // absolute hierarchy paths (due to some ancient Linux kernel penguin foo).
-func fridgeStates(fridgepaths []string) (frozens []bool) {
-	fridgeroot, unified := fridgeRoot(1) // cgroups mounted in initial mount namespace with PID 1.
+func fridgeStates(fridgepaths []string, procroot string) (frozens []bool) {
+	fridgeroot, unified := fridgeRoot(1, procroot) // cgroups mounted in initial mount namespace with PID 1.
 	frozens = make([]bool, len(fridgepaths))
 	if unified {
 		// ...me not trusting Golang's toolchain to correctly optimizing the
@@ -169,9 +189,9 @@ func frozenV1(fridgepath string) (frozen bool) {
 // Note: the cgroup path(s) returned is (are) relative to the cgroups root, even
 // as they always start with "/" (for some reason, or other). And they are
 // subject to the current cgroup namespace, but not any mount namespace.
-func processCgroup(controllertypes []string, pid PIDType) (paths []string) {
+func processCgroup(controllertypes []string, pid PIDType, procroot string) (paths []string) {
 	paths = make([]string, len(controllertypes))
-	cgroup, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	cgroup, err := os.Open(fmt.Sprintf("%s/%d/cgroup", procroot, pid))
 	if err != nil {
 		return
 	}
@@ -228,24 +248,25 @@ func processCgroup(controllertypes []string, pid PIDType) (paths []string) {
 // namespace to which the specified process is attached. For cgroups v1 this
 // usually is its own hierarchy, for cgroups v2 this is the unified hierarchy.
 // The root path returned addresses the hierarchy root via the specified
-// process' root mount namespace "wormhole" (/proc/[PID]/root/...).
-func fridgeRoot(pid PIDType) (root string, unified bool) {
+// process' root mount namespace "wormhole" (/proc/[PID]/root/...), where /proc
+// is the proc filesystem mounted at procroot.
+func fridgeRoot(pid PIDType, procroot string) (root string, unified bool) {
 	// First search for a cgroups v1 freezer hierarchy, because a v2 unified
 	// hierarchy might also be present ... now don't let us worry about some
 	// software already using the v2 freezer in a hybrid configuration (argh).
-	for _, mountinfo := range mntinfo.MountsOfType(int(pid), "cgroup") {
+	for _, mountinfo := range mounts.MountsOfType(procroot, int(pid), "cgroup") {
 		for sopt := range strings.SplitSeq(mountinfo.SuperOptions, ",") {
 			if sopt == "freezer" {
-				root = "/proc/" + strconv.FormatInt(int64(pid), 10) + "/root" + mountinfo.MountPoint
+				root = procroot + "/" + strconv.FormatInt(int64(pid), 10) + "/root" + mountinfo.MountPoint
 				return
 			}
 		}
 	}
 	// ...otherwise, there must be a cgroups v2 unified hierarchy.
-	mountinfo := mntinfo.MountsOfType(int(pid), "cgroup2")
+	mountinfo := mounts.MountsOfType(procroot, int(pid), "cgroup2")
 	if len(mountinfo) > 0 {
 		unified = true
-		root = "/proc/" + strconv.FormatInt(int64(pid), 10) + "/root" + mountinfo[0].MountPoint
+		root = procroot + "/" + strconv.FormatInt(int64(pid), 10) + "/root" + mountinfo[0].MountPoint
 	}
 	return
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/samber/lo"
 	"github.com/thediveo/go-mntinfo"
+	"github.com/thediveo/lxkns/mounts"
 
 	"github.com/onsi/gomega/gexec"
 
@@ -84,6 +85,19 @@ var _ = Describe("cgrouping", func() {
 		)))
 	})
 
+	It("reads mounts from a proc filesystem mounted elsewhere", func() {
+		Expect(mounts.MountsOfType("test/mountinfo/proc", 1, "tmpfs")).To(HaveExactElements(
+			And(
+				HaveField("MountPoint", "/mnt/with space"),
+				HaveField("Source", "my tmp"),
+				HaveField("SuperOptions", "rw"))))
+		Expect(mounts.MountsOfType("test/mountinfo/proc", 666, "tmpfs")).To(BeEmpty())
+
+		root, unified := fridgeRoot(1, "test/mountinfo/proc")
+		Expect(unified).To(BeTrue())
+		Expect(root).To(Equal("test/mountinfo/proc/1/root/sys/fs/cgroup"))
+	})
+
 	It("gets fridge status", func() {
 		// since we're going to mess around with control groups, we need to be
 		// root (well, simplified constraint).
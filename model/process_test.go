@@ -15,6 +15,7 @@
 package model
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"runtime"
@@ -195,6 +196,27 @@ var _ = Describe("process table", func() {
 		Expect(proc1.Children[0]).To(BeIdenticalTo(proc42))
 	})
 
+	It("reads synthetic /proc concurrently", func() {
+		pt := NewProcessTableFromProcfsConcurrently(context.Background(), false, true, "test/proctable/proc", 4)
+		Expect(pt).To(HaveLen(2))
+		Expect(pt[1].Children).To(ConsistOf(BeIdenticalTo(pt[42])))
+		Expect(pt[42].Parent).To(BeIdenticalTo(pt[1]))
+
+		seqpt := NewProcessTableFromProcfs(false, true, "test/proctable/proc")
+		for pid, proc := range seqpt {
+			Expect(pt).To(HaveKey(pid))
+			Expect(pt[pid].Name).To(Equal(proc.Name))
+			Expect(pt[pid].Cmdline).To(Equal(proc.Cmdline))
+			Expect(pt[pid].Tasks).To(HaveLen(len(proc.Tasks)))
+		}
+	})
+
+	It("stops reading /proc when cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(NewProcessTableFromProcfsConcurrently(ctx, false, true, "test/proctable/proc", 4)).To(BeNil())
+	})
+
 	It("returns nil for inaccessible /proc", func() {
 		Expect(NewProcessTableFromProcfs(false, false, "test/nirvana")).To(BeNil())
 	})
@@ -0,0 +1,148 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/thediveo/lxkns/species"
+)
+
+// DefaultSharedNamespaceTypes are the namespace types to check for sharing
+// when not told otherwise. User and time namespaces are left out, as most
+// containers don't get their own ones but share the initial user and time
+// namespaces with the host.
+const DefaultSharedNamespaceTypes = species.AllNS &^ (species.CLONE_NEWUSER | species.CLONE_NEWTIME)
+
+// NamespaceSharings is a list of namespaces shared by multiple containers.
+type NamespaceSharings []NamespaceSharing
+
+// NamespaceSharing is a namespace shared by multiple containers, such as the
+// network namespace of the containers of a Kubernetes pod, or the network
+// namespace of a container joined by another container using “--network
+// container:x”.
+type NamespaceSharing struct {
+	// Namespace shared by the containers.
+	Namespace Namespace
+	// Containers sharing the namespace, ordered by name.
+	Containers Containers
+	// Group all containers sharing the namespace belong to when this sharing
+	// is intended, otherwise nil. Sharing an initial namespace is never
+	// intended, as the host doesn't belong to any group.
+	Group *Group
+	// Initial is true if the shared namespace is one of the initial
+	// namespaces, so the containers share it with the host, such as when
+	// using “--pid host”.
+	Initial bool
+}
+
+// Intended returns true if all containers sharing the namespace belong to
+// the same group of a type intended to share namespaces, such as a
+// Kubernetes pod.
+func (s NamespaceSharing) Intended() bool { return s.Group != nil }
+
+// Unexpected returns only the unexpected namespace sharings, keeping their
+// order. Sharing an initial namespace with the host is unexpected too.
+func (s NamespaceSharings) Unexpected() NamespaceSharings {
+	sharings := NamespaceSharings{}
+	for _, sharing := range s {
+		if !sharing.Intended() {
+			sharings = append(sharings, sharing)
+		}
+	}
+	return sharings
+}
+
+// SharedNamespaces returns the namespaces of the specified types (OR'ed
+// together) shared by multiple containers, where the containers are related
+// to their namespaces through their initial processes. Sharing is considered
+// to be intended if all containers sharing a namespace belong to the same
+// group, with the group being of one of the specified group types, such as
+// [github.com/thediveo/lxkns/decorator/kuhbernetes.PodGroupType]. Otherwise,
+// the sharing is unexpected, as it happens between unrelated containers or
+// groups. Containers sharing one of the specified initial namespaces, such as
+// [github.com/thediveo/lxkns/discover.Result.InitialNamespaces], share it with
+// the host instead of only among themselves, so these sharings are marked as
+// Initial and never considered intended.
+//
+// Pass [DefaultSharedNamespaceTypes] as nstypes in order to skip the user and
+// time namespaces most containers share with the host.
+//
+// The namespace sharings are ordered by namespace type in the order of
+// [TypesByIndex], and then by namespace identifier.
+func (cs Containers) SharedNamespaces(initialns NamespacesSet, nstypes species.NamespaceType, grouptypes ...string) NamespaceSharings {
+	sharings := NamespaceSharings{}
+	for typeidx, nstype := range TypesByIndex {
+		if nstypes&nstype == 0 {
+			continue
+		}
+		sharers := map[species.NamespaceID]Containers{}
+		namespaces := map[species.NamespaceID]Namespace{}
+		for _, container := range cs {
+			if container.Process == nil {
+				continue
+			}
+			ns := container.Process.Namespaces[typeidx]
+			if ns == nil {
+				continue
+			}
+			sharers[ns.ID()] = append(sharers[ns.ID()], container)
+			namespaces[ns.ID()] = ns
+		}
+		var typesharings NamespaceSharings
+		for nsid, containers := range sharers {
+			if len(containers) < 2 {
+				continue
+			}
+			slices.SortFunc(containers, func(a, b *Container) int {
+				return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+			})
+			sharing := NamespaceSharing{
+				Namespace:  namespaces[nsid],
+				Containers: containers,
+			}
+			if initialns[typeidx] != nil && initialns[typeidx].ID() == nsid {
+				sharing.Initial = true
+			} else {
+				sharing.Group = commonGroup(containers, grouptypes)
+			}
+			typesharings = append(typesharings, sharing)
+		}
+		slices.SortFunc(typesharings, func(a, b NamespaceSharing) int {
+			return cmp.Compare(a.Namespace.ID().Ino, b.Namespace.ID().Ino)
+		})
+		sharings = append(sharings, typesharings...)
+	}
+	return sharings
+}
+
+// commonGroup returns the group of one of the specified group types that all
+// specified containers belong to, otherwise nil.
+func commonGroup(containers Containers, grouptypes []string) *Group {
+	for _, group := range containers[0].Groups {
+		if !slices.Contains(grouptypes, group.Type) {
+			continue
+		}
+		if !slices.ContainsFunc(containers[1:], func(c *Container) bool {
+			return !slices.Contains(c.Groups, group)
+		}) {
+			return group
+		}
+	}
+	return nil
+}
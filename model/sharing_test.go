@@ -0,0 +1,144 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+// sharedNamespace is a namespace stand-in only implementing the ID and Type
+// methods.
+type sharedNamespace struct {
+	Namespace
+	id     species.NamespaceID
+	nstype species.NamespaceType
+}
+
+func (n *sharedNamespace) ID() species.NamespaceID     { return n.id }
+func (n *sharedNamespace) Type() species.NamespaceType { return n.nstype }
+
+var _ = Describe("namespace sharing", func() {
+
+	var initialnetns, netns1, netns2, netns3, pidns1, pidns2 Namespace
+	var initialns NamespacesSet
+	var pod, hostpod, project *Group
+	var containers Containers
+
+	// container returns a new container attached to the specified network and
+	// PID namespaces, belonging to the specified groups.
+	container := func(name string, netns, pidns Namespace, groups ...*Group) *Container {
+		proc := &Process{}
+		proc.Namespaces[NetNS] = netns
+		proc.Namespaces[PIDNS] = pidns
+		c := &Container{ID: name + "-id", Name: name, Process: proc}
+		for _, group := range groups {
+			group.AddContainer(c)
+		}
+		return c
+	}
+
+	BeforeEach(func() {
+		initialnetns = &sharedNamespace{id: species.NamespaceID{Dev: 4, Ino: 4}, nstype: species.CLONE_NEWNET}
+		initialns = NamespacesSet{}
+		initialns[NetNS] = initialnetns
+		netns1 = &sharedNamespace{id: species.NamespaceID{Dev: 4, Ino: 3}, nstype: species.CLONE_NEWNET}
+		netns2 = &sharedNamespace{id: species.NamespaceID{Dev: 4, Ino: 2}, nstype: species.CLONE_NEWNET}
+		netns3 = &sharedNamespace{id: species.NamespaceID{Dev: 4, Ino: 1}, nstype: species.CLONE_NEWNET}
+		pidns1 = &sharedNamespace{id: species.NamespaceID{Dev: 4, Ino: 10}, nstype: species.CLONE_NEWPID}
+		pidns2 = &sharedNamespace{id: species.NamespaceID{Dev: 4, Ino: 11}, nstype: species.CLONE_NEWPID}
+		pod = &Group{Name: "default/foo", Type: "io.kubernetes.pod"}
+		hostpod = &Group{Name: "kube-system/baz", Type: "io.kubernetes.pod"}
+		project = &Group{Name: "bar", Type: "com.docker.compose.project"}
+		// A pod with its sandbox and a workload container sharing the pod's
+		// network namespace, a container joining the network namespace of
+		// another unrelated container, a container sharing the PID namespace
+		// of a pod container, and a pod using the initial network namespace.
+		containers = Containers{
+			container("hostsandbox", initialnetns, nil, hostpod),
+			container("hostworkload", initialnetns, nil, hostpod),
+			container("sandbox", netns1, pidns1, pod),
+			container("workload", netns1, pidns2, pod),
+			container("joiner", netns2, pidns2, project),
+			container("joinee", netns2, nil),
+			container("loner", netns3, nil, project),
+			{Name: "processless"},
+		}
+	})
+
+	It("returns shared namespaces", func() {
+		sharings := containers.SharedNamespaces(initialns, species.AllNS, "io.kubernetes.pod")
+		Expect(sharings).To(HaveExactElements(
+			And(
+				HaveField("Namespace", BeIdenticalTo(pidns2)),
+				HaveField("Containers", HaveExactElements(
+					HaveField("Name", "joiner"),
+					HaveField("Name", "workload"))),
+				HaveField("Intended()", BeFalse())),
+			And(
+				HaveField("Namespace", BeIdenticalTo(netns2)),
+				HaveField("Containers", HaveExactElements(
+					HaveField("Name", "joinee"),
+					HaveField("Name", "joiner"))),
+				HaveField("Intended()", BeFalse())),
+			And(
+				HaveField("Namespace", BeIdenticalTo(netns1)),
+				HaveField("Containers", HaveExactElements(
+					HaveField("Name", "sandbox"),
+					HaveField("Name", "workload"))),
+				HaveField("Group", BeIdenticalTo(pod)),
+				HaveField("Intended()", BeTrue())),
+			And(
+				HaveField("Namespace", BeIdenticalTo(initialnetns)),
+				HaveField("Containers", HaveExactElements(
+					HaveField("Name", "hostsandbox"),
+					HaveField("Name", "hostworkload"))),
+				HaveField("Initial", BeTrue()),
+				HaveField("Group", BeNil()),
+				HaveField("Intended()", BeFalse())),
+		))
+		Expect(sharings[:3]).To(HaveEach(HaveField("Initial", BeFalse())))
+		Expect(sharings.Unexpected()).To(HaveExactElements(
+			HaveField("Namespace", BeIdenticalTo(pidns2)),
+			HaveField("Namespace", BeIdenticalTo(netns2)),
+			HaveField("Namespace", BeIdenticalTo(initialnetns)),
+		))
+	})
+
+	It("tells sharing with the host only from the specified initial namespaces", func() {
+		Expect(containers.SharedNamespaces(NamespacesSet{}, species.CLONE_NEWNET, "io.kubernetes.pod")).To(ContainElement(And(
+			HaveField("Namespace", BeIdenticalTo(initialnetns)),
+			HaveField("Initial", BeFalse()),
+			HaveField("Group", BeIdenticalTo(hostpod)))))
+	})
+
+	It("returns only shared namespaces of the specified types", func() {
+		Expect(containers.SharedNamespaces(initialns, species.CLONE_NEWPID, "io.kubernetes.pod")).To(HaveExactElements(
+			HaveField("Namespace", BeIdenticalTo(pidns2))))
+		Expect(containers.SharedNamespaces(initialns, species.CLONE_NEWUTS)).To(BeEmpty())
+	})
+
+	It("considers sharing unexpected without intended group types", func() {
+		Expect(containers.SharedNamespaces(initialns, species.CLONE_NEWNET)).To(HaveEach(
+			HaveField("Intended()", BeFalse())))
+		Expect(containers.SharedNamespaces(initialns, species.CLONE_NEWNET, "com.docker.compose.project")).To(HaveEach(
+			HaveField("Intended()", BeFalse())))
+	})
+
+})
@@ -0,0 +1,160 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"net/netip"
+	"slices"
+)
+
+// SocketInventory informs about the sockets of a network namespace. Only
+// network namespaces provide and implement SocketInventory.
+//
+// Sockets are only discovered when opting in, as this requires switching into
+// each network namespace.
+type SocketInventory interface {
+	// Sockets returns the TCP, UDP, UNIX domain, and packet sockets of this
+	// network namespace, or nil if unknown.
+	Sockets() []Socket
+}
+
+// Socket describes a socket of a network namespace, together with the
+// processes having this socket open. Which fields are set depends on the
+// socket's protocol.
+type Socket struct {
+	Protocol  SocketProtocol `json:"protocol"`            // protocol, such as "tcp" or "unix".
+	Type      SocketType     `json:"type,omitempty"`      // UNIX domain and packet sockets only: socket type, such as "stream".
+	State     SocketState    `json:"state,omitempty"`     // TCP-style state; empty for packet sockets.
+	Inode     uint64         `json:"inode"`               // socket inode number.
+	Local     netip.AddrPort `json:"local,omitzero"`      // TCP and UDP sockets only: local address and port.
+	Remote    netip.AddrPort `json:"remote,omitzero"`     // TCP and UDP sockets only: remote address and port.
+	Path      string         `json:"path,omitempty"`      // UNIX domain sockets only: bound path, with abstract names starting with "@".
+	Peer      uint64         `json:"peer,omitempty"`      // UNIX domain sockets only: inode number of the connected peer socket.
+	VFSInode  uint64         `json:"vfs-inode,omitempty"` // UNIX domain sockets only: inode number of the bound socket file.
+	VFSDev    uint64         `json:"vfs-dev,omitempty"`   // UNIX domain sockets only: device number of the bound socket file, as in stat(2).
+	Interface int            `json:"ifindex,omitempty"`   // packet sockets only: index of the bound network interface.
+	EtherType uint16         `json:"ethertype,omitempty"` // packet sockets only: Ethernet protocol, such as 0x0003 for all protocols.
+	PIDs      []PIDType      `json:"pids,omitempty"`      // PIDs of the processes having this socket open, if known.
+}
+
+// SocketProtocol is the protocol of a socket.
+type SocketProtocol string
+
+// The socket protocols discovered.
+const (
+	SocketProtocolTCP    SocketProtocol = "tcp"
+	SocketProtocolUDP    SocketProtocol = "udp"
+	SocketProtocolUNIX   SocketProtocol = "unix"
+	SocketProtocolPacket SocketProtocol = "packet"
+)
+
+// SocketType is the type of a UNIX domain or packet socket.
+type SocketType string
+
+// The socket types of UNIX domain and packet sockets.
+const (
+	SocketTypeStream    SocketType = "stream"
+	SocketTypeDgram     SocketType = "dgram"
+	SocketTypeRaw       SocketType = "raw"
+	SocketTypeSeqpacket SocketType = "seqpacket"
+)
+
+// socketTypes maps the socket type values used by the Linux kernel (SOCK_*)
+// to their names.
+var socketTypes = map[uint8]SocketType{
+	1: SocketTypeStream,
+	2: SocketTypeDgram,
+	3: SocketTypeRaw,
+	5: SocketTypeSeqpacket,
+}
+
+// SocketTypeFromKernel returns the socket type corresponding with the
+// specified Linux kernel SOCK_* value, or an empty type if unknown.
+func SocketTypeFromKernel(socktype uint8) SocketType {
+	return socketTypes[socktype]
+}
+
+// SocketState is the TCP-style state of a TCP, UDP, or UNIX domain socket.
+// UDP and UNIX domain sockets only use a subset of these states.
+type SocketState string
+
+// The TCP-style socket states, as reported by the Linux kernel.
+const (
+	SocketStateEstablished SocketState = "established"
+	SocketStateSynSent     SocketState = "syn-sent"
+	SocketStateSynRecv     SocketState = "syn-recv"
+	SocketStateFinWait1    SocketState = "fin-wait-1"
+	SocketStateFinWait2    SocketState = "fin-wait-2"
+	SocketStateTimeWait    SocketState = "time-wait"
+	SocketStateUnconnected SocketState = "unconnected"
+	SocketStateCloseWait   SocketState = "close-wait"
+	SocketStateLastAck     SocketState = "last-ack"
+	SocketStateListen      SocketState = "listen"
+	SocketStateClosing     SocketState = "closing"
+	SocketStateNewSynRecv  SocketState = "new-syn-recv"
+)
+
+// socketStates maps the socket state values used by the Linux kernel (TCP_*)
+// to their names; the kernel starts its socket states with 1.
+var socketStates = [...]SocketState{
+	"",
+	SocketStateEstablished,
+	SocketStateSynSent,
+	SocketStateSynRecv,
+	SocketStateFinWait1,
+	SocketStateFinWait2,
+	SocketStateTimeWait,
+	SocketStateUnconnected,
+	SocketStateCloseWait,
+	SocketStateLastAck,
+	SocketStateListen,
+	SocketStateClosing,
+	SocketStateNewSynRecv,
+}
+
+// SocketStateFromKernel returns the socket state corresponding with the
+// specified Linux kernel TCP_* state value, or an empty state if unknown.
+func SocketStateFromKernel(state uint8) SocketState {
+	if int(state) >= len(socketStates) {
+		return ""
+	}
+	return socketStates[state]
+}
+
+// Processes returns the processes having this socket open, looked up in the
+// specified process table.
+func (s *Socket) Processes(procs ProcessTable) []*Process {
+	var processes []*Process
+	for _, pid := range s.PIDs {
+		if proc, ok := procs[pid]; ok {
+			processes = append(processes, proc)
+		}
+	}
+	return processes
+}
+
+// Containers returns the containers of the processes having this socket open,
+// looked up in the specified process table. Please note that this requires a
+// discovery with containers, as otherwise there won't be any containers.
+func (s *Socket) Containers(procs ProcessTable) []*Container {
+	var containers []*Container
+	for _, proc := range s.Processes(procs) {
+		if container := proc.EnclosingContainer(); container != nil &&
+			!slices.Contains(containers, container) {
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sockets", func() {
+
+	It("maps kernel socket types and states", func() {
+		Expect(SocketTypeFromKernel(1)).To(Equal(SocketTypeStream))
+		Expect(SocketTypeFromKernel(5)).To(Equal(SocketTypeSeqpacket))
+		Expect(SocketTypeFromKernel(42)).To(BeEmpty())
+
+		Expect(SocketStateFromKernel(0)).To(BeEmpty())
+		Expect(SocketStateFromKernel(1)).To(Equal(SocketStateEstablished))
+		Expect(SocketStateFromKernel(7)).To(Equal(SocketStateUnconnected))
+		Expect(SocketStateFromKernel(10)).To(Equal(SocketStateListen))
+		Expect(SocketStateFromKernel(42)).To(BeEmpty())
+	})
+
+	It("marshals sockets", func() {
+		j, err := json.Marshal(Socket{
+			Protocol: SocketProtocolTCP,
+			State:    SocketStateListen,
+			Inode:    42,
+			Local:    netip.MustParseAddrPort("[::]:8080"),
+			Remote:   netip.MustParseAddrPort("[::]:0"),
+			PIDs:     []PIDType{666},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`{
+			"protocol": "tcp",
+			"state": "listen",
+			"inode": 42,
+			"local": "[::]:8080",
+			"remote": "[::]:0",
+			"pids": [666]
+		}`))
+
+		j, err = json.Marshal(Socket{
+			Protocol: SocketProtocolUNIX,
+			Type:     SocketTypeStream,
+			State:    SocketStateEstablished,
+			Inode:    42,
+			Path:     "/run/docker.sock",
+			Peer:     666,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j).To(MatchJSON(`{
+			"protocol": "unix",
+			"type": "stream",
+			"state": "established",
+			"inode": 42,
+			"path": "/run/docker.sock",
+			"peer": 666
+		}`))
+	})
+
+	It("attributes sockets to processes and containers", func() {
+		container := &Container{ID: "deadbeef", Name: "foobar"}
+		initproc := &Process{PID: 1}
+		shim := &Process{PID: 42, Parent: initproc}
+		leader := &Process{PID: 666, Parent: shim, Container: container}
+		worker := &Process{PID: 667, Parent: leader}
+		procs := ProcessTable{1: initproc, 42: shim, 666: leader, 667: worker}
+
+		Expect(worker.EnclosingContainer()).To(BeIdenticalTo(container))
+		Expect(shim.EnclosingContainer()).To(BeNil())
+
+		sock := Socket{PIDs: []PIDType{666, 667, 1234}}
+		Expect(sock.Processes(procs)).To(ConsistOf(leader, worker))
+		Expect(sock.Containers(procs)).To(ConsistOf(container))
+
+		sock = Socket{PIDs: []PIDType{42}}
+		Expect(sock.Containers(procs)).To(BeEmpty())
+	})
+
+})
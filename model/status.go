@@ -0,0 +1,79 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strconv"
+)
+
+// StatusDetails selects the details to retrieve from the status file of a
+// process or task, such as “/proc/self/status”.
+type StatusDetails uint
+
+// Details retrievable from the status file of a process or task; they can be
+// combined in order to retrieve multiple details from a single read of the
+// status file.
+const (
+	StatusCapabilities StatusDetails = 1 << iota // capability sets
+	StatusCredentials                            // user and group credentials
+)
+
+// RetrieveStatus updates this Process object's details from its status file in
+// the proc filesystem mounted at procroot, reading the status file only once.
+// It returns nil when successful. Otherwise, it returns an error, updating
+// only the details that could be successfully parsed.
+func (p *Process) RetrieveStatus(procroot string, details StatusDetails) error {
+	return p.retrieveStatus(procroot+"/"+strconv.Itoa(int(p.PID))+"/status", details)
+}
+
+// RetrieveStatus updates this Task object's details from its status file in
+// the proc filesystem mounted at procroot, reading the status file only once.
+// It returns nil when successful. Otherwise, it returns an error, updating
+// only the details that could be successfully parsed.
+func (t *Task) RetrieveStatus(procroot string, details StatusDetails) error {
+	return t.retrieveStatus(procroot+"/"+strconv.Itoa(int(t.Process.PID))+
+		"/task/"+strconv.Itoa(int(t.TID))+"/status", details)
+}
+
+func (c *ProTaskCommon) retrieveStatus(path string, details StatusDetails) error {
+	if details == 0 {
+		return nil
+	}
+	status, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return err
+	}
+	var errs []error
+	if details&StatusCapabilities != 0 {
+		capabilities, err := ParseCapabilities(bytes.NewReader(status))
+		if err == nil {
+			c.Capabilities = capabilities
+		}
+		errs = append(errs, err)
+	}
+	if details&StatusCredentials != 0 {
+		credentials, err := ParseCredentials(bytes.NewReader(status))
+		if err == nil {
+			c.Credentials = credentials
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
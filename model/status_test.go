@@ -0,0 +1,65 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("status", func() {
+
+	It("retrieves capabilities and credentials together", func() {
+		proc := NewProcess(PIDType(os.Getpid()), true)
+		Expect(proc).NotTo(BeNil())
+		Expect(proc.RetrieveStatus("/proc", 0)).To(Succeed())
+		Expect(proc.Capabilities).To(BeNil())
+		Expect(proc.Credentials).To(BeNil())
+
+		Expect(proc.RetrieveStatus("/proc", StatusCapabilities|StatusCredentials)).To(Succeed())
+		Expect(proc.Capabilities).NotTo(BeNil())
+		Expect(proc.Credentials).NotTo(BeNil())
+		Expect(proc.Credentials.UIDs.Effective).To(Equal(uint32(os.Geteuid())))
+
+		Expect(proc.Tasks).NotTo(BeEmpty())
+		task := proc.Tasks[0]
+		Expect(task.RetrieveStatus("/proc", StatusCredentials)).To(Succeed())
+		Expect(task.Capabilities).To(BeNil())
+		Expect(task.Credentials).To(Equal(proc.Credentials))
+
+		Expect((&Process{PID: -1}).RetrieveStatus("/proc", StatusCapabilities)).NotTo(Succeed())
+	})
+
+	It("retrieves what it can from an incomplete status", func() {
+		procroot := GinkgoT().TempDir()
+		Expect(os.Mkdir(filepath.Join(procroot, "42"), 0o755)).To(Succeed())
+		status, err := os.ReadFile("test/credentials/status-good")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(procroot, "42", "status"), status, 0o644)).To(Succeed())
+
+		proc := &Process{PID: 42}
+		Expect(proc.RetrieveStatus(procroot, StatusCapabilities|StatusCredentials)).To(
+			MatchError(ContainSubstring("missing effective capabilities")))
+		Expect(proc.Capabilities).To(BeNil())
+		Expect(proc.Credentials).NotTo(BeNil())
+		Expect(proc.Credentials.UIDs.Real).To(Equal(uint32(1000)))
+	})
+
+})
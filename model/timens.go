@@ -0,0 +1,125 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOffsets informs about the clock offsets of a time namespace. Only time
+// namespaces provide and implement TimeOffsets.
+//
+// The clock offsets are read from the “timens_offsets” file of a leader
+// process of a time namespace, so time namespaces without any processes lack
+// clock offset information.
+type TimeOffsets interface {
+	// ClockOffsets returns the offsets of the monotonic and boottime clocks of
+	// this time namespace, or nil if unknown.
+	ClockOffsets() *ClockOffsets
+}
+
+// ClockOffsets are the offsets of the monotonic and boottime clocks inside a
+// time namespace, relative to the initial time namespace.
+type ClockOffsets struct {
+	Monotonic time.Duration `json:"monotonic"` // offset of CLOCK_MONOTONIC (and its variants).
+	Boottime  time.Duration `json:"boottime"`  // offset of CLOCK_BOOTTIME (and its variants).
+}
+
+// Clock IDs as used in “timens_offsets”, as an alternative to clock names.
+const (
+	clockMonotonic = 1
+	clockBoottime  = 7
+)
+
+// ReadClockOffsets reads the clock offsets of a time namespace from the
+// specified file, such as “/proc/self/timens_offsets”.
+func ReadClockOffsets(path string) (*ClockOffsets, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ParseClockOffsets(f)
+}
+
+// ParseClockOffsets parses the clock offsets of a time namespace in the
+// textual format of “/proc/[PID]/timens_offsets”, with one clock per line
+// consisting of the clock name (or ID), followed by the offset's seconds and
+// nanoseconds.
+func ParseClockOffsets(r io.Reader) (*ClockOffsets, error) {
+	offsets := &ClockOffsets{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid clock offset line %q", scanner.Text())
+		}
+		secs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clock offset line %q, %w", scanner.Text(), err)
+		}
+		nsecs, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clock offset line %q, %w", scanner.Text(), err)
+		}
+		offset := time.Duration(secs)*time.Second + time.Duration(nsecs)
+		switch fields[0] {
+		case "monotonic", strconv.Itoa(clockMonotonic):
+			offsets.Monotonic = offset
+		case "boottime", strconv.Itoa(clockBoottime):
+			offsets.Boottime = offset
+		default:
+			return nil, fmt.Errorf("invalid clock %q", fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return offsets, nil
+}
+
+// IsZero returns true if neither the monotonic nor the boottime clock are
+// offset, as is the case for the initial time namespace.
+func (o *ClockOffsets) IsZero() bool {
+	return o == nil || (o.Monotonic == 0 && o.Boottime == 0)
+}
+
+// String returns a textual representation of the clock offsets, such as
+// “monotonic +1h0m0s, boottime -5s”.
+func (o *ClockOffsets) String() string {
+	if o == nil {
+		return ""
+	}
+	return "monotonic " + signedDuration(o.Monotonic) +
+		", boottime " + signedDuration(o.Boottime)
+}
+
+// signedDuration returns the textual representation of the specified duration
+// with an explicit sign, unless zero.
+func signedDuration(d time.Duration) string {
+	if d > 0 {
+		return "+" + d.String()
+	}
+	return d.String()
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("clock offsets", func() {
+
+	It("parses clock offsets", func() {
+		offsets, err := ParseClockOffsets(strings.NewReader(
+			"monotonic        3600         0\n" +
+				"boottime           -5 500000000\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offsets).To(Equal(&ClockOffsets{
+			Monotonic: time.Hour,
+			Boottime:  -4500 * time.Millisecond,
+		}))
+		Expect(offsets.IsZero()).To(BeFalse())
+		Expect(offsets.String()).To(Equal("monotonic +1h0m0s, boottime -4.5s"))
+
+		offsets, err = ParseClockOffsets(strings.NewReader("1 42 0\n7 0 0\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offsets.Monotonic).To(Equal(42 * time.Second))
+		Expect(offsets.Boottime).To(BeZero())
+	})
+
+	It("handles zero clock offsets", func() {
+		offsets, err := ParseClockOffsets(strings.NewReader(
+			"monotonic 0 0\nboottime 0 0\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offsets.IsZero()).To(BeTrue())
+		Expect(offsets.String()).To(Equal("monotonic 0s, boottime 0s"))
+
+		var nooffsets *ClockOffsets
+		Expect(nooffsets.IsZero()).To(BeTrue())
+		Expect(nooffsets.String()).To(BeEmpty())
+	})
+
+	It("rejects invalid clock offsets", func() {
+		for _, text := range []string{
+			"monotonic 0\n",
+			"monotonic x 0\n",
+			"monotonic 0 x\n",
+			"realtime 0 0\n",
+			"0 0 0\n",
+		} {
+			_, err := ParseClockOffsets(strings.NewReader(text))
+			Expect(err).To(HaveOccurred(), "text %q", text)
+		}
+	})
+
+	It("reads clock offsets", func() {
+		_, err := ReadClockOffsets("/nonexisting")
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,134 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/thediveo/lxkns/species"
+)
+
+// UnixSocketGraph is a graph of connected UNIX domain sockets across network
+// namespaces: its nodes are connected UNIX domain sockets and its edges are
+// connections between pairs of sockets. Such connections often cross network
+// and mount namespaces, such as when containers talk to a container engine
+// via its API socket.
+//
+// As socket inode numbers are unique system-wide, the nodes are identified by
+// their socket inode numbers.
+//
+// Use [NewUnixSocketGraph] to build the graph from the sockets of discovered
+// network namespaces.
+type UnixSocketGraph struct {
+	Nodes []UnixSocketNode `json:"nodes,omitempty"` // connected UNIX domain sockets, sorted by inode number.
+	Edges []UnixSocketEdge `json:"edges,omitempty"` // connections between UNIX domain sockets.
+}
+
+// UnixSocketNode is a connected UNIX domain socket in a [UnixSocketGraph],
+// together with the network namespace it belongs to.
+type UnixSocketNode struct {
+	NetNS species.NamespaceID `json:"netns"` // network namespace of the socket.
+	Socket
+}
+
+// UnixSocketEdge connects two UNIX domain sockets, identified by their inode
+// numbers. Connections are symmetric and thus only present once.
+type UnixSocketEdge struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// NewUnixSocketGraph returns the graph of the connected UNIX domain sockets of
+// the specified network namespaces. Sockets connected to peers in network
+// namespaces without known sockets still get an edge, but their peers don't
+// become nodes.
+func NewUnixSocketGraph(netnsmap NamespaceMap) UnixSocketGraph {
+	graph := UnixSocketGraph{}
+	for netnsid, netns := range netnsmap {
+		inventory, ok := netns.(SocketInventory)
+		if !ok {
+			continue
+		}
+		for _, socket := range inventory.Sockets() {
+			if socket.Protocol != SocketProtocolUNIX || socket.Peer == 0 {
+				continue
+			}
+			graph.Nodes = append(graph.Nodes, UnixSocketNode{
+				NetNS:  netnsid,
+				Socket: socket,
+			})
+		}
+	}
+	slices.SortFunc(graph.Nodes, func(a, b UnixSocketNode) int {
+		return cmp.Compare(a.Inode, b.Inode)
+	})
+	for _, node := range graph.Nodes {
+		// Both ends of a connection reference each other, so we add the
+		// connection only once, unless the peer is unknown.
+		if _, ok := graph.Node(node.Peer); ok && node.Peer < node.Inode {
+			continue
+		}
+		graph.Edges = append(graph.Edges, UnixSocketEdge{
+			From: node.Inode,
+			To:   node.Peer,
+		})
+	}
+	return graph
+}
+
+// Node returns the connected UNIX domain socket with the specified inode
+// number, if known.
+func (g *UnixSocketGraph) Node(inode uint64) (UnixSocketNode, bool) {
+	idx, ok := slices.BinarySearchFunc(g.Nodes, inode, func(node UnixSocketNode, inode uint64) int {
+		return cmp.Compare(node.Inode, inode)
+	})
+	if !ok {
+		return UnixSocketNode{}, false
+	}
+	return g.Nodes[idx], true
+}
+
+// Peer returns the peer of the UNIX domain socket with the specified inode
+// number, if known.
+func (g *UnixSocketGraph) Peer(inode uint64) (UnixSocketNode, bool) {
+	node, ok := g.Node(inode)
+	if !ok {
+		return UnixSocketNode{}, false
+	}
+	return g.Node(node.Peer)
+}
+
+// Clients returns the client ends of the connections to the UNIX domain socket
+// file with the specified device and inode number, as returned by stat(2) for
+// a socket file such as “/run/docker.sock”. As a socket file might be visible
+// under different paths in different mount namespaces, Clients identifies the
+// socket file by its device and inode number instead of its path.
+//
+// Server-side sockets accepted from a listening socket report the same socket
+// file as the listening socket, so the peers of these accepted sockets are the
+// client ends.
+func (g *UnixSocketGraph) Clients(dev, ino uint64) []UnixSocketNode {
+	var clients []UnixSocketNode
+	for _, node := range g.Nodes {
+		if node.VFSDev != dev || node.VFSInode != ino {
+			continue
+		}
+		if peer, ok := g.Node(node.Peer); ok {
+			clients = append(clients, peer)
+		}
+	}
+	return clients
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+// netnsSockets is a network namespace stand-in only implementing the
+// SocketInventory interface.
+type netnsSockets struct {
+	Namespace
+	sockets []Socket
+}
+
+func (n *netnsSockets) Sockets() []Socket { return n.sockets }
+
+var _ = Describe("UNIX domain socket graph", func() {
+
+	hostid := species.NamespaceID{Dev: 4, Ino: 1}
+	ctrid := species.NamespaceID{Dev: 4, Ino: 2}
+
+	// host: a listening engine API socket, the accepted server end of a
+	// container's connection to it, as well as a connection between two
+	// host processes. container: the client end of the connection to the
+	// engine API socket, as well as a socket connected to a peer in an
+	// unknown network namespace.
+	netnsmap := NamespaceMap{
+		hostid: &netnsSockets{sockets: []Socket{
+			{Protocol: SocketProtocolUNIX, Inode: 10, Path: "/run/docker.sock", VFSDev: 25, VFSInode: 1234, PIDs: []PIDType{1}},
+			{Protocol: SocketProtocolUNIX, Inode: 11, Path: "/run/docker.sock", VFSDev: 25, VFSInode: 1234, Peer: 20, PIDs: []PIDType{1}},
+			{Protocol: SocketProtocolUNIX, Inode: 12, Peer: 13},
+			{Protocol: SocketProtocolUNIX, Inode: 13, Peer: 12},
+			{Protocol: SocketProtocolTCP, Inode: 14},
+		}},
+		ctrid: &netnsSockets{sockets: []Socket{
+			{Protocol: SocketProtocolUNIX, Inode: 20, Peer: 11, PIDs: []PIDType{42}},
+			{Protocol: SocketProtocolUNIX, Inode: 21, Peer: 666},
+		}},
+	}
+
+	It("builds the graph", func() {
+		graph := NewUnixSocketGraph(netnsmap)
+		Expect(graph.Nodes).To(HaveLen(5))
+		Expect(graph.Nodes[0].Inode).To(Equal(uint64(11)))
+		Expect(graph.Nodes[0].NetNS).To(Equal(hostid))
+		Expect(graph.Nodes[3].NetNS).To(Equal(ctrid))
+		Expect(graph.Edges).To(ConsistOf(
+			UnixSocketEdge{From: 11, To: 20},
+			UnixSocketEdge{From: 12, To: 13},
+			UnixSocketEdge{From: 21, To: 666},
+		))
+
+		j, err := json.Marshal(graph)
+		Expect(err).NotTo(HaveOccurred())
+		var g UnixSocketGraph
+		Expect(json.Unmarshal(j, &g)).To(Succeed())
+		Expect(g).To(Equal(graph))
+	})
+
+	It("navigates the graph", func() {
+		graph := NewUnixSocketGraph(netnsmap)
+
+		_, ok := graph.Node(10)
+		Expect(ok).To(BeFalse(), "listening sockets aren't connected")
+
+		peer, ok := graph.Peer(20)
+		Expect(ok).To(BeTrue())
+		Expect(peer.Inode).To(Equal(uint64(11)))
+		_, ok = graph.Peer(21)
+		Expect(ok).To(BeFalse())
+
+		clients := graph.Clients(25, 1234)
+		Expect(clients).To(HaveLen(1))
+		Expect(clients[0].NetNS).To(Equal(ctrid))
+		Expect(clients[0].PIDs).To(ConsistOf(PIDType(42)))
+		Expect(graph.Clients(25, 4321)).To(BeEmpty())
+	})
+
+})
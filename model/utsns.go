@@ -0,0 +1,32 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+// UTSNames informs about the host (node) name and NIS domain name of a UTS
+// namespace, as returned by uname(2) when inside the UTS namespace. Only UTS
+// namespaces provide and implement UTSNames.
+//
+// The names are read by temporarily switching into a UTS namespace, so they
+// are unknown for UTS namespaces the discovery couldn't switch into, such as
+// when discovering without the necessary privileges.
+type UTSNames interface {
+	// Nodename returns the host (node) name of this UTS namespace, or "" if
+	// unknown.
+	Nodename() string
+	// Domainname returns the NIS domain name of this UTS namespace, or "" if
+	// unknown. Please note that an unset NIS domain name is usually reported
+	// as "(none)".
+	Domainname() string
+}
@@ -34,6 +34,12 @@ Mount points have unique (integer) identifiers; however, while these are
 unambiguous at a given time, they can be reused by the Linux kernel, so they're
 not necessarily unambiguous over time.
 
+On Linux kernels 6.13 and later, [MountsOfNamespace] directly lists and stats
+the mounts of a mount namespace using the listmount(2) and statmount(2)
+syscalls, without the need for any process attached to the mount namespace.
+Mount points gathered this way additionally carry 64 bit mount IDs that never
+get reused, as well as the 64 bit ID of their mount namespace.
+
 # Hidden Mounts and Overmounts
 
 The terms “hidden mounts” and “overmounts” are used more or less synonymously to
@@ -56,9 +56,12 @@ type MountPath struct {
 // /proc/$PID/mountinfo), instead of mount paths.
 type MountPoint struct {
 	mntinfo.Mountinfo               // mount (point) information.
-	Hidden            bool          `json:"hidden"` // mount point hidden or "overmounted".
-	Parent            *MountPoint   `json:"-"`      // parent mount point, if its ID could be resolved.
-	Children          []*MountPoint `json:"-"`      // child mount points, derived from mount and parent IDs.
+	UniqueID          uint64        `json:"uniqueid,omitempty"`       // 64 bit mount ID, never reused; only from statmount(2).
+	UniqueParentID    uint64        `json:"uniqueparentid,omitempty"` // 64 bit mount ID of parent mount; only from statmount(2).
+	MountNamespaceID  uint64        `json:"mntnsid,omitempty"`        // 64 bit ID of the mount namespace; only from statmount(2).
+	Hidden            bool          `json:"hidden"`                   // mount point hidden or "overmounted".
+	Parent            *MountPoint   `json:"-"`                        // parent mount point, if its ID could be resolved.
+	Children          []*MountPoint `json:"-"`                        // child mount points, derived from mount and parent IDs.
 }
 
 // Path returns the path name of a [MountPath] object.
@@ -89,6 +92,19 @@ func (p MountPath) VisibleMount() *MountPoint {
 // two separate trees: one tree for the hierarchy of mount paths and a separate
 // tree for the mount point hierarchy.
 func NewMountPathMap(mounts []mntinfo.Mountinfo) (mountpathmap MountPathMap) {
+	mountpoints := make([]MountPoint, 0, len(mounts))
+	for _, mount := range mounts {
+		mountpoints = append(mountpoints, MountPoint{Mountinfo: mount})
+	}
+	return NewMountPathMapFromMountPoints(mountpoints)
+}
+
+// NewMountPathMapFromMountPoints works like [NewMountPathMap], but takes a
+// list of mount points, such as returned by [MountsOfNamespace], instead of
+// plain mount information. Only the mount information and unique IDs of the
+// passed mount points are used; their visibility and hierarchy are determined
+// afresh.
+func NewMountPathMapFromMountPoints(mounts []MountPoint) (mountpathmap MountPathMap) {
 	// Bail out immediately when there are no mounts to process. This may happen
 	// when reading the mount point information for a particular mount namespace
 	// failed.
@@ -106,7 +122,12 @@ func NewMountPathMap(mounts []mntinfo.Mountinfo) (mountpathmap MountPathMap) {
 			mpoint = &MountPath{}
 			mountpathmap[mount.MountPoint] = mpoint
 		}
-		mnt := &MountPoint{Mountinfo: mount}
+		mnt := &MountPoint{
+			Mountinfo:        mount.Mountinfo,
+			UniqueID:         mount.UniqueID,
+			UniqueParentID:   mount.UniqueParentID,
+			MountNamespaceID: mount.MountNamespaceID,
+		}
 		mpoint.Mounts = append(mpoint.Mounts, mnt)
 		mountidmap[mnt.MountID] = mnt
 	}
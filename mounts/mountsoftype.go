@@ -0,0 +1,121 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package mounts
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thediveo/go-mntinfo"
+	"golang.org/x/sys/unix"
+)
+
+// MountsOfType returns the mounts of the specified filesystem type as seen by
+// the process with the specified PID, where procroot is the path of the proc
+// filesystem to use. For the usual “/proc” this simply defers to go-mntinfo.
+//
+// As go-mntinfo only reads from “/proc”, MountsOfType otherwise opens the
+// mount namespace of the process from the proc filesystem at procroot and then
+// lists its mounts using [MountsOfNamespace]. On kernels lacking support for
+// listmount(2) and statmount(2) with mount namespace IDs, MountsOfType falls
+// back to reading the process' mountinfo from the proc filesystem at procroot,
+// but then only fills in the mount point, filesystem type, source, and super
+// options.
+func MountsOfType(procroot string, pid int, fstype string) []mntinfo.Mountinfo {
+	if procroot == "/proc" {
+		return mntinfo.MountsOfType(pid, fstype)
+	}
+	mountpoints, err := mountsOfProcess(procroot, pid)
+	if err != nil {
+		return mountinfoOfType(procroot, pid, fstype)
+	}
+	mounts := []mntinfo.Mountinfo{}
+	for _, mountpoint := range mountpoints {
+		if mountpoint.FsType == fstype {
+			mounts = append(mounts, mountpoint.Mountinfo)
+		}
+	}
+	return mounts
+}
+
+// mountsOfProcess returns the mount points of the mount namespace of the
+// process with the specified PID, using [MountsOfNamespace].
+func mountsOfProcess(procroot string, pid int) ([]MountPoint, error) {
+	mntnsfd, err := unix.Open(procroot+"/"+strconv.Itoa(pid)+"/ns/mnt",
+		unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = unix.Close(mntnsfd) }()
+	return MountsOfNamespace(mntnsfd)
+}
+
+// mountinfoOfType returns the mounts of the specified filesystem type as seen
+// by the process with the specified PID, reading them from the process'
+// mountinfo in the proc filesystem at procroot. It only fills in the mount
+// point, filesystem type, source, and super options.
+func mountinfoOfType(procroot string, pid int, fstype string) []mntinfo.Mountinfo {
+	mounts := []mntinfo.Mountinfo{}
+	f, err := os.Open(procroot + "/" + strconv.Itoa(pid) + "/mountinfo") // #nosec G304
+	if err != nil {
+		return mounts
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// See https://man7.org/linux/man-pages/man5/proc_pid_mountinfo.5.html:
+		// mount ID, parent ID, major:minor, root, mount point, mount options,
+		// optional fields, "-" separator, filesystem type, source, and finally
+		// super options.
+		fields := strings.Fields(scanner.Text())
+		sep := slices.Index(fields, "-")
+		if sep < 6 || len(fields) < sep+4 || fields[sep+1] != fstype {
+			continue
+		}
+		mounts = append(mounts, mntinfo.Mountinfo{
+			MountPoint:   unescapeMountinfo(fields[4]),
+			FsType:       fields[sep+1],
+			Source:       unescapeMountinfo(fields[sep+2]),
+			SuperOptions: fields[sep+3],
+		})
+	}
+	_ = scanner.Err()
+	return mounts
+}
+
+// unescapeMountinfo replaces the octal escape sequences in mount information
+// fields, such as "\040" for spaces, with the characters they represent.
+func unescapeMountinfo(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for idx := 0; idx < len(s); idx++ {
+		if s[idx] == '\\' && idx+3 < len(s) {
+			if ch, err := strconv.ParseUint(s[idx+1:idx+4], 8, 8); err == nil {
+				b.WriteByte(byte(ch))
+				idx += 3
+				continue
+			}
+		}
+		b.WriteByte(s[idx])
+	}
+	return b.String()
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package mounts
+
+import (
+	"os"
+	"time"
+
+	"github.com/thediveo/go-mntinfo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("mounts of type", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("reads mounts from the usual proc filesystem", func() {
+		Expect(MountsOfType("/proc", os.Getpid(), "proc")).To(
+			Equal(mntinfo.MountsOfType(os.Getpid(), "proc")))
+	})
+
+	It("reads mounts from a proc filesystem mounted elsewhere", func() {
+		mountPoints := func(mounts []mntinfo.Mountinfo) []string {
+			mountpoints := make([]string, 0, len(mounts))
+			for _, mount := range mounts {
+				mountpoints = append(mountpoints, mount.MountPoint)
+			}
+			return mountpoints
+		}
+		Expect(mountPoints(MountsOfType("/proc/self/root/proc", os.Getpid(), "proc"))).To(
+			ConsistOf(mountPoints(mntinfo.MountsOfType(os.Getpid(), "proc"))))
+		Expect(MountsOfType("/proc/self/root/proc", 0, "proc")).To(BeEmpty())
+		Expect(MountsOfType("/nonexisting", os.Getpid(), "proc")).To(BeEmpty())
+	})
+
+	It("falls back to reading mountinfo", func() {
+		Expect(mountinfoOfType("/proc", os.Getpid(), "proc")).To(ContainElement(
+			HaveField("MountPoint", "/proc")))
+		Expect(unescapeMountinfo(`/mnt/with\040space`)).To(Equal("/mnt/with space"))
+		Expect(unescapeMountinfo(`/mnt/trailing\04`)).To(Equal(`/mnt/trailing\04`))
+	})
+
+})
@@ -0,0 +1,343 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package mounts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/thediveo/go-mntinfo"
+	"golang.org/x/sys/unix"
+)
+
+// mntIDReq is the request passed to listmount(2) and statmount(2), see also
+// [include/uapi/linux/mount.h].
+//
+// [include/uapi/linux/mount.h]: https://elixir.bootlin.com/linux/v6.11/source/include/uapi/linux/mount.h
+type mntIDReq struct {
+	size    uint32
+	_       uint32
+	mntID   uint64 // mount to list the child mounts of, or to stat.
+	param   uint64 // listmount: last mount ID listed; statmount: request mask.
+	mntNsID uint64 // mount namespace to list or stat mounts in; since 6.11.
+}
+
+// listmount(2) pseudo mount ID for listing all mounts of a mount namespace.
+const lsmtRoot = ^uint64(0)
+
+// statmount(2) request mask bits.
+const (
+	statmountSbBasic    = 0x00000001
+	statmountMntBasic   = 0x00000002
+	statmountPropFrom   = 0x00000004
+	statmountMntRoot    = 0x00000008
+	statmountMntPoint   = 0x00000010
+	statmountFsType     = 0x00000020
+	statmountMntNsID    = 0x00000040
+	statmountMntOpts    = 0x00000080
+	statmountFsSubtype  = 0x00000100
+	statmountSbSource   = 0x00000200
+	statmountAllWeNeed  = statmountSbBasic | statmountMntBasic | statmountPropFrom | statmountMntRoot | statmountMntPoint | statmountFsType | statmountMntNsID | statmountMntOpts | statmountFsSubtype | statmountSbSource
+	statmountStrOffset  = 512 // size of the fixed part of struct statmount.
+	statmountBufferSize = 4096
+)
+
+// Offsets of the fields in struct statmount that we're interested in.
+const (
+	smSize           = 0
+	smMntOpts        = 4
+	smMask           = 8
+	smSbDevMajor     = 16
+	smSbDevMinor     = 20
+	smSbFlags        = 32
+	smFsType         = 36
+	smMntID          = 40
+	smMntParentID    = 48
+	smMntIDOld       = 56
+	smMntParentIDOld = 60
+	smMntAttr        = 64
+	smMntPropagation = 72
+	smMntPeerGroup   = 80
+	smMntMaster      = 88
+	smPropagateFrom  = 96
+	smMntRoot        = 104
+	smMntPoint       = 108
+	smMntNsID        = 112
+	smFsSubtype      = 120
+	smSbSource       = 124
+)
+
+// listmountBatchLen is the maximum number of mount IDs to ask listmount(2)
+// for in a single call.
+const listmountBatchLen = 512
+
+// ErrStatmountUnsupported indicates that the kernel lacks support for listing
+// and stat'ing mounts of a mount namespace using the listmount(2) and
+// statmount(2) syscalls.
+var ErrStatmountUnsupported = fmt.Errorf("listmount/statmount %w", errors.ErrUnsupported)
+
+// MountsOfNamespace returns the mount points of the mount namespace referenced
+// by the specified open file descriptor, using the listmount(2) and
+// statmount(2) syscalls. In contrast to reading “/proc/[PID]/mountinfo”, this
+// doesn't need any process attached to the mount namespace. Additionally, the
+// mount points returned carry their unique 64 bit mount IDs, as well as the
+// 64 bit ID of their mount namespace.
+//
+// On kernels before 6.11, MountsOfNamespace returns an error wrapping
+// [errors.ErrUnsupported], so callers need to fall back to reading
+// “/proc/[PID]/mountinfo” instead.
+//
+// The mount information is formatted in the same way as in
+// “/proc/[PID]/mountinfo”, so it can be used interchangeably.
+func MountsOfNamespace(mntnsfd int) ([]MountPoint, error) {
+	var mntnsid uint64
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(mntnsfd), // #nosec G115
+		uintptr(unix.NS_GET_MNTNS_ID), uintptr(unsafe.Pointer(&mntnsid)))
+	if errno != 0 {
+		if errno == unix.ENOTTY {
+			return nil, ErrStatmountUnsupported
+		}
+		return nil, errno
+	}
+	mntids, err := listMounts(mntnsid)
+	if err != nil {
+		return nil, err
+	}
+	mountpoints := make([]MountPoint, 0, len(mntids))
+	buf := make([]byte, statmountBufferSize)
+	for _, mntid := range mntids {
+		mountpoint, err := statMount(mntnsid, mntid, &buf)
+		if err != nil {
+			if errors.Is(err, unix.ENOENT) {
+				continue // mount has gone in the meantime.
+			}
+			return nil, err
+		}
+		mountpoints = append(mountpoints, mountpoint)
+	}
+	return mountpoints, nil
+}
+
+// listMounts returns the unique IDs of all mounts in the mount namespace with
+// the specified 64 bit ID.
+func listMounts(mntnsid uint64) ([]uint64, error) {
+	req := mntIDReq{
+		size:    uint32(unsafe.Sizeof(mntIDReq{})),
+		mntID:   lsmtRoot,
+		mntNsID: mntnsid,
+	}
+	var mntids []uint64
+	batch := make([]uint64, listmountBatchLen)
+	for {
+		n, _, errno := unix.Syscall6(unix.SYS_LISTMOUNT,
+			uintptr(unsafe.Pointer(&req)),
+			uintptr(unsafe.Pointer(&batch[0])), uintptr(len(batch)),
+			0, 0, 0)
+		if errno != 0 {
+			// Kernels before 6.11 don't know about mount namespace IDs
+			// in requests, so they reject our request as too big.
+			if errno == unix.ENOSYS || errno == unix.E2BIG {
+				return nil, ErrStatmountUnsupported
+			}
+			return nil, errno
+		}
+		mntids = append(mntids, batch[:n]...)
+		if n < uintptr(len(batch)) {
+			return mntids, nil
+		}
+		req.param = batch[n-1]
+	}
+}
+
+// statMount returns the mount point information for the mount with the
+// specified unique ID in the specified mount namespace. It (re)uses the
+// passed buffer, enlarging it when necessary.
+func statMount(mntnsid uint64, mntid uint64, buf *[]byte) (MountPoint, error) {
+	req := mntIDReq{
+		size:    uint32(unsafe.Sizeof(mntIDReq{})),
+		mntID:   mntid,
+		param:   statmountAllWeNeed,
+		mntNsID: mntnsid,
+	}
+	for {
+		_, _, errno := unix.Syscall6(unix.SYS_STATMOUNT,
+			uintptr(unsafe.Pointer(&req)),
+			uintptr(unsafe.Pointer(&(*buf)[0])), uintptr(len(*buf)),
+			0, 0, 0)
+		if errno == unix.EOVERFLOW {
+			*buf = make([]byte, 2*len(*buf))
+			continue
+		}
+		if errno != 0 {
+			return MountPoint{}, errno
+		}
+		return newMountPoint(*buf)
+	}
+}
+
+// newMountPoint returns the mount point information from the specified struct
+// statmount, formatted the same as in “/proc/[PID]/mountinfo”. It returns
+// [ErrStatmountUnsupported] if the struct statmount lacks the mount source, as
+// is the case with kernels before 6.13.
+func newMountPoint(sm []byte) (MountPoint, error) {
+	ne := binary.NativeEndian
+	mask := ne.Uint64(sm[smMask:])
+	if mask&statmountSbSource == 0 {
+		return MountPoint{}, ErrStatmountUnsupported
+	}
+	size := min(int(ne.Uint32(sm[smSize:])), len(sm))
+	str := func(bit uint64, offset int) string {
+		if mask&bit == 0 {
+			return ""
+		}
+		start := statmountStrOffset + int(ne.Uint32(sm[offset:]))
+		if start >= size {
+			return ""
+		}
+		s := sm[start:size]
+		if end := bytes.IndexByte(s, 0); end >= 0 {
+			s = s[:end]
+		}
+		return string(s)
+	}
+
+	fstype := str(statmountFsType, smFsType)
+	if subtype := str(statmountFsSubtype, smFsSubtype); subtype != "" {
+		fstype += "." + subtype
+	}
+	source := str(statmountSbSource, smSbSource)
+	if source == "" {
+		source = "none"
+	}
+	superopts := sbOptions(ne.Uint32(sm[smSbFlags:]))
+	if opts := str(statmountMntOpts, smMntOpts); opts != "" {
+		superopts += "," + opts
+	}
+	return MountPoint{
+		Mountinfo: mntinfo.Mountinfo{
+			MountID:      int(ne.Uint32(sm[smMntIDOld:])),
+			ParentID:     int(ne.Uint32(sm[smMntParentIDOld:])),
+			Major:        int(ne.Uint32(sm[smSbDevMajor:])),
+			Minor:        int(ne.Uint32(sm[smSbDevMinor:])),
+			Root:         escapeMountinfo(str(statmountMntRoot, smMntRoot)),
+			MountPoint:   escapeMountinfo(str(statmountMntPoint, smMntPoint)),
+			MountOptions: mntOptions(ne.Uint64(sm[smMntAttr:])),
+			Tags: propagationTags(
+				ne.Uint64(sm[smMntPropagation:]),
+				ne.Uint64(sm[smMntPeerGroup:]),
+				ne.Uint64(sm[smMntMaster:]),
+				ne.Uint64(sm[smPropagateFrom:])),
+			FsType:       escapeMountinfo(fstype),
+			Source:       escapeMountinfo(source),
+			SuperOptions: escapeMountinfo(superopts),
+		},
+		UniqueID:         ne.Uint64(sm[smMntID:]),
+		UniqueParentID:   ne.Uint64(sm[smMntParentID:]),
+		MountNamespaceID: ne.Uint64(sm[smMntNsID:]),
+	}, nil
+}
+
+// mntOptions returns the per-mount options corresponding with the specified
+// MOUNT_ATTR_* flags, in the same order as the kernel shows them in
+// “/proc/[PID]/mountinfo”.
+func mntOptions(attr uint64) []string {
+	opts := []string{"rw"}
+	if attr&unix.MOUNT_ATTR_RDONLY != 0 {
+		opts[0] = "ro"
+	}
+	for _, opt := range []struct {
+		mask uint64
+		attr uint64
+		name string
+	}{
+		{unix.MOUNT_ATTR_NOSUID, unix.MOUNT_ATTR_NOSUID, "nosuid"},
+		{unix.MOUNT_ATTR_NODEV, unix.MOUNT_ATTR_NODEV, "nodev"},
+		{unix.MOUNT_ATTR_NOEXEC, unix.MOUNT_ATTR_NOEXEC, "noexec"},
+		{unix.MOUNT_ATTR__ATIME, unix.MOUNT_ATTR_NOATIME, "noatime"},
+		{unix.MOUNT_ATTR_NODIRATIME, unix.MOUNT_ATTR_NODIRATIME, "nodiratime"},
+		{unix.MOUNT_ATTR__ATIME, unix.MOUNT_ATTR_RELATIME, "relatime"},
+		{unix.MOUNT_ATTR_NOSYMFOLLOW, unix.MOUNT_ATTR_NOSYMFOLLOW, "nosymfollow"},
+		{unix.MOUNT_ATTR_IDMAP, unix.MOUNT_ATTR_IDMAP, "idmapped"},
+	} {
+		if attr&opt.mask == opt.attr {
+			opts = append(opts, opt.name)
+		}
+	}
+	return opts
+}
+
+// sbOptions returns the generic superblock options corresponding with the
+// specified SB_* flags, in the same order as the kernel shows them in
+// “/proc/[PID]/mountinfo”.
+func sbOptions(flags uint32) string {
+	opts := "rw"
+	if flags&unix.MS_RDONLY != 0 {
+		opts = "ro"
+	}
+	if flags&unix.MS_SYNCHRONOUS != 0 {
+		opts += ",sync"
+	}
+	if flags&unix.MS_DIRSYNC != 0 {
+		opts += ",dirsync"
+	}
+	if flags&unix.MS_LAZYTIME != 0 {
+		opts += ",lazytime"
+	}
+	return opts
+}
+
+// propagationTags returns the optional “shared”, “master”, “propagate_from”,
+// and “unbindable” mountinfo fields.
+func propagationTags(propagation, peergroup, master, propagatefrom uint64) map[string]string {
+	tags := map[string]string{}
+	if propagation&unix.MS_SHARED != 0 {
+		tags["shared"] = strconv.FormatUint(peergroup, 10)
+	}
+	if propagation&unix.MS_SLAVE != 0 {
+		tags["master"] = strconv.FormatUint(master, 10)
+		if propagatefrom != 0 && propagatefrom != master {
+			tags["propagate_from"] = strconv.FormatUint(propagatefrom, 10)
+		}
+	}
+	if propagation&unix.MS_UNBINDABLE != 0 {
+		tags["unbindable"] = ""
+	}
+	return tags
+}
+
+// escapeMountinfo escapes spaces, tabs, newlines, and backslashes the same way
+// as the kernel does in “/proc/[PID]/mountinfo”.
+func escapeMountinfo(s string) string {
+	if !strings.ContainsAny(s, " \t\n\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\\':
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
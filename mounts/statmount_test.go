@@ -0,0 +1,88 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License. You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package mounts
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/thediveo/go-mntinfo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("statmount", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("escapes like mountinfo", func() {
+		Expect(escapeMountinfo("/a b\tc\nd\\e")).To(Equal(`/a\040b\011c\012d\134e`))
+		Expect(escapeMountinfo("/foo")).To(Equal("/foo"))
+	})
+
+	It("rejects mounts without source", func() {
+		sm := make([]byte, statmountStrOffset)
+		binary.NativeEndian.PutUint32(sm[smSize:], statmountStrOffset)
+		binary.NativeEndian.PutUint64(sm[smMask:], statmountAllWeNeed&^statmountSbSource)
+		_, err := newMountPoint(sm)
+		Expect(err).To(MatchError(errors.ErrUnsupported))
+
+		binary.NativeEndian.PutUint64(sm[smMask:], statmountAllWeNeed)
+		mountpoint, err := newMountPoint(sm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mountpoint.Source).To(Equal("none"))
+	})
+
+	It("lists the same mounts as mountinfo", func() {
+		f, err := os.Open("/proc/self/ns/mnt")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = f.Close() }()
+		mountpoints, err := MountsOfNamespace(int(f.Fd()))
+		if errors.Is(err, errors.ErrUnsupported) {
+			Skip("kernel doesn't support listmount/statmount with mount namespace IDs")
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		mountinfos := mntinfo.Mounts()
+		Expect(mountpoints).To(HaveLen(len(mountinfos)))
+		mntnsid := mountpoints[0].MountNamespaceID
+		Expect(mntnsid).NotTo(BeZero())
+		for _, mountinfo := range mountinfos {
+			Expect(mountpoints).To(ContainElement(And(
+				HaveField("Mountinfo", Equal(mountinfo)),
+				HaveField("UniqueID", Not(BeZero())),
+				HaveField("MountNamespaceID", mntnsid),
+			)), "mount %d at %s", mountinfo.MountID, mountinfo.MountPoint)
+		}
+
+		mpm := NewMountPathMapFromMountPoints(mountpoints)
+		Expect(mpm).To(HaveKey("/"))
+		Expect(mpm["/"].Mounts[0].UniqueID).NotTo(BeZero())
+	})
+
+})
@@ -8,8 +8,11 @@ defined in the sys/unix standard package.
     operations.
   - For background information on getting the network namespace of a TAP/TUN
     netdev please refer to [TUNGETDEVNETNS].
+  - See also [pidfd.h] for the pidfd ioctl operations returning the namespaces
+    of a process.
 
 [ioctl_ns(2)]: https://man7.org/linux/man-pages/man2/ioctl_ns.2.html
 [TUNGETDEVNETNS]: https://unix.stackexchange.com/a/743003
+[pidfd.h]: https://elixir.bootlin.com/linux/v6.11/source/include/uapi/linux/pidfd.h
 */
 package nsioctl
@@ -42,3 +42,25 @@ var (
 	// namespace of the TAP/TUN netdev.
 	TUNGETDEVNETNS = ioctl.IO('T', 227)
 )
+
+// Linux kernel [ioctl(2)] command for [pidfd namespace queries], available
+// since kernel 6.11.
+//
+// [ioctl(2)]: https://man7.org/linux/man-pages/man2/ioctl.2.html
+// [pidfd namespace queries]: https://elixir.bootlin.com/linux/v6.11/source/include/uapi/linux/pidfd.h
+const _PIDFS_IOCTL_MAGIC = 0xff
+
+// PIDFD_GET_*_NAMESPACE return a file descriptor that refers to a particular
+// type of namespace of the process referenced by a pidfd.
+var (
+	PIDFD_GET_CGROUP_NAMESPACE            = ioctl.IO(_PIDFS_IOCTL_MAGIC, 1)
+	PIDFD_GET_IPC_NAMESPACE               = ioctl.IO(_PIDFS_IOCTL_MAGIC, 2)
+	PIDFD_GET_MNT_NAMESPACE               = ioctl.IO(_PIDFS_IOCTL_MAGIC, 3)
+	PIDFD_GET_NET_NAMESPACE               = ioctl.IO(_PIDFS_IOCTL_MAGIC, 4)
+	PIDFD_GET_PID_NAMESPACE               = ioctl.IO(_PIDFS_IOCTL_MAGIC, 5)
+	PIDFD_GET_PID_FOR_CHILDREN_NAMESPACE  = ioctl.IO(_PIDFS_IOCTL_MAGIC, 6)
+	PIDFD_GET_TIME_NAMESPACE              = ioctl.IO(_PIDFS_IOCTL_MAGIC, 7)
+	PIDFD_GET_TIME_FOR_CHILDREN_NAMESPACE = ioctl.IO(_PIDFS_IOCTL_MAGIC, 8)
+	PIDFD_GET_USER_NAMESPACE              = ioctl.IO(_PIDFS_IOCTL_MAGIC, 9)
+	PIDFD_GET_UTS_NAMESPACE               = ioctl.IO(_PIDFS_IOCTL_MAGIC, 10)
+)
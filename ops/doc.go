@@ -36,6 +36,14 @@ uintptr respectively.
 	netns := NamespacePath("/proc/self/ns/net")
 	path := string(netns)
 
+On Linux kernels 6.18 and later, namespaces can also be referenced independent
+of any process, open file descriptor, or bind-mount using nsfs file handles.
+The textual form of such a [NamespaceHandle], as returned by
+[NamespaceHandle.Path], can be used as a [NamespacePath]:
+
+	nsh, _ := NewNamespaceHandle(fd)
+	netns := NamespacePath(nsh.Path()) // "nsfs:net:..."
+
 In case you want to use the [Visit] function for switching namespaces and you
 need to support Linux kernels before 4.11 (which lack a required ioctl) then you
 can resort to [TypedNamespacePath] instead of [NamespacePath].
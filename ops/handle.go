@@ -0,0 +1,148 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ops
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/species"
+)
+
+// NamespaceHandlePrefix is the prefix of namespace reference paths that are
+// actually nsfs file handles, in the textual form returned by
+// [NamespaceHandle.Path].
+const NamespaceHandlePrefix = "nsfs:"
+
+// Linux kernel nsfs file handle type and the special file descriptor to pass
+// to open_by_handle_at(2) for opening nsfs file handles, see also
+// [include/uapi/linux/fcntl.h] and [include/linux/exportfs.h].
+//
+// [include/uapi/linux/fcntl.h]: https://elixir.bootlin.com/linux/v6.18/source/include/uapi/linux/fcntl.h
+// [include/linux/exportfs.h]: https://elixir.bootlin.com/linux/v6.18/source/include/linux/exportfs.h
+const (
+	_FILEID_NSFS  = 0xf1
+	_FD_NSFS_ROOT = -10003
+)
+
+// nsfsFileHandleSize is the size of a “struct nsfs_file_handle” consisting of
+// the 64 bit namespace identifier, the namespace type, and the inode number.
+const nsfsFileHandleSize = 8 + 4 + 4
+
+// NamespaceHandle references a Linux-kernel namespace via an nsfs file handle.
+// In contrast to filesystem paths, nsfs file handles reference namespaces
+// independent of any process, open file descriptor, or bind-mount, and thus
+// can also reference namespaces that are only kept alive by, for instance,
+// child namespaces.
+//
+// The textual form of a NamespaceHandle, as returned by [NamespaceHandle.Path],
+// can be used as a [NamespacePath] reference.
+//
+// 🛈 A Linux kernel version 6.18 or later is required.
+type NamespaceHandle struct {
+	ID   uint64                // kernel-internal 64 bit namespace identifier.
+	Type species.NamespaceType // type of namespace.
+	Ino  uint32                // inode number of the namespace.
+}
+
+// NewNamespaceHandle returns the nsfs file handle for the namespace referenced
+// by the specified open file descriptor.
+func NewNamespaceHandle(fd int) (NamespaceHandle, error) {
+	fh, _, err := unix.NameToHandleAt(fd, "", unix.AT_EMPTY_PATH)
+	if err != nil {
+		return NamespaceHandle{}, err
+	}
+	if fh.Type() != _FILEID_NSFS || fh.Size() < nsfsFileHandleSize {
+		return NamespaceHandle{}, errors.New("not an nsfs file handle")
+	}
+	b := fh.Bytes()
+	return NamespaceHandle{
+		ID:   binary.NativeEndian.Uint64(b[0:8]),
+		Type: species.NamespaceType(binary.NativeEndian.Uint32(b[8:12])),
+		Ino:  binary.NativeEndian.Uint32(b[12:16]),
+	}, nil
+}
+
+// ParseNamespaceHandle parses the textual form of an nsfs file handle as
+// returned by [NamespaceHandle.Path]. It returns false if the specified path
+// isn't an nsfs file handle.
+func ParseNamespaceHandle(path string) (NamespaceHandle, bool) {
+	fields := strings.Split(strings.TrimPrefix(path, NamespaceHandlePrefix), ":")
+	if len(fields) != 3 || !strings.HasPrefix(path, NamespaceHandlePrefix) {
+		return NamespaceHandle{}, false
+	}
+	nstype := species.NameToType(fields[0])
+	if nstype == 0 {
+		return NamespaceHandle{}, false
+	}
+	id, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return NamespaceHandle{}, false
+	}
+	ino, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return NamespaceHandle{}, false
+	}
+	return NamespaceHandle{ID: id, Type: nstype, Ino: uint32(ino)}, true
+}
+
+// Path returns the textual form of this nsfs file handle in the form of
+// “nsfs:<type>:<id>:<inode>”, such as “nsfs:net:4711:4026531840”.
+func (nsh NamespaceHandle) Path() string {
+	return NamespaceHandlePrefix + nsh.Type.Name() +
+		":" + strconv.FormatUint(nsh.ID, 10) +
+		":" + strconv.FormatUint(uint64(nsh.Ino), 10)
+}
+
+// String returns the textual representation for a namespace reference by nsfs
+// file handle.
+func (nsh NamespaceHandle) String() string {
+	return "handle " + nsh.Path()
+}
+
+// Open opens the namespace referenced by this nsfs file handle, returning a
+// file descriptor. The caller is responsible for closing the file descriptor
+// when done.
+func (nsh NamespaceHandle) Open() (int, error) {
+	b := make([]byte, nsfsFileHandleSize)
+	binary.NativeEndian.PutUint64(b[0:8], nsh.ID)
+	binary.NativeEndian.PutUint32(b[8:12], uint32(nsh.Type)) // #nosec G115
+	binary.NativeEndian.PutUint32(b[12:16], nsh.Ino)
+	return unix.OpenByHandleAt(_FD_NSFS_ROOT,
+		unix.NewFileHandle(_FILEID_NSFS, b), unix.O_RDONLY|unix.O_CLOEXEC)
+}
+
+// openPath opens the namespace referenced by the specified path, which might
+// also be the textual form of an nsfs file handle.
+func openPath(path string) (int, error) {
+	if nsh, ok := ParseNamespaceHandle(path); ok {
+		return nsh.Open()
+	}
+	return unix.Open(path, unix.O_RDONLY|unix.O_CLOEXEC, 0)
+}
+
+// openPathFile is like openPath, but returns an *os.File instead.
+func openPathFile(path string) (*os.File, error) {
+	fd, err := openPath(path)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(fd), path), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ops
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/lxkns/species"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+)
+
+var _ = Describe("nsfs file handles", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).WithPolling(100 * time.Millisecond).
+				ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("parses only valid textual handles", func() {
+		nsh, ok := ParseNamespaceHandle("nsfs:net:42:4026531840")
+		Expect(ok).To(BeTrue())
+		Expect(nsh).To(Equal(NamespaceHandle{ID: 42, Type: species.CLONE_NEWNET, Ino: 4026531840}))
+		for _, path := range []string{
+			"/proc/self/ns/net",
+			"nsfs:",
+			"nsfs:net:42",
+			"nsfs:foo:42:4026531840",
+			"nsfs:net:-1:4026531840",
+			"nsfs:net:42:4294967296",
+			"net:42:4026531840",
+		} {
+			_, ok := ParseNamespaceHandle(path)
+			Expect(ok).To(BeFalse(), "path %q", path)
+		}
+	})
+
+	It("references a namespace by handle", func() {
+		fd, err := unix.Open("/proc/self/ns/net", unix.O_RDONLY|unix.O_CLOEXEC, 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = unix.Close(fd) }()
+		nsh, err := NewNamespaceHandle(fd)
+		if err != nil {
+			Skip("kernel doesn't support nsfs file handles")
+		}
+		Expect(nsh.Type).To(Equal(species.CLONE_NEWNET))
+		Expect(nsh.String()).To(HavePrefix("handle nsfs:net:"))
+
+		path := nsh.Path()
+		parsed, ok := ParseNamespaceHandle(path)
+		Expect(ok).To(BeTrue())
+		Expect(parsed).To(Equal(nsh))
+		netnsid, err := NamespacePath("/proc/self/ns/net").ID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(NamespacePath(path).ID()).To(Equal(netnsid))
+		Expect(NamespacePath(path).Type()).To(Equal(species.CLONE_NEWNET))
+		Expect(NewTypedNamespacePath(path, species.CLONE_NEWNET).ID()).To(Equal(netnsid))
+
+		_, err = NamespacePath(NamespaceHandle{ID: nsh.ID, Type: nsh.Type, Ino: nsh.Ino + 1}.Path()).ID()
+		Expect(err).To(MatchError(unix.ESTALE))
+	})
+
+})
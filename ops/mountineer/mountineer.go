@@ -125,6 +125,26 @@ func NewInContext(initialContextPID model.PIDType, ref model.NamespaceRef, usern
 	// Now work along the list of mount namespace references, switching contexts
 	// along the way as we make progress...
 	for idx, refpath := range ref {
+		// A (single) nsfs file handle reference doesn't need any context, so
+		// we can directly attach a pause task to it.
+		if _, ok := ops.ParseNamespaceHandle(refpath); ok {
+			if idx != 0 {
+				err = errors.New("invalid mount namespace " + ref[:idx+1].String() +
+					" reference in multi-ref context")
+				return
+			}
+			var sandbox Pauser
+			sandbox, err = newPauseTask(refpath)
+			if err != nil {
+				err = fmt.Errorf("sandbox failure, reason: %w", err)
+				return
+			}
+			pid = sandbox.PID()
+			m.pid = pid
+			m.sandbox = sandbox
+			m.contentsRoot = "/proc/" + strconv.FormatUint(uint64(pid), 10) + "/root"
+			continue
+		}
 		// Sanity check: empty and non-absolute reference paths are considered
 		// invalid.
 		if refpath == "" || refpath[0] != '/' {
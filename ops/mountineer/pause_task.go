@@ -22,6 +22,7 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/thediveo/lxkns/model"
+	"github.com/thediveo/lxkns/ops"
 )
 
 // newPauseTask starts a new pause task that immediately attaches itself
@@ -75,13 +76,13 @@ func (p *pauseTask) pause(mntnsref string) {
 		return
 	}
 
-	mntnsfd, err := unix.Open(mntnsref, unix.O_RDONLY, 0)
+	mntnsfd, closer, err := ops.NamespacePath(mntnsref).NsFd()
 	if err != nil {
 		p.outcome <- fmt.Errorf("invalid mount namespace reference, reason: %w", err)
 		return
 	}
 	err = unix.Setns(mntnsfd, unix.CLONE_NEWNS)
-	_ = unix.Close(mntnsfd)
+	closer()
 	if err != nil {
 		p.outcome <- fmt.Errorf("cannot join mount namespace using reference %q, reason: %w",
 			mntnsref, err)